@@ -0,0 +1,184 @@
+// Package boltbackend provides an embedded, file based storage backend for use with the storage package.
+// It uses bbolt as key/value store and does not need any external database server.
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	bolt "go.etcd.io/bbolt"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+var buckets = []string{
+	"champions",
+	"championdetails",
+	"freerotation",
+	"summonerspells",
+	"runesreforged",
+	"items",
+	"leagues",
+	"summonerleagues",
+	"summonerleaguesbyid",
+	"matches",
+	"matchesindex",
+	"timelines",
+	"summoners",
+	"summonersbyid",
+	"summonersbyaccountid",
+	"summonersbypuuid",
+	"championmasteries",
+	"championstats",
+	"championstatssummary",
+	"itemstats",
+	"summonerspellsstats",
+	"runesreforgedstats",
+	"gameversions",
+	"crawlfrontier",
+}
+
+// Backend represents the Bolt Backend
+type Backend struct {
+	config config.BoltBackend
+	log    *logrus.Entry
+	db     *bolt.DB
+}
+
+// NewBackend creates a new Bolt Backend
+func NewBackend(cfg config.BoltBackend) (*Backend, error) {
+	if len(cfg.File) == 0 {
+		return nil, fmt.Errorf("No database file specified for Bolt Backend")
+	}
+
+	b := &Backend{
+		log:    logging.Get("Bolt Storage Backend"),
+		config: cfg,
+	}
+
+	return b, nil
+}
+
+// Connect opens the database file and creates the buckets if necessary.
+// This method must be called before the Bolt Backend can be used.
+func (b *Backend) Connect() error {
+	if dir := filepath.Dir(b.config.File); len(dir) > 0 {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			b.log.Errorf("Error creating directory for Bolt database: %s", err)
+			return err
+		}
+	}
+
+	db, err := bolt.Open(b.config.File, 0600, &bolt.Options{Timeout: 5 * time.Second})
+	if err != nil {
+		b.log.Errorf("Error opening Bolt database %s: %s", b.config.File, err)
+		return err
+	}
+	b.db = db
+
+	err = b.checkBuckets()
+	if err != nil {
+		b.log.Errorf("Error checking buckets: %s", err)
+		return err
+	}
+
+	return nil
+}
+
+// Close closes the database file
+func (b *Backend) Close() error {
+	if b.db == nil {
+		return nil
+	}
+	return b.db.Close()
+}
+
+// checkBuckets makes sure all buckets needed exist
+func (b *Backend) checkBuckets() error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		for _, bucket := range buckets {
+			_, err := tx.CreateBucketIfNotExists([]byte(bucket))
+			if err != nil {
+				return fmt.Errorf("Error creating bucket %s: %s", bucket, err)
+			}
+		}
+		return nil
+	})
+}
+
+// key builds a bucket key out of its parts
+func key(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// prefix builds a key prefix out of its parts which only matches keys with exactly these leading parts
+func prefix(parts ...string) string {
+	return key(parts...) + "/"
+}
+
+// get decodes the value stored for key in bucket into v. It returns found = false when there is no such key.
+func (b *Backend) get(bucket, key string, v interface{}) (found bool, err error) {
+	err = b.db.View(func(tx *bolt.Tx) error {
+		data := tx.Bucket([]byte(bucket)).Get([]byte(key))
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, v)
+	})
+	return found, err
+}
+
+// put stores v for key in bucket, replacing an already existing value
+func (b *Backend) put(bucket, key string, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket([]byte(bucket)).Put([]byte(key), data)
+	})
+}
+
+// forEachWithPrefix calls fn for every key/value pair in bucket whose key starts with prefix
+func (b *Backend) forEachWithPrefix(bucket, prefix string, fn func(k, v []byte) error) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket([]byte(bucket)).Cursor()
+		p := []byte(prefix)
+		for k, v := c.Seek(p); k != nil && strings.HasPrefix(string(k), prefix); k, v = c.Next() {
+			if err := fn(k, v); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// count returns the number of keys in a bucket
+func (b *Backend) count(bucket string) (uint64, error) {
+	var n int
+	err := b.db.View(func(tx *bolt.Tx) error {
+		n = tx.Bucket([]byte(bucket)).Stats().KeyN
+		return nil
+	})
+	return uint64(n), err
+}
+
+// GetStorageSummary returns stats about the stored elements in the Backend
+func (b *Backend) GetStorageSummary() (storage.Summary, error) {
+	summary := storage.Summary{}
+
+	summary.NumberOfMatches, _ = b.count("matches")
+	summary.NumberOfChampions, _ = b.count("champions")
+	summary.NumberOfSummoners, _ = b.count("summoners")
+
+	return summary, nil
+}
@@ -0,0 +1,137 @@
+package boltbackend
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func newTestBackend(t *testing.T) (*Backend, func()) {
+	dir, err := ioutil.TempDir("", "boltbackend")
+	if err != nil {
+		t.Fatalf("Could not create temp dir: %s", err)
+	}
+
+	backend, err := NewBackend(config.BoltBackend{File: filepath.Join(dir, "test.db")})
+	if err != nil {
+		t.Fatalf("Could not get a new Bolt Backend: %s", err)
+	}
+	if err := backend.Connect(); err != nil {
+		t.Fatalf("Could not connect Bolt Backend: %s", err)
+	}
+
+	return backend, func() {
+		backend.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func TestCreatingNewBoltBackend(t *testing.T) {
+	if _, err := NewBackend(config.BoltBackend{}); err == nil {
+		t.Errorf("Expected error when creating Bolt Backend without file")
+	}
+
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	summary, err := backend.GetStorageSummary()
+	if err != nil {
+		t.Fatalf("Could not get storage summary: %s", err)
+	}
+	if summary != (storage.Summary{}) {
+		t.Errorf("Expected empty storage summary, got %v", summary)
+	}
+}
+
+func TestStoreAndRetrieveMatch(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	match := riotclient.MatchDTO{
+		GameID:       1234,
+		PlatformID:   "EUW1",
+		GameVersion:  "9.5.263.5124",
+		MapID:        11,
+		QueueID:      420,
+		Participants: []riotclient.ParticipantDTO{{ChampionID: 12}, {ChampionID: 42}},
+	}
+
+	if err := backend.StoreMatch(&match); err != nil {
+		t.Fatalf("Could not store match: %s", err)
+	}
+	if err := backend.StoreMatch(&match); err == nil {
+		t.Errorf("Expected error when storing the same match twice")
+	}
+
+	stored, err := backend.GetMatch("EUW1", 1234)
+	if err != nil {
+		t.Fatalf("Could not get match: %s", err)
+	}
+	if stored.GameID != match.GameID || stored.PlatformID != match.PlatformID || len(stored.Participants) != 2 {
+		t.Errorf("Stored match differs, got %v", stored)
+	}
+
+	if _, err := backend.GetMatch("EUW1", 123); err == nil {
+		t.Errorf("Expected error for unknown match")
+	}
+
+	cur, err := backend.GetMatchesCursorByGameVersionMapQueueID("", `9\.5\.`, 11, 420)
+	if err != nil {
+		t.Fatalf("Could not get cursor: %s", err)
+	}
+	defer cur.Close()
+
+	count := 0
+	for cur.Next() {
+		decoded := riotclient.MatchDTO{}
+		if err := cur.Decode(&decoded); err != nil {
+			t.Errorf("Could not decode match: %s", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected one match from cursor, got %d", count)
+	}
+
+	matches, err := backend.GetMatchesByGameVersionAndChampionID(`9\.5\.`, 13)
+	if err != nil {
+		t.Fatalf("Could not get matches: %s", err)
+	}
+	if len(matches.Matches) != 0 {
+		t.Errorf("Expected no matches for champion not played, got %d", len(matches.Matches))
+	}
+}
+
+func TestStoreSummonerReplacesOldEntries(t *testing.T) {
+	backend, cleanup := newTestBackend(t)
+	defer cleanup()
+
+	summoner := storage.Summoner{SummonerName: "oldname", SummonerID: "sid", AccountID: "aid", PUUID: "puuid"}
+	if err := backend.StoreSummoner(&summoner); err != nil {
+		t.Fatalf("Could not store summoner: %s", err)
+	}
+
+	summoner.SummonerName = "newname"
+	if err := backend.StoreSummoner(&summoner); err != nil {
+		t.Fatalf("Could not store summoner: %s", err)
+	}
+
+	if _, err := backend.GetSummonerByName("OldName"); err == nil {
+		t.Errorf("Expected old Summoner name to be removed")
+	}
+	stored, err := backend.GetSummonerByAccountID("aid")
+	if err != nil {
+		t.Fatalf("Could not get summoner by account id: %s", err)
+	}
+	if stored.SummonerName != "newname" {
+		t.Errorf("Expected newname, got %s", stored.SummonerName)
+	}
+	if summary, _ := backend.GetStorageSummary(); summary.NumberOfSummoners != 1 {
+		t.Errorf("Expected one stored Summoner, got %d", summary.NumberOfSummoners)
+	}
+}
@@ -0,0 +1,73 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetChampions gets the champions list from storage
+func (b *Backend) GetChampions() (riotclient.ChampionsList, error) {
+	championsList := make(riotclient.ChampionsList)
+
+	err := b.forEachWithPrefix("champions", "", func(k, v []byte) error {
+		champion := riotclient.Champion{}
+		err := json.Unmarshal(v, &champion)
+		if err != nil {
+			b.log.Warnln("Decode error ", err)
+			return nil
+		}
+		championsList[champion.ID] = champion
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	return championsList, nil
+}
+
+// GetChampionsTimeStamp gets the timestamp of the stored champions list
+func (b *Backend) GetChampionsTimeStamp() time.Time {
+	championList, err := b.GetChampions()
+	if err != nil {
+		b.log.Errorf("Error getting Champions for TimeStamp: %s", err)
+		return time.Time{}
+	}
+
+	if len(championList) == 0 {
+		return time.Time{}
+	}
+
+	// Find oldest champ time
+	oldest := time.Now()
+	for _, champion := range championList {
+		if oldest.Sub(champion.Timestamp) > 0 {
+			oldest = champion.Timestamp
+		}
+	}
+
+	return oldest
+}
+
+// StoreChampions stores a new champions list
+func (b *Backend) StoreChampions(championList riotclient.ChampionsList) error {
+	b.log.Debugf("Storing Champions in storage")
+
+	hadErrors := false
+	for _, champion := range championList {
+		err := b.put("champions", champion.Key, champion)
+		if err != nil {
+			b.log.Warnf("Error saving champion in DB: %s", err)
+			hadErrors = true
+		}
+	}
+
+	if hadErrors {
+		return fmt.Errorf("There were errors saving the champions in DB")
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetChampionDetails gets the full data of a champion for a game version and language from storage
+func (b *Backend) GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	championDetails := riotclient.ChampionDetails{}
+
+	found, err := b.get("championdetails", key(championID, gameVersion, language), &championDetails)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Details for Champion ID %s, GameVersion %s and Language %s: %s", championID, gameVersion, language, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Champion Details found for Champion ID %s, GameVersion %s and Language %s", championID, gameVersion, language)
+	}
+
+	return &championDetails, nil
+}
+
+// StoreChampionDetails stores the full data of a champion for a game version and language
+func (b *Backend) StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error {
+	err := b.put("championdetails", key(championDetails.ID, gameVersion, language), championDetails)
+	if err != nil {
+		return fmt.Errorf("Error saving Champion Details %s for gameversion %s and language %s in DB: %s", championDetails.ID, gameVersion, language, err)
+	}
+
+	return nil
+}
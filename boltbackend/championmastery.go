@@ -0,0 +1,40 @@
+package boltbackend
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetChampionMasteries returns the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteries(summonerID string) (*storage.ChampionMasteries, error) {
+	masteries := storage.ChampionMasteries{}
+
+	found, err := b.get("championmasteries", summonerID, &masteries)
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("Champion Masteries for Summoner ID %s not found in storage backend", summonerID)
+	}
+
+	return &masteries, nil
+}
+
+// GetChampionMasteriesTimeStamp gets the timestamp of the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteriesTimeStamp(summonerID string) time.Time {
+	masteries, err := b.GetChampionMasteries(summonerID)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return masteries.ChampionMasteryDTOList.Timestamp
+}
+
+// StoreChampionMasteries stores the Champion Masteries of a Summoner, replacing previously stored ones
+func (b *Backend) StoreChampionMasteries(masteries *storage.ChampionMasteries) error {
+	b.log.Debugf("Storing Champion Masteries for Summoner ID %s in storage", masteries.SummonerID)
+
+	return b.put("championmasteries", masteries.SummonerID, masteries)
+}
@@ -0,0 +1,14 @@
+package boltbackend
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/storage"
+	"git.abyle.org/hps/alolstats/storage/backendtest"
+)
+
+func TestConformance(t *testing.T) {
+	backendtest.Run(t, func(t *testing.T) (storage.Backend, func()) {
+		return newTestBackend(t)
+	})
+}
@@ -0,0 +1,61 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetCrawlEntry returns the crawl entry of an account in a region
+func (b *Backend) GetCrawlEntry(region string, accountID string) (*storage.CrawlEntry, error) {
+	entry := storage.CrawlEntry{}
+
+	found, err := b.get("crawlfrontier", key(region, accountID), &entry)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No crawl entry found for Account ID %s in region %s", accountID, region)
+	}
+
+	return &entry, nil
+}
+
+// GetCrawlEntries returns up to limit crawl entries of a region with at least minPriority which were not crawled since crawledBefore
+func (b *Backend) GetCrawlEntries(region string, minPriority int, crawledBefore time.Time, limit int) ([]storage.CrawlEntry, error) {
+	entries := []storage.CrawlEntry{}
+
+	err := b.forEachWithPrefix("crawlfrontier", prefix(region), func(k, v []byte) error {
+		entry := storage.CrawlEntry{}
+		if err := json.Unmarshal(v, &entry); err != nil {
+			return err
+		}
+		if entry.Priority >= minPriority && entry.LastCrawled.Before(crawledBefore) {
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return storage.CrawlEntryLess(entries[i], entries[j]) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+// StoreCrawlEntry stores a crawl entry, replacing the stored one of the same account and region
+func (b *Backend) StoreCrawlEntry(entry *storage.CrawlEntry) error {
+	err := b.put("crawlfrontier", key(entry.Region, entry.AccountID), entry)
+	if err != nil {
+		return fmt.Errorf("Error saving crawl entry for Account ID %s in region %s in DB: %s", entry.AccountID, entry.Region, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package boltbackend
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetFreeRotation gets the stored free champions rotation
+func (b *Backend) GetFreeRotation() (*riotclient.FreeRotation, error) {
+	freeRotation := riotclient.FreeRotation{}
+
+	_, err := b.get("freerotation", "freerotation", &freeRotation)
+	if err != nil {
+		b.log.Errorln("Decode error:", err)
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &freeRotation, nil
+}
+
+// GetFreeRotationTimeStamp gets the timestamp of the stored free champions rotation
+func (b *Backend) GetFreeRotationTimeStamp() time.Time {
+	freeRotation, err := b.GetFreeRotation()
+	if err != nil {
+		b.log.Errorf("Error getting Free Rotation for TimeStamp")
+		return time.Time{}
+	}
+
+	return freeRotation.Timestamp
+}
+
+// StoreFreeRotation stores a new free champions rotation list
+func (b *Backend) StoreFreeRotation(data *riotclient.FreeRotation) error {
+	b.log.Debugf("Storing Free Rotation in storage")
+
+	return b.put("freerotation", "freerotation", data)
+}
@@ -0,0 +1,86 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetMatchesCursorByGameVersion returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (b *Backend) GetMatchesCursorByGameVersion(platformID string, gameVersion string) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	keys, err := b.findMatchKeys(func(entry *matchIndexEntry) bool {
+		return entry.isOnPlatform(platformID) &&
+			versionMatcher.MatchString(entry.GameVersion)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s: %s", gameVersion, err)
+	}
+
+	return newMatchCursor(b.db, keys), nil
+}
+
+// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	keys, err := b.findMatchKeys(func(entry *matchIndexEntry) bool {
+		return entry.isOnPlatform(platformID) &&
+			versionMatcher.MatchString(entry.GameVersion) &&
+			uint64(entry.MapID) == mapID &&
+			uint64(entry.QueueID) <= ltequeue && uint64(entry.QueueID) >= gtequeue &&
+			entry.hasChampion(championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, championID, mapID, gtequeue, ltequeue, err)
+	}
+
+	return newMatchCursor(b.db, keys), nil
+}
+
+// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	keys, err := b.findMatchKeys(func(entry *matchIndexEntry) bool {
+		return entry.isOnPlatform(platformID) &&
+			versionMatcher.MatchString(entry.GameVersion) &&
+			uint64(entry.MapID) == mapID &&
+			uint64(entry.QueueID) <= ltequeue && uint64(entry.QueueID) >= gtequeue
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, mapID, gtequeue, ltequeue, err)
+	}
+
+	return newMatchCursor(b.db, keys), nil
+}
+
+// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue id
+func (b *Backend) GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueID uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	keys, err := b.findMatchKeys(func(entry *matchIndexEntry) bool {
+		return entry.isOnPlatform(platformID) &&
+			versionMatcher.MatchString(entry.GameVersion) &&
+			uint64(entry.MapID) == mapID &&
+			uint64(entry.QueueID) == queueID
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Map ID %d, Queue ID  %d: %s", gameVersion, mapID, queueID, err)
+	}
+
+	return newMatchCursor(b.db, keys), nil
+}
@@ -0,0 +1,57 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+type storedItem struct {
+	GameVersion string
+	Language    string
+
+	Key  uint16
+	Item riotclient.Item
+}
+
+// GetItems gets the items list from storage
+func (b *Backend) GetItems(gameVersion, language string) (riotclient.ItemList, error) {
+	itemList := make(riotclient.ItemList)
+
+	err := b.forEachWithPrefix("items", prefix(gameVersion, language), func(k, v []byte) error {
+		item := storedItem{}
+		err := json.Unmarshal(v, &item)
+		if err != nil {
+			b.log.Warnf("GetItems decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			return nil
+		}
+		itemList[item.Key] = item.Item
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetItems find error for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	return itemList, nil
+}
+
+// StoreItems stores a new items list
+func (b *Backend) StoreItems(gameVersion, language string, itemList riotclient.ItemList) error {
+	for _, item := range itemList {
+		itemForStorage := storedItem{
+			GameVersion: gameVersion,
+			Language:    language,
+			Key:         item.Key,
+			Item:        item,
+		}
+
+		err := b.put("items", key(gameVersion, language, strconv.Itoa(int(item.Key))), itemForStorage)
+		if err != nil {
+			return fmt.Errorf("Error saving Item %d for gameversion %s and language %s in DB: %s", item.Key, gameVersion, language, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetItemStatsByChampionIDGameVersionTierQueue returns all item stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetItemStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.ItemStatsStorage, error) {
+	stat := storage.ItemStatsStorage{}
+
+	found, err := b.get("itemstats", key(championID, gameVersion, tier, queue), &stat)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Items Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Champion Items Stats found for Champion ID %s, GameVersion %s, Tier %s and Queue %s", championID, gameVersion, tier, queue)
+	}
+
+	return &stat, nil
+}
+
+// StoreItemStats stores new item stats in storage
+func (b *Backend) StoreItemStats(data *storage.ItemStatsStorage) error {
+	return b.put("itemstats", key(data.ChampionID, data.GameVersion, data.Tier, data.Queue), data)
+}
@@ -0,0 +1,198 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// leagueTimeStampLayout is used for the timestamp part of league keys, it has a fixed width so that keys sort chronologically
+const leagueTimeStampLayout = "2006-01-02T15:04:05.000000000"
+
+// leagueKey returns the key of a league snapshot
+func leagueKey(league *storage.League) string {
+	return key(league.Region, strings.ToUpper(league.Tier), league.Queue, league.Timestamp.UTC().Format(leagueTimeStampLayout))
+}
+
+// leagueSnapshots calls fn for all stored snapshots of a league in chronological order
+func (b *Backend) leagueSnapshots(region string, tier string, queue string, fn func(league *storage.League) error) error {
+	return b.forEachWithPrefix("leagues", prefix(region, strings.ToUpper(tier), queue), func(k, v []byte) error {
+		league := storage.League{}
+		if err := json.Unmarshal(v, &league); err != nil {
+			return err
+		}
+		return fn(&league)
+	})
+}
+
+// GetLeagueByQueue returns the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueue(region string, tier string, queue string) (*storage.League, error) {
+	var newest *storage.League
+
+	err := b.leagueSnapshots(region, tier, queue, func(league *storage.League) error {
+		newest = league
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+	if newest == nil {
+		return nil, fmt.Errorf("League %s for queue %s in region %s not found in storage backend", tier, queue, region)
+	}
+
+	return newest, nil
+}
+
+// GetLeagueByQueueTimeStamp returns the timestamp of the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueueTimeStamp(region string, tier string, queue string) (time.Time, error) {
+	league, err := b.GetLeagueByQueue(region, tier, queue)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return league.Timestamp, nil
+}
+
+// GetLeagueHistoryByQueue returns all stored snapshots of a league identified by region, tier and queue name
+// which were taken between from and to (inclusive), sorted from oldest to newest
+func (b *Backend) GetLeagueHistoryByQueue(region string, tier string, queue string, from time.Time, to time.Time) ([]storage.League, error) {
+	leagues := []storage.League{}
+
+	err := b.leagueSnapshots(region, tier, queue, func(league *storage.League) error {
+		if !league.Timestamp.Before(from) && !league.Timestamp.After(to) {
+			leagues = append(leagues, *league)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return leagues, nil
+}
+
+// StoreLeague stores a new snapshot of a league. Older snapshots are kept to be able to look at the league history,
+// only a snapshot with the same timestamp is replaced.
+func (b *Backend) StoreLeague(league *storage.League) error {
+	b.log.Debugf("Storing League %s for queue %s in region %s in storage", league.Tier, league.Queue, league.Region)
+
+	stored := *league
+	stored.Tier = strings.ToUpper(stored.Tier)
+
+	return b.put("leagues", leagueKey(&stored), &stored)
+}
+
+func (b *Backend) leaguesForSummonerQuery(byID bool, id string) (*storage.SummonerLeagues, error) {
+	leagues := storage.SummonerLeagues{}
+	found := false
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		name := []byte(id)
+		if byID {
+			name = tx.Bucket([]byte("summonerleaguesbyid")).Get([]byte(id))
+			if name == nil {
+				return nil
+			}
+		}
+
+		data := tx.Bucket([]byte("summonerleagues")).Get(name)
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &leagues)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("Summoner League information not found in storage backend")
+	}
+
+	return &leagues, nil
+}
+
+// oldestLeagueTimeStamp returns the oldest timestamp of the stored Leagues of a Summoner
+func oldestLeagueTimeStamp(leagues *storage.SummonerLeagues) time.Time {
+	if len(leagues.LeaguePositionDTOList.LeaguePosition) == 0 {
+		return time.Time{}
+	}
+
+	oldest := time.Now()
+	for _, league := range leagues.LeaguePositionDTOList.LeaguePosition {
+		if oldest.Sub(league.Timestamp) > 0 {
+			oldest = league.Timestamp
+		}
+	}
+
+	return oldest
+}
+
+// GetLeaguesForSummoner returns the stores League information for a certain Summoner identified by its name
+func (b *Backend) GetLeaguesForSummoner(summonerName string) (*storage.SummonerLeagues, error) {
+	return b.leaguesForSummonerQuery(false, strings.ToLower(summonerName))
+}
+
+// GetLeaguesForSummonerTimeStamp gets the timestamp of the stored Leagues for Summoner idenfitied by name
+func (b *Backend) GetLeaguesForSummonerTimeStamp(summonerName string) (time.Time, error) {
+	leagues, err := b.GetLeaguesForSummoner(summonerName)
+	if err != nil {
+		b.log.Errorf("Error getting Leagues for Summoner %s for TimeStamp: %s", summonerName, err)
+		return time.Time{}, fmt.Errorf("Error getting Leagues for Summoner %s for TimeStamp: %s", summonerName, err)
+	}
+
+	return oldestLeagueTimeStamp(leagues), nil
+}
+
+// GetLeaguesForSummonerBySummonerID returns the stores League information for a certain Summoner identified by its Summoner ID
+func (b *Backend) GetLeaguesForSummonerBySummonerID(summonerID string) (*storage.SummonerLeagues, error) {
+	return b.leaguesForSummonerQuery(true, summonerID)
+}
+
+// GetLeaguesForSummonerBySummonerIDTimeStamp gets the timestamp of the stored Leagues for Summoner idenfitied by ID
+func (b *Backend) GetLeaguesForSummonerBySummonerIDTimeStamp(summonerID string) (time.Time, error) {
+	leagues, err := b.GetLeaguesForSummonerBySummonerID(summonerID)
+	if err != nil {
+		b.log.Errorf("Error getting Leagues for Summoner ID %s for TimeStamp: %s", summonerID, err)
+		return time.Time{}, fmt.Errorf("Error getting Leagues for Summoner ID %s for TimeStamp: %s", summonerID, err)
+	}
+
+	return oldestLeagueTimeStamp(leagues), nil
+}
+
+// StoreLeaguesForSummoner stores new Summoner League information data
+func (b *Backend) StoreLeaguesForSummoner(leagues *storage.SummonerLeagues) error {
+	b.log.Debugf("Storing Summoner %s in storage", leagues.SummonerName)
+
+	data, err := json.Marshal(leagues)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		summonerLeagues := tx.Bucket([]byte("summonerleagues"))
+		byID := tx.Bucket([]byte("summonerleaguesbyid"))
+
+		if len(leagues.SummonerID) == 0 {
+			return summonerLeagues.Put([]byte(leagues.SummonerName), data)
+		}
+
+		// Make sure we clean possible old entries for the same Summoner
+		if name := byID.Get([]byte(leagues.SummonerID)); name != nil {
+			if err := summonerLeagues.Delete(append([]byte(nil), name...)); err != nil {
+				return err
+			}
+		}
+
+		if err := summonerLeagues.Put([]byte(leagues.SummonerName), data); err != nil {
+			return err
+		}
+		return byID.Put([]byte(leagues.SummonerID), []byte(leagues.SummonerName))
+	})
+}
@@ -0,0 +1,207 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	bolt "go.etcd.io/bbolt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// matchIndexEntry holds the fields of a stored match which can be used to filter matches
+// without having to decode the whole match
+type matchIndexEntry struct {
+	PlatformID  string
+	GameVersion string
+	MapID       int
+	QueueID     int
+	ChampionIDs []int
+}
+
+func (e *matchIndexEntry) hasChampion(championID uint64) bool {
+	for _, id := range e.ChampionIDs {
+		if uint64(id) == championID {
+			return true
+		}
+	}
+	return false
+}
+
+// isOnPlatform returns true if the match was played on platformID, an empty platformID matches all platforms
+func (e *matchIndexEntry) isOnPlatform(platformID string) bool {
+	return len(platformID) == 0 || strings.EqualFold(e.PlatformID, platformID)
+}
+
+// matchKey returns the key of a match which is unique for game id and platform id
+func matchKey(gameID int64, platformID string) string {
+	return key(fmt.Sprintf("%020d", gameID), strings.ToUpper(platformID))
+}
+
+// gameVersionMatcher returns a matcher for game versions starting with gameVersion.
+// As with the MongoDB Backend, gameVersion is interpreted as a regular expression.
+func gameVersionMatcher(gameVersion string) (*regexp.Regexp, error) {
+	return regexp.Compile("^" + gameVersion)
+}
+
+// GetMatch retreives match data for given platform and id
+func (b *Backend) GetMatch(platformID string, id uint64) (*riotclient.MatchDTO, error) {
+	match := riotclient.MatchDTO{}
+
+	found, err := b.get("matches", matchKey(int64(id), platformID), &match)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("Match with id=%d on platform %s not found in storage backend", id, platformID)
+	}
+
+	return &match, nil
+}
+
+// GetMatchesCount returns the number of stored Matches in the Backend
+func (b *Backend) GetMatchesCount() (uint64, error) {
+	return b.count("matches")
+}
+
+// StoreMatch stores new match data
+func (b *Backend) StoreMatch(data *riotclient.MatchDTO) error {
+	b.log.Debugf("Storing Match id=%d in storage", data.GameID)
+
+	matchData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	indexEntry := matchIndexEntry{
+		PlatformID:  data.PlatformID,
+		GameVersion: data.GameVersion,
+		MapID:       data.MapID,
+		QueueID:     data.QueueID,
+	}
+	for _, participant := range data.Participants {
+		indexEntry.ChampionIDs = append(indexEntry.ChampionIDs, participant.ChampionID)
+	}
+	indexData, err := json.Marshal(indexEntry)
+	if err != nil {
+		return err
+	}
+
+	k := []byte(matchKey(data.GameID, data.PlatformID))
+	return b.db.Update(func(tx *bolt.Tx) error {
+		matches := tx.Bucket([]byte("matches"))
+		if matches.Get(k) != nil {
+			return fmt.Errorf("Match with id=%d and platform id %s already stored", data.GameID, data.PlatformID)
+		}
+
+		if err := matches.Put(k, matchData); err != nil {
+			return err
+		}
+		return tx.Bucket([]byte("matchesindex")).Put(k, indexData)
+	})
+}
+
+// findMatchKeys returns the keys of all stored matches for which filter returns true, in ascending game id order
+func (b *Backend) findMatchKeys(filter func(entry *matchIndexEntry) bool) ([][]byte, error) {
+	var keys [][]byte
+
+	err := b.forEachWithPrefix("matchesindex", "", func(k, v []byte) error {
+		entry := matchIndexEntry{}
+		if err := json.Unmarshal(v, &entry); err != nil {
+			b.log.Warnln("Decode error ", err)
+			return nil
+		}
+		if filter(&entry) {
+			keys = append(keys, append([]byte(nil), k...))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// findMatches returns all stored matches for which filter returns true
+func (b *Backend) findMatches(filter func(entry *matchIndexEntry) bool) (*riotclient.Matches, error) {
+	keys, err := b.findMatchKeys(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	matches := riotclient.Matches{Matches: make([]riotclient.MatchDTO, 0, len(keys))}
+
+	cur := newMatchCursor(b.db, keys)
+	defer cur.Close()
+	for cur.Next() {
+		match := riotclient.MatchDTO{}
+		err := cur.Decode(&match)
+		if err != nil {
+			b.log.Warnln("Decode error ", err)
+		}
+		matches.Matches = append(matches.Matches, match)
+	}
+
+	return &matches, nil
+}
+
+// GetMatchesByGameVersionAndChampionID returns all matches specific to a certain game version and champion id
+func (b *Backend) GetMatchesByGameVersionAndChampionID(gameVersion string, championID uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.findMatches(func(entry *matchIndexEntry) bool {
+		return versionMatcher.MatchString(entry.GameVersion) &&
+			entry.hasChampion(championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s and Champion ID %d: %s", gameVersion, championID, err)
+	}
+
+	return matches, nil
+}
+
+// GetMatchesByGameVersionChampionIDMapQueue returns all matches specific to a certain game version, champion id, map id and queue id
+func (b *Backend) GetMatchesByGameVersionChampionIDMapQueue(gameVersion string, championID uint64, mapID uint64, queueID uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.findMatches(func(entry *matchIndexEntry) bool {
+		return versionMatcher.MatchString(entry.GameVersion) &&
+			uint64(entry.MapID) == mapID &&
+			uint64(entry.QueueID) == queueID &&
+			entry.hasChampion(championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID %d: %s", gameVersion, championID, mapID, queueID, err)
+	}
+
+	return matches, nil
+}
+
+// GetMatchesByGameVersionChampionIDMapBetweenQueueIDs returns all matches specific to a certain game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.findMatches(func(entry *matchIndexEntry) bool {
+		return versionMatcher.MatchString(entry.GameVersion) &&
+			uint64(entry.MapID) == mapID &&
+			uint64(entry.QueueID) <= ltequeue && uint64(entry.QueueID) >= gtequeue &&
+			entry.hasChampion(championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, championID, mapID, gtequeue, ltequeue, err)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,66 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// MatchCursor is the cursor implementation for Match queries.
+// It holds the keys of the matching matches and loads one match at a time, so
+// no read transaction is kept open while the caller works through the results.
+type MatchCursor struct {
+	db      *bolt.DB
+	keys    [][]byte
+	current []byte
+}
+
+func newMatchCursor(db *bolt.DB, keys [][]byte) *MatchCursor {
+	return &MatchCursor{
+		db:   db,
+		keys: keys,
+	}
+}
+
+// Next gets the next result from the cursor.
+// Returns true if there were no errors and there is a next result.
+func (c *MatchCursor) Next() bool {
+	c.current = nil
+
+	for len(c.keys) > 0 {
+		k := c.keys[0]
+		c.keys = c.keys[1:]
+
+		err := c.db.View(func(tx *bolt.Tx) error {
+			if data := tx.Bucket([]byte("matches")).Get(k); data != nil {
+				c.current = append([]byte(nil), data...)
+			}
+			return nil
+		})
+		if err != nil {
+			return false
+		}
+
+		if c.current != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Decode decodes the current result
+func (c *MatchCursor) Decode(i interface{}) error {
+	if c.current == nil {
+		return fmt.Errorf("No current result to decode")
+	}
+	return json.Unmarshal(c.current, i)
+}
+
+// Close the cursor
+func (c *MatchCursor) Close() error {
+	c.keys = nil
+	c.current = nil
+	return nil
+}
@@ -0,0 +1,66 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+
+	bolt "go.etcd.io/bbolt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+type storedMatchTimeLine struct {
+	GameID      int64
+	QueueID     int
+	MapID       int
+	SeasonID    int
+	GameVersion string
+	GameMode    string
+	GameType    string
+	PlatformID  string
+	TimeLine    *riotclient.MatchTimelineDTO
+}
+
+// GetMatchTimeLine retreives the match timeline for given platform and id
+func (b *Backend) GetMatchTimeLine(platformID string, id uint64) (*riotclient.MatchTimelineDTO, error) {
+	timeline := storedMatchTimeLine{}
+
+	found, err := b.get("timelines", matchKey(int64(id), platformID), &timeline)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("Match TimeLine with id=%d on platform %s not found in storage backend", id, platformID)
+	}
+
+	return timeline.TimeLine, nil
+}
+
+// StoreMatchTimeLine stores the timeline of a match
+func (b *Backend) StoreMatchTimeLine(match *riotclient.MatchDTO, data *riotclient.MatchTimelineDTO) error {
+	b.log.Debugf("Storing Match TimeLine id=%d in storage", match.GameID)
+
+	storeData, err := json.Marshal(storedMatchTimeLine{
+		GameID:      match.GameID,
+		QueueID:     match.QueueID,
+		MapID:       match.MapID,
+		SeasonID:    match.SeasonID,
+		GameVersion: match.GameVersion,
+		GameMode:    match.GameMode,
+		GameType:    match.GameType,
+		PlatformID:  match.PlatformID,
+		TimeLine:    data,
+	})
+	if err != nil {
+		return err
+	}
+
+	k := []byte(matchKey(match.GameID, match.PlatformID))
+	return b.db.Update(func(tx *bolt.Tx) error {
+		timelines := tx.Bucket([]byte("timelines"))
+		if timelines.Get(k) != nil {
+			return fmt.Errorf("Match TimeLine with id=%d and platform id %s already stored", match.GameID, match.PlatformID)
+		}
+		return timelines.Put(k, storeData)
+	})
+}
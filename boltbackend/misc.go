@@ -0,0 +1,27 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetKnownGameVersions gets the stored known game versions
+func (b *Backend) GetKnownGameVersions() (*storage.GameVersions, error) {
+	gameVersions := storage.GameVersions{}
+
+	_, err := b.get("gameversions", "gameversions", &gameVersions)
+	if err != nil {
+		b.log.Errorln("Decode error:", err)
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &gameVersions, nil
+}
+
+// StoreKnownGameVersions stores a new list of known game versions
+func (b *Backend) StoreKnownGameVersions(data *storage.GameVersions) error {
+	b.log.Debugf("Storing known Game Versions in storage")
+
+	return b.put("gameversions", "gameversions", data)
+}
@@ -0,0 +1,57 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+type storedRunesReforged struct {
+	GameVersion string
+	Language    string
+
+	ID            int
+	RunesReforged riotclient.RunesReforgedSet
+}
+
+// GetRunesReforged gets the Runes Reforged list from storage
+func (b *Backend) GetRunesReforged(gameVersion, language string) (riotclient.RunesReforgedList, error) {
+	runesReforgedList := make(riotclient.RunesReforgedList)
+
+	err := b.forEachWithPrefix("runesreforged", prefix(gameVersion, language), func(k, v []byte) error {
+		runesReforged := storedRunesReforged{}
+		err := json.Unmarshal(v, &runesReforged)
+		if err != nil {
+			b.log.Warnf("GetRunesReforged decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			return nil
+		}
+		runesReforgedList[runesReforged.ID] = runesReforged.RunesReforged
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetRunesReforged find error for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	return runesReforgedList, nil
+}
+
+// StoreRunesReforged stores a new Runes Reforged list
+func (b *Backend) StoreRunesReforged(gameVersion, language string, runesReforgedList riotclient.RunesReforgedList) error {
+	for _, runesReforged := range runesReforgedList {
+		runesReforgedForStorage := storedRunesReforged{
+			GameVersion:   gameVersion,
+			Language:      language,
+			ID:            runesReforged.ID,
+			RunesReforged: runesReforged,
+		}
+
+		err := b.put("runesreforged", key(gameVersion, language, strconv.Itoa(runesReforged.ID)), runesReforgedForStorage)
+		if err != nil {
+			return fmt.Errorf("Error saving Runes Reforged %d for gameversion %s and language %s in DB: %s", runesReforged.ID, gameVersion, language, err)
+		}
+	}
+
+	return nil
+}
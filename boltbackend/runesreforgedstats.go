@@ -0,0 +1,27 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetRunesReforgedStatsByChampionIDGameVersionTierQueue returns all runes reforged stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetRunesReforgedStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.RunesReforgedStatsStorage, error) {
+	stat := storage.RunesReforgedStatsStorage{}
+
+	found, err := b.get("runesreforgedstats", key(championID, gameVersion, tier, queue), &stat)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Runes Reforged Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Runes Reforged Stats found for Champion ID %s, GameVersion %s and Tier %s", championID, gameVersion, tier)
+	}
+
+	return &stat, nil
+}
+
+// StoreRunesReforgedStats stores new runes reforged stats in storage
+func (b *Backend) StoreRunesReforgedStats(data *storage.RunesReforgedStatsStorage) error {
+	return b.put("runesreforgedstats", key(data.ChampionID, data.GameVersion, data.Tier, data.Queue), data)
+}
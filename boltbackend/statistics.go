@@ -0,0 +1,47 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetChampionStatsByChampionIDGameVersionTierQueue returns all stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetChampionStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.ChampionStatsStorage, error) {
+	stat := storage.ChampionStatsStorage{}
+
+	found, err := b.get("championstats", key(championID, gameVersion, tier, queue), &stat)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Champion Stats found for Champion ID %s, GameVersion %s and Tier %s", championID, gameVersion, tier)
+	}
+
+	return &stat, nil
+}
+
+// StoreChampionStats stores new champion stats in storage
+func (b *Backend) StoreChampionStats(data *storage.ChampionStatsStorage) error {
+	return b.put("championstats", key(data.ChampionID, data.GameVersion, data.Tier, data.Queue), data)
+}
+
+// GetChampionStatsSummaryByGameVersionTierQueue returns the stats summary for a specific game version, queue and tier
+func (b *Backend) GetChampionStatsSummaryByGameVersionTierQueue(gameVersion, tier, queue string) (*storage.ChampionStatsSummaryStorage, error) {
+	stat := storage.ChampionStatsSummaryStorage{}
+
+	found, err := b.get("championstatssummary", key(gameVersion, tier, queue), &stat)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Stats Summary for GameVersion %s, Queue %s and Tier %s: %s", gameVersion, queue, tier, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Champion Stats Summary found for GameVersion %s, Queue %s and Tier %s", gameVersion, queue, tier)
+	}
+
+	return &stat, nil
+}
+
+// StoreChampionStatsSummary stores a Champion Statistics Summary in the db
+func (b *Backend) StoreChampionStatsSummary(data *storage.ChampionStatsSummaryStorage) error {
+	return b.put("championstatssummary", key(data.GameVersion, data.Tier, data.Queue), data)
+}
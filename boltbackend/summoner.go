@@ -0,0 +1,170 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// summonerIndices maps the index buckets of the summoners bucket to the id they index
+var summonerIndices = map[string]func(s *storage.Summoner) string{
+	"summonersbyid":        func(s *storage.Summoner) string { return s.SummonerID },
+	"summonersbyaccountid": func(s *storage.Summoner) string { return s.AccountID },
+	"summonersbypuuid":     func(s *storage.Summoner) string { return s.PUUID },
+}
+
+func (b *Backend) summonerQuery(index string, id string) (*storage.Summoner, error) {
+	summoner := storage.Summoner{}
+	found := false
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		name := []byte(id)
+		if len(index) > 0 {
+			name = tx.Bucket([]byte(index)).Get([]byte(id))
+			if name == nil {
+				return nil
+			}
+		}
+
+		data := tx.Bucket([]byte("summoners")).Get(name)
+		if data == nil {
+			return nil
+		}
+		found = true
+		return json.Unmarshal(data, &summoner)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	if !found {
+		return nil, fmt.Errorf("Summoner not found in storage backend")
+	}
+
+	return &summoner, nil
+}
+
+// GetSummonerByNameTimeStamp gets the Timestamp when the data was stored for the Summoner specified by name
+func (b *Backend) GetSummonerByNameTimeStamp(name string) time.Time {
+	summoner, err := b.GetSummonerByName(name)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByName retrieves a summoner by name
+func (b *Backend) GetSummonerByName(name string) (*storage.Summoner, error) {
+	return b.summonerQuery("", strings.ToLower(name))
+}
+
+// GetSummonerBySummonerID retrieves a summoner identified by its Summoner ID
+func (b *Backend) GetSummonerBySummonerID(summonerID string) (*storage.Summoner, error) {
+	return b.summonerQuery("summonersbyid", summonerID)
+}
+
+// GetSummonerBySummonerIDTimeStamp retrieves a summoners time stamp by its Summoner ID
+func (b *Backend) GetSummonerBySummonerIDTimeStamp(summonerID string) time.Time {
+	summoner, err := b.GetSummonerBySummonerID(summonerID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByAccountID retrieves a summoner identified by its Account ID
+func (b *Backend) GetSummonerByAccountID(accountID string) (*storage.Summoner, error) {
+	return b.summonerQuery("summonersbyaccountid", accountID)
+}
+
+// GetSummonerByAccountIDTimeStamp retrieves a summoners time stamp by its Account ID
+func (b *Backend) GetSummonerByAccountIDTimeStamp(accountID string) time.Time {
+	summoner, err := b.GetSummonerByAccountID(accountID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByPUUID retrieves a summoner identified by its PUUID
+func (b *Backend) GetSummonerByPUUID(PUUID string) (*storage.Summoner, error) {
+	return b.summonerQuery("summonersbypuuid", PUUID)
+}
+
+// GetSummonerByPUUIDTimeStamp retrieves a summoners time stamp by its PUUID
+func (b *Backend) GetSummonerByPUUIDTimeStamp(PUUID string) time.Time {
+	summoner, err := b.GetSummonerByPUUID(PUUID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// deleteSummoner removes the Summoner stored under name together with its index entries
+func deleteSummoner(tx *bolt.Tx, name []byte) error {
+	summoners := tx.Bucket([]byte("summoners"))
+
+	data := summoners.Get(name)
+	if data == nil {
+		return nil
+	}
+
+	old := storage.Summoner{}
+	if err := json.Unmarshal(data, &old); err == nil {
+		for index, id := range summonerIndices {
+			if len(id(&old)) > 0 {
+				if err := tx.Bucket([]byte(index)).Delete([]byte(id(&old))); err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return summoners.Delete(name)
+}
+
+// StoreSummoner stores new Summoner data
+func (b *Backend) StoreSummoner(data *storage.Summoner) error {
+	b.log.Debugf("Storing Summoner %s in storage", data.SummonerName)
+
+	summonerData, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		// Make sure we clean possible old entries with same ids first
+		for index, id := range summonerIndices {
+			if len(id(data)) == 0 {
+				continue
+			}
+			if name := tx.Bucket([]byte(index)).Get([]byte(id(data))); name != nil {
+				if err := deleteSummoner(tx, append([]byte(nil), name...)); err != nil {
+					return err
+				}
+			}
+		}
+		if err := deleteSummoner(tx, []byte(data.SummonerName)); err != nil {
+			return err
+		}
+
+		if err := tx.Bucket([]byte("summoners")).Put([]byte(data.SummonerName), summonerData); err != nil {
+			return err
+		}
+		for index, id := range summonerIndices {
+			if len(id(data)) == 0 {
+				continue
+			}
+			if err := tx.Bucket([]byte(index)).Put([]byte(id(data)), []byte(data.SummonerName)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,56 @@
+package boltbackend
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+type storedSummonerSpell struct {
+	GameVersion string
+	Language    string
+
+	ID            string
+	SummonerSpell riotclient.SummonerSpell
+}
+
+// GetSummonerSpells gets the summoner spells list from storage
+func (b *Backend) GetSummonerSpells(gameVersion, language string) (riotclient.SummonerSpellsList, error) {
+	summonerSpellsList := make(riotclient.SummonerSpellsList)
+
+	err := b.forEachWithPrefix("summonerspells", prefix(gameVersion, language), func(k, v []byte) error {
+		summonerSpell := storedSummonerSpell{}
+		err := json.Unmarshal(v, &summonerSpell)
+		if err != nil {
+			b.log.Warnf("GetSummonerSpells decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			return nil
+		}
+		summonerSpellsList[summonerSpell.ID] = summonerSpell.SummonerSpell
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("GetSummonerSpells find error for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	return summonerSpellsList, nil
+}
+
+// StoreSummonerSpells stores a new summoner spells list
+func (b *Backend) StoreSummonerSpells(gameVersion, language string, summonerSpellsList riotclient.SummonerSpellsList) error {
+	for _, summonerSpell := range summonerSpellsList {
+		summonerSpellForStorage := storedSummonerSpell{
+			GameVersion:   gameVersion,
+			Language:      language,
+			ID:            summonerSpell.ID,
+			SummonerSpell: summonerSpell,
+		}
+
+		err := b.put("summonerspells", key(gameVersion, language, summonerSpell.ID), summonerSpellForStorage)
+		if err != nil {
+			return fmt.Errorf("Error saving Summoner Spell %s for gameversion %s and language %s in DB: %s", summonerSpell.ID, gameVersion, language, err)
+		}
+	}
+
+	return nil
+}
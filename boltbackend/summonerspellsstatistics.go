@@ -0,0 +1,27 @@
+package boltbackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetSummonerSpellsStatsByChampionIDGameVersionTierQueue returns all summoner spells stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetSummonerSpellsStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.SummonerSpellsStatsStorage, error) {
+	stat := storage.SummonerSpellsStatsStorage{}
+
+	found, err := b.get("summonerspellsstats", key(championID, gameVersion, tier, queue), &stat)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Summoner Spells Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+	if !found {
+		return nil, fmt.Errorf("No Summoner Spells Stats found for Champion ID %s, GameVersion %s, Tier %s and Queue %s", championID, gameVersion, tier, queue)
+	}
+
+	return &stat, nil
+}
+
+// StoreSummonerSpellsStats stores new summoner spells stats in storage
+func (b *Backend) StoreSummonerSpellsStats(data *storage.SummonerSpellsStatsStorage) error {
+	return b.put("summonerspellsstats", key(data.ChampionID, data.GameVersion, data.Tier, data.Queue), data)
+}
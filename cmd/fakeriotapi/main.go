@@ -0,0 +1,55 @@
+// Command fakeriotapi runs a local fake Riot API server which serves recorded JSON fixtures, so that
+// ALoLStats can be run without API key and network access. Point the BaseURL of a RiotClient in the
+// ALoLStats config to it, e.g., BaseURL = "http://127.0.0.1:8081".
+package main
+
+import (
+	"flag"
+	"net/http"
+	"strings"
+
+	"git.abyle.org/hps/alolstats/fakeriotapi"
+	"git.abyle.org/hps/alolstats/logging"
+)
+
+var listenAddress string
+var fixtureDir string
+var appRateLimit string
+var methodRateLimit string
+var keys string
+var loggingLevel string
+
+func init() {
+	flag.StringVar(&listenAddress, "a", "127.0.0.1:8081", "Address to listen on")
+	flag.StringVar(&fixtureDir, "d", "./fixtures", "Directory holding the JSON fixtures, e.g., ./fixtures/lol/match/v4/matches/3827449823.json")
+	flag.StringVar(&appRateLimit, "app-limit", "20:1,100:120", "Application Rate Limits per API key")
+	flag.StringVar(&methodRateLimit, "method-limit", "1000:10", "Method Rate Limits per API key and method")
+	flag.StringVar(&keys, "keys", "", "Comma separated list of accepted API keys, empty to accept all keys")
+	flag.StringVar(&loggingLevel, "l", "info", "Logging level (panic, fatal, error, warn/warning, info or debug)")
+	flag.Parse()
+}
+
+func main() {
+	logging.Init()
+	logging.SetLoggingLevel(loggingLevel)
+	log := logging.Get("main")
+
+	cfg := fakeriotapi.Config{
+		FixtureDir:      fixtureDir,
+		AppRateLimit:    appRateLimit,
+		MethodRateLimit: methodRateLimit,
+	}
+	for _, key := range strings.Split(keys, ",") {
+		if key = strings.TrimSpace(key); len(key) > 0 {
+			cfg.Keys = append(cfg.Keys, key)
+		}
+	}
+
+	server, err := fakeriotapi.New(cfg)
+	if err != nil {
+		log.Fatalln("Error creating the fake Riot API:", err)
+	}
+
+	log.Infof("Serving fake Riot API from %s on %s", fixtureDir, listenAddress)
+	log.Fatalln(http.ListenAndServe(listenAddress, server))
+}
@@ -9,14 +9,69 @@ type API struct {
 	Port string
 }
 
+// RetryPolicy holds the settings for retrying Riot API requests which failed with a certain class of errors
+type RetryPolicy struct {
+	// How often a request is tried at most, including the first try. 0 uses the default policy, 1 disables retries
+	MaxAttempts uint32
+	// Delay before the first retry in milliseconds, it is doubled for every further retry
+	BaseDelay uint32
+	// Maximum delay before a retry in milliseconds. 0 means unlimited
+	MaxDelay uint32
+	// Fraction of the delay which is randomized, e.g., 0.2 for +-20%
+	Jitter float64
+}
+
+// Cassette holds the settings for recording the HTTP exchanges of a Riot client with the Riot API and Data Dragon
+// to a file, or replaying them from it
+type Cassette struct {
+	// Mode is "record" to record the exchanges, "replay" to serve them from the file without network access,
+	// or empty to disable recording and replaying
+	Mode string
+	// File is the path of the cassette file
+	File string
+}
+
+// DataDragonCache holds the settings for keeping Data Dragon files on disk
+type DataDragonCache struct {
+	// Dir is the cache directory. Empty disables the cache.
+	Dir string
+	// RefreshInterval is how often the realm and the version list are downloaded again in minutes, default 60
+	RefreshInterval uint32
+	// SeedDir is an extracted dragontail archive whose files are copied into the cache on startup
+	SeedDir string
+}
+
 // RiotClient holds the settings specific for the Riot API
 type RiotClient struct {
 	// Riot developer API key used for API access
 	Key string
-	// Riot API version (v3, v4, ...)
+	// Further Riot API keys for the region. Requests are distributed over Key and Keys,
+	// every key has its own Rate Limits.
+	Keys []string
+	// Riot API version (v4 or v5)
 	APIVersion string
 	// Game region to use ("euw1", "eun1", ...)
 	Region string
+	// Base URL of the Riot API, e.g., http://localhost:8081 to run against a local fake Riot API server.
+	// Empty means the real Riot API of the region.
+	BaseURL string
+
+	// How many requests per Rate Limit method may be in flight at the same time (8 if 0).
+	// The Rate Limits are enforced regardless of it.
+	MaxConcurrentRequests uint32
+
+	// Retry policy for server errors (5xx)
+	RetryServerErrors RetryPolicy
+	// Retry policy for transport errors, e.g., failed connections and timeouts
+	RetryTransportErrors RetryPolicy
+	// Retry policy for rate limited requests (429). The delays add to the wait time given by the Rate Limits.
+	RetryRateLimited RetryPolicy
+
+	// Record or replay the HTTP exchanges of the client
+	Cassette Cassette
+
+	// Keep Data Dragon files on disk, so that they are not downloaded again and can be used when Data Dragon is unreachable
+	DataDragonCache DataDragonCache
 }
 
 // MongoBackend holds the settings for the mongodb backend
@@ -27,12 +82,19 @@ type MongoBackend struct {
 	Database string
 }
 
+// BoltBackend holds the settings for the embedded bolt backend
+type BoltBackend struct {
+	// File is the path to the database file, it is created if it does not exist
+	File string
+}
+
 // StorageBackend holds the settings for the used backend component
 type StorageBackend struct {
-	// Name of the storage backend to use (e.g., mongo)
+	// Name of the storage backend to use (e.g., mongo, bolt or memory)
 	Backend string
 
 	MongoBackend MongoBackend
+	BoltBackend  BoltBackend
 }
 
 // LoLStorage holds the settings specific for the storage component
@@ -45,6 +107,9 @@ type LoLStorage struct {
 	MatchFileDir string
 	// Specified the maximum age for champion data in minutes until it's invalidated. 0 means it is always fetched newly.
 	MaxAgeChampion uint32
+	// Stored static data, e.g., imported from a dragontail archive, never expires. Champions are only fetched from the Riot client if
+	// they are not stored or an update is forced.
+	OfflineStaticData bool
 	// Specified the maximum age for free champion rotation data in minutes until it's invalidated. 0 means it is always fetched newly.
 	MaxAgeChampionRotation uint32
 	// Specified the maximum age for summoner data in minutes until it's invalidated. 0 means it is always fetched newly.
@@ -53,6 +118,10 @@ type LoLStorage struct {
 	MaxAgeSummonerSpells uint32
 	// Specified the maximum age for items data in minutes until it's invalidated. 0 means it is always fetched newly.
 	MaxAgeItems uint32
+	// Specified the maximum age for league data in minutes until it's invalidated. 0 means it is always fetched newly.
+	MaxAgeLeague uint32
+	// Specified the maximum age for champion mastery data in minutes until it's invalidated. 0 means it is always fetched newly.
+	MaxAgeChampionMastery uint32
 	// Specifies a default RiotClient for use if not otherwise specified in requests or function calls
 	DefaultRiotClient string
 }
@@ -79,9 +148,37 @@ type FetchRunner struct {
 	// How many of the last matches shall be checked/pulled per account. 0 means all of them
 	FetchMatchesForLeaguesNumber uint64
 
+	// Specifies the tiers for which a sample of Summoners shall be drawn from the league entries, e.g., "DIAMOND", "GOLD", "IRON".
+	// The queues are taken from FetchMatchesForLeagueQueues, the number of matches per account from FetchMatchesForLeaguesNumber.
+	FetchMatchesForTiers []string
+	// Specifies the divisions which shall be sampled per tier. Allowed are "I", "II", "III", "IV", all of them if empty
+	FetchMatchesForTierDivisions []string
+	// How many Summoners shall be sampled per queue, tier and division. 0 disables the sampling
+	FetchMatchesForTiersSampleSize uint32
+	// How many pages of league entries (about 200 per page) shall be read per queue, tier and division to draw the sample from (at least 1)
+	FetchMatchesForTiersPages uint32
+	// How many matches per tier and queue of the game version LatestGameVersionForFetching shall be fetched. Summoners seen in fetched
+	// matches whose tier has not reached it yet are crawled first and matches are no longer fetched for a tier once it is reached. 0 disables it
+	FetchMatchesPerTierTarget uint32
+
 	// Specifies if for Summoners encountered in fetched matches an additional fetch run shall be performed (warning, can take a while)
 	FetchMatchesForSeenSummoners bool
 
+	// How many Summoners are read at once from the crawl frontier stored in the backend (default 100)
+	FetchMatchesCrawlBatchSize uint32
+	// How many workers download the listed matches and their timelines at the same time (default 8). They are fed from the
+	// match lists of all crawled Summoners, so that the throughput is limited by the Rate Limits and MaxConcurrentRequests of the RiotClient.
+	FetchMatchesConcurrency uint32
+
+	// Specifies the queue ids of the matches which shall be fetched. If empty 400, 420, 430 and 440, the queues analyzed by the StatsRunner
+	FetchMatchesQueueIDs []int
+	// Specifies the map ids of the matches which shall be stored, e.g., 11 for Summoner's Rift. All maps if empty
+	FetchMatchesMapIDs []int
+	// Specifies the season ids of the matches which shall be fetched, e.g., 13. All seasons if empty. With API v4 the match lists are filtered by them
+	FetchMatchesSeasonIDs []int
+	// Only matches created on or after this date are fetched, e.g., "2019-01-23". No restriction if empty
+	FetchMatchesMinGameCreation string
+
 	// If true stops fetching matches for a summoner if it encounters a game version != latest known game version
 	FetchOnlyLatestGameVersion bool
 
@@ -130,6 +227,7 @@ type StatsRunner struct {
 	RScriptsUpdateInterval uint32 // Update Interval for running the R scripts in minutes > 0
 
 	GameVersion []string // We want to do stats calculations for the following versions, must be valid game versions, ordered decending, e.g. 9.5, 9.4, ..., see https://ddragon.leagueoflegends.com/api/versions.json, e.g., 9.1.1, 8.24.1
+	PlatformID  string   // Only use matches from this platform for the stats calculations, e.g., EUW1. Empty means all platforms.
 
 	ChampionsStats      ChampionsStats      // ChampionsStats worker settings
 	ItemsStats          ItemsStats          // ItemsStats worker settings
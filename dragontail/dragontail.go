@@ -0,0 +1,186 @@
+// Package dragontail imports the static data of a Data Dragon "dragontail" archive into a storage backend,
+// so that ALoLStats can work without ever contacting Data Dragon.
+// See https://developer.riotgames.com/docs/lol#data-dragon_data-assets for where to get the archives.
+package dragontail
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclientv4"
+
+	riotclientdd "git.abyle.org/hps/alolstats/riotclient/datadragon"
+)
+
+// Backend is the part of the storage backend used to store the imported data
+type Backend interface {
+	StoreChampions(championsList riotclient.ChampionsList) error
+	StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error
+	StoreItems(gameVersion, language string, itemsList riotclient.ItemList) error
+	StoreRunesReforged(gameVersion, language string, runesReforgedList riotclient.RunesReforgedList) error
+	StoreSummonerSpells(gameVersion, language string, summonerSpellsList riotclient.SummonerSpellsList) error
+}
+
+// Summary tells what was imported from an archive
+type Summary struct {
+	// Versions are the imported game versions, newest first
+	Versions []string
+	// Languages are the imported languages
+	Languages []string
+	// Files is the number of imported files
+	Files int
+	// ChampionsVersion is the game version of the imported champions, empty if they were not found
+	ChampionsVersion string
+}
+
+// ImportFile imports the dragontail .tgz archive at filePath, see Import
+func ImportFile(filePath string, version string, championsLanguage string, backend Backend) (*Summary, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Import(file, version, championsLanguage, backend)
+}
+
+// Import reads a gzipped dragontail tar archive and stores the champion details, items, runes reforged and summoner spells
+// of all languages in the backend. Only game version version is imported, all versions in the archive if it is empty. The backend holds
+// only one list of champions, so the champions of the newest version in championsLanguage are stored.
+func Import(r io.Reader, version string, championsLanguage string, backend Backend) (*Summary, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading dragontail archive: %s", err)
+	}
+	defer gz.Close()
+
+	summary := &Summary{}
+	versions := make(map[string]bool)
+	languages := make(map[string]bool)
+	var champions riotclient.ChampionsList
+
+	archive := tar.NewReader(gz)
+	for {
+		header, err := archive.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return summary, fmt.Errorf("Error reading dragontail archive: %s", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		fileVersion, language, asset, ok := splitDataPath(header.Name)
+		if !ok || (len(version) > 0 && fileVersion != version) {
+			continue
+		}
+		if asset == "champion" && (language != championsLanguage ||
+			(len(summary.ChampionsVersion) > 0 && !riotclientdd.NewerVersion(fileVersion, summary.ChampionsVersion))) {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(archive)
+		if err != nil {
+			return summary, fmt.Errorf("Error reading %s from dragontail archive: %s", header.Name, err)
+		}
+
+		switch asset {
+		case "champion":
+			// A newer version may come later in the archive, so the champions are counted once they are stored
+			champions, err = riotclientv4.ParseChampions(data)
+			if err != nil {
+				return summary, fmt.Errorf("Error importing %s: %s", header.Name, err)
+			}
+			summary.ChampionsVersion = fileVersion
+			continue
+		case "item":
+			var items *riotclient.ItemList
+			if items, err = riotclientv4.ParseItems(data); err == nil {
+				err = backend.StoreItems(fileVersion, language, *items)
+			}
+		case "runesReforged":
+			var runes *riotclient.RunesReforgedList
+			if runes, err = riotclientv4.ParseRunesReforged(data); err == nil {
+				err = backend.StoreRunesReforged(fileVersion, language, *runes)
+			}
+		case "summoner":
+			var spells *riotclient.SummonerSpellsList
+			if spells, err = riotclientv4.ParseSummonerSpells(data); err == nil {
+				err = backend.StoreSummonerSpells(fileVersion, language, *spells)
+			}
+		default:
+			var championDetails *riotclient.ChampionDetails
+			if championDetails, err = riotclientv4.ParseChampionDetails(data); err == nil {
+				err = backend.StoreChampionDetails(fileVersion, language, championDetails)
+			}
+		}
+		if err != nil {
+			return summary, fmt.Errorf("Error importing %s: %s", header.Name, err)
+		}
+
+		summary.Files++
+		versions[fileVersion] = true
+		languages[language] = true
+	}
+
+	if champions != nil {
+		if err := backend.StoreChampions(champions); err != nil {
+			return summary, fmt.Errorf("Error importing champions: %s", err)
+		}
+		summary.Files++
+		versions[summary.ChampionsVersion] = true
+		languages[championsLanguage] = true
+	}
+
+	for v := range versions {
+		summary.Versions = append(summary.Versions, v)
+	}
+	sort.Slice(summary.Versions, func(i, j int) bool { return riotclientdd.NewerVersion(summary.Versions[i], summary.Versions[j]) })
+	for l := range languages {
+		summary.Languages = append(summary.Languages, l)
+	}
+	sort.Strings(summary.Languages)
+
+	if summary.Files == 0 {
+		return summary, fmt.Errorf("No data files found in dragontail archive")
+	}
+
+	return summary, nil
+}
+
+// splitDataPath splits a path in the archive like 9.10.1/data/en_US/item.json into version, language and asset.
+// Champion details like 9.10.1/data/en_US/champion/Annie.json have the asset champion/Annie.
+// It returns false for all other files.
+func splitDataPath(name string) (version, language, asset string, ok bool) {
+	parts := strings.Split(path.Clean(strings.TrimPrefix(name, "./")), "/")
+	if len(parts) >= 5 && parts[len(parts)-2] == "champion" {
+		parts = parts[len(parts)-5:]
+		if parts[1] == "data" && strings.HasSuffix(parts[4], ".json") {
+			return parts[0], parts[2], "champion/" + strings.TrimSuffix(parts[4], ".json"), true
+		}
+	}
+	if len(parts) < 4 {
+		return "", "", "", false
+	}
+	parts = parts[len(parts)-4:]
+	if parts[1] != "data" || !strings.HasSuffix(parts[3], ".json") {
+		return "", "", "", false
+	}
+
+	asset = strings.TrimSuffix(parts[3], ".json")
+	switch asset {
+	case "champion", "item", "runesReforged", "summoner":
+		return parts[0], parts[2], asset, true
+	}
+	return "", "", "", false
+}
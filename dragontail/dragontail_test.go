@@ -0,0 +1,167 @@
+package dragontail
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"reflect"
+	"sort"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/memorybackend"
+)
+
+// archive returns a .tgz containing the given files sorted by name, so that the order of the entries is deterministic
+func archive(t *testing.T, files map[string]string) *bytes.Buffer {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return archiveInOrder(t, names, files)
+}
+
+// archiveInOrder returns a .tgz containing the given files in the order of names
+func archiveInOrder(t *testing.T, names []string, files map[string]string) *bytes.Buffer {
+	buf := &bytes.Buffer{}
+	gz := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gz)
+	for _, name := range names {
+		content := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}); err != nil {
+			t.Fatalf("Could not write archive: %s", err)
+		}
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	gz.Close()
+	return buf
+}
+
+func testFiles() map[string]string {
+	files := map[string]string{
+		"9.10.1/img/champion/Annie.png":      "png",
+		"9.10.1/data/en_US/profileicon.json": `{}`,
+	}
+	for _, version := range []string{"9.9.1", "9.10.1"} {
+		for _, language := range []string{"en_US", "de_DE"} {
+			prefix := "./" + version + "/data/" + language + "/"
+			files[prefix+"champion.json"] = `{"data":{"Annie":{"id":"Annie","key":"1","name":"Annie ` + version + ` ` + language + `"}}}`
+			files[prefix+"item.json"] = `{"data":{"1001":{"name":"Boots ` + language + `"}}}`
+			files[prefix+"runesReforged.json"] = `{"0":{"id":8100,"key":"Domination"}}`
+			files[prefix+"summoner.json"] = `{"data":{"SummonerFlash":{"id":"SummonerFlash","key":"4","name":"Flash"}}}`
+			files[prefix+"champion/Annie.json"] = `{"version":"` + version + `","data":{"Annie":{"id":"Annie","key":"1","lore":"` + language + `","spells":[{"id":"Disintegrate","cooldown":[4,4,4,4,4]}],"passive":{"name":"Pyromania"}}}}`
+		}
+	}
+	return files
+}
+
+func TestImport(t *testing.T) {
+	backend, _ := memorybackend.NewBackend()
+
+	summary, err := Import(archive(t, testFiles()), "", "en_US", backend)
+	if err != nil {
+		t.Fatalf("Import() returned error: %s", err)
+	}
+	want := &Summary{Versions: []string{"9.10.1", "9.9.1"}, Languages: []string{"de_DE", "en_US"}, Files: 17, ChampionsVersion: "9.10.1"}
+	if !reflect.DeepEqual(summary, want) {
+		t.Errorf("Import() = %+v, want %+v", summary, want)
+	}
+
+	items, err := backend.GetItems("9.9.1", "de_DE")
+	if err != nil || items[1001].Name != "Boots de_DE" {
+		t.Errorf("GetItems() = %+v, %v", items, err)
+	}
+	if runes, err := backend.GetRunesReforged("9.10.1", "en_US"); err != nil || len(runes) != 1 {
+		t.Errorf("GetRunesReforged() = %+v, %v", runes, err)
+	}
+	if spells, err := backend.GetSummonerSpells("9.10.1", "de_DE"); err != nil || spells["SummonerFlash"].Name != "Flash" {
+		t.Errorf("GetSummonerSpells() = %+v, %v", spells, err)
+	}
+	if champions, err := backend.GetChampions(); err != nil || champions["Annie"].Name != "Annie 9.10.1 en_US" {
+		t.Errorf("GetChampions() = %+v, %v", champions, err)
+	}
+	championDetails, err := backend.GetChampionDetails("Annie", "9.9.1", "de_DE")
+	if err != nil || championDetails.Lore != "de_DE" || championDetails.Version != "9.9.1" || championDetails.Spells[0].Cooldown[0] != 4 {
+		t.Errorf("GetChampionDetails() = %+v, %v", championDetails, err)
+	}
+}
+
+func TestImport_championsReplaced(t *testing.T) {
+	files := testFiles()
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	// The champions of 9.9.1 come first and are replaced by the ones of 9.10.1
+	sort.Sort(sort.Reverse(sort.StringSlice(names)))
+
+	backend, _ := memorybackend.NewBackend()
+	summary, err := Import(archiveInOrder(t, names, files), "", "en_US", backend)
+	if err != nil {
+		t.Fatalf("Import() returned error: %s", err)
+	}
+	if summary.Files != 17 || summary.ChampionsVersion != "9.10.1" {
+		t.Errorf("Import() = %+v, want 17 files and champions of 9.10.1", summary)
+	}
+	if champions, err := backend.GetChampions(); err != nil || champions["Annie"].Name != "Annie 9.10.1 en_US" {
+		t.Errorf("GetChampions() = %+v, %v", champions, err)
+	}
+}
+
+func TestImport_version(t *testing.T) {
+	backend, _ := memorybackend.NewBackend()
+
+	summary, err := Import(archive(t, testFiles()), "9.9.1", "de_DE", backend)
+	if err != nil {
+		t.Fatalf("Import() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(summary.Versions, []string{"9.9.1"}) || summary.Files != 9 {
+		t.Errorf("Import() = %+v, want only 9 files of 9.9.1", summary)
+	}
+	if items, _ := backend.GetItems("9.10.1", "en_US"); len(items) > 0 {
+		t.Errorf("Items of 9.10.1 were imported")
+	}
+	if champions, err := backend.GetChampions(); err != nil || champions["Annie"].Name != "Annie 9.9.1 de_DE" {
+		t.Errorf("GetChampions() = %+v, %v", champions, err)
+	}
+
+	if _, err := Import(archive(t, testFiles()), "8.1.1", "en_US", backend); err == nil {
+		t.Errorf("Import() of a version not in the archive returned no error")
+	}
+}
+
+func TestImport_invalid(t *testing.T) {
+	backend, _ := memorybackend.NewBackend()
+
+	if _, err := Import(bytes.NewBufferString("no archive"), "", "en_US", backend); err == nil {
+		t.Errorf("Import() of an invalid archive returned no error")
+	}
+	files := map[string]string{"9.9.1/data/en_US/item.json": `{"data":[]}`}
+	if _, err := Import(archive(t, files), "", "en_US", backend); err == nil {
+		t.Errorf("Import() of an invalid item file returned no error")
+	}
+}
+
+func Test_splitDataPath(t *testing.T) {
+	tests := []struct {
+		name                     string
+		version, language, asset string
+		ok                       bool
+	}{
+		{"9.10.1/data/en_US/item.json", "9.10.1", "en_US", "item", true},
+		{"./9.10.1/data/en_US/summoner.json", "9.10.1", "en_US", "summoner", true},
+		{"dragontail-9.10.1/9.10.1/data/de_DE/runesReforged.json", "9.10.1", "de_DE", "runesReforged", true},
+		{"9.10.1/data/en_US/champion/Annie.json", "9.10.1", "en_US", "champion/Annie", true},
+		{"9.10.1/img/en_US/champion/Annie.json", "", "", "", false},
+		{"9.10.1/data/en_US/map.json", "", "", "", false},
+		{"9.10.1/img/en_US/item.json", "", "", "", false},
+		{"item.json", "", "", "", false},
+	}
+	for _, tt := range tests {
+		version, language, asset, ok := splitDataPath(tt.name)
+		if version != tt.version || language != tt.language || asset != tt.asset || ok != tt.ok {
+			t.Errorf("splitDataPath(%s) = %s, %s, %s, %v", tt.name, version, language, asset, ok)
+		}
+	}
+}
@@ -0,0 +1,302 @@
+// Package fakeriotapi provides a local stand-in for the Riot API which serves recorded JSON fixtures.
+// It enforces Application and Method Rate Limits per API key like the Riot API does, so that clients
+// can be developed and tested offline, including their rate limiting.
+package fakeriotapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+var now = time.Now
+
+// Config holds the settings of the fake Riot API server
+type Config struct {
+	// FixtureDir is the directory holding the responses. The response for a path is read from the file
+	// <FixtureDir>/<path>.json, e.g., lol/summoner/v4/summoners/by-name/Player.json.
+	FixtureDir string
+	// AppRateLimit are the Application Rate Limits per key in the format of the X-App-Rate-Limit header, e.g., 20:1,100:120
+	AppRateLimit string
+	// MethodRateLimit are the Method Rate Limits per key and method used for all methods not listed in MethodRateLimits
+	MethodRateLimit string
+	// MethodRateLimits are the Method Rate Limits of single methods, e.g., match-v4.getMatch
+	MethodRateLimits map[string]string
+	// Keys are the accepted API keys. If empty, every key is accepted.
+	Keys []string
+}
+
+// keyState holds the rate limit windows of one API key
+type keyState struct {
+	app     limits
+	methods map[string]limits
+}
+
+// Server is a fake Riot API server
+type Server struct {
+	config Config
+	log    *logrus.Entry
+
+	appLimit     limits
+	methodLimit  limits
+	methodLimits map[string]limits
+
+	mutex sync.Mutex
+	keys  map[string]*keyState
+}
+
+// New creates a new fake Riot API server
+func New(cfg Config) (*Server, error) {
+	if info, err := os.Stat(cfg.FixtureDir); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("Fixture directory %s does not exist", cfg.FixtureDir)
+	}
+
+	appLimit, err := parseLimits(cfg.AppRateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Application Rate Limit: %s", err)
+	}
+	methodLimit, err := parseLimits(cfg.MethodRateLimit)
+	if err != nil {
+		return nil, fmt.Errorf("Error parsing Method Rate Limit: %s", err)
+	}
+	methodLimits := make(map[string]limits)
+	for method, l := range cfg.MethodRateLimits {
+		methodLimits[method], err = parseLimits(l)
+		if err != nil {
+			return nil, fmt.Errorf("Error parsing Method Rate Limit of %s: %s", method, err)
+		}
+	}
+
+	return &Server{
+		config:       cfg,
+		log:          logging.Get("FakeRiotAPI"),
+		appLimit:     appLimit,
+		methodLimit:  methodLimit,
+		methodLimits: methodLimits,
+		keys:         make(map[string]*keyState),
+	}, nil
+}
+
+// ServeHTTP answers a Riot API request from the fixtures
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.log.Debugln("Received request", r.URL)
+
+	if r.Method != http.MethodGet {
+		writeStatus(w, http.StatusMethodNotAllowed, "Method not allowed")
+		return
+	}
+
+	key := r.Header.Get("X-Riot-Token")
+	if len(key) == 0 {
+		writeStatus(w, http.StatusUnauthorized, "Unauthorized")
+		return
+	}
+	if !s.validKey(key) {
+		writeStatus(w, http.StatusForbidden, "Forbidden")
+		return
+	}
+
+	route, ok := findRoute(r.URL.Path)
+	if !ok {
+		writeStatus(w, http.StatusNotFound, "Resource not found")
+		return
+	}
+
+	if !s.allow(w, key, route.name) {
+		return
+	}
+
+	data, err := ioutil.ReadFile(s.fixturePath(r.URL.Path))
+	if err != nil {
+		writeStatus(w, http.StatusNotFound, "Data not found")
+		return
+	}
+
+	switch route.name {
+	case "match-v4.getMatchlist":
+		data, err = pageMatchlist(data, r)
+	case "match-v5.getMatchIdsByPUUID":
+		data, err = pageMatchIDs(data, r)
+	}
+	if err != nil {
+		writeStatus(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(data)
+}
+
+func (s *Server) validKey(key string) bool {
+	if len(s.config.Keys) == 0 {
+		return true
+	}
+	for _, k := range s.config.Keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// allow counts a request of key for method against the rate limits and sets the rate limit headers.
+// If a limit is exceeded, it answers with 429 and returns false.
+func (s *Server) allow(w http.ResponseWriter, key string, method string) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	state, ok := s.keys[key]
+	if !ok {
+		state = &keyState{app: s.appLimit.clone(), methods: make(map[string]limits)}
+		s.keys[key] = state
+	}
+	methodLimit, ok := state.methods[method]
+	if !ok {
+		if l, ok := s.methodLimits[method]; ok {
+			methodLimit = l.clone()
+		} else {
+			methodLimit = s.methodLimit.clone()
+		}
+		state.methods[method] = methodLimit
+	}
+
+	t := now()
+	state.app.reset(t)
+	methodLimit.reset(t)
+
+	appWait, methodWait := state.app.retryAfter(t), methodLimit.retryAfter(t)
+	if appWait == 0 && methodWait == 0 {
+		state.app.add(t)
+		methodLimit.add(t)
+	}
+
+	header := w.Header()
+	if len(state.app) > 0 {
+		header.Set("X-App-Rate-Limit", state.app.header())
+		header.Set("X-App-Rate-Limit-Count", state.app.countHeader())
+	}
+	if len(methodLimit) > 0 {
+		header.Set("X-Method-Rate-Limit", methodLimit.header())
+		header.Set("X-Method-Rate-Limit-Count", methodLimit.countHeader())
+	}
+
+	if appWait == 0 && methodWait == 0 {
+		return true
+	}
+
+	limitType, wait := "method", methodWait
+	if appWait > methodWait {
+		limitType, wait = "application", appWait
+	}
+	seconds := int64((wait + time.Second - 1) / time.Second)
+	header.Set("Retry-After", strconv.FormatInt(seconds, 10))
+	header.Set("X-Rate-Limit-Type", limitType)
+	s.log.Debugf("Rate limited %s for key ending in %s, retry after %ds", method, lastChars(key, 4), seconds)
+	writeStatus(w, http.StatusTooManyRequests, "Rate limit exceeded")
+
+	return false
+}
+
+// fixturePath returns the file holding the response for the given URL path
+func (s *Server) fixturePath(urlPath string) string {
+	return filepath.Join(s.config.FixtureDir, filepath.FromSlash(path.Clean("/"+urlPath))) + ".json"
+}
+
+// pageMatchlist returns the part of a matchlist selected by beginIndex and endIndex like the Riot API does:
+// At most 100 matches starting at beginIndex (default 0).
+func pageMatchlist(data []byte, r *http.Request) ([]byte, error) {
+	matchlist := riotclient.MatchlistDTO{}
+	if err := json.Unmarshal(data, &matchlist); err != nil {
+		return nil, fmt.Errorf("Invalid matchlist fixture: %s", err)
+	}
+
+	beginIndex, endIndex := 0, -1
+	var err error
+	if v := r.URL.Query().Get("beginIndex"); len(v) > 0 {
+		if beginIndex, err = strconv.Atoi(v); err != nil || beginIndex < 0 {
+			return nil, fmt.Errorf("Invalid beginIndex %s", v)
+		}
+	}
+	if v := r.URL.Query().Get("endIndex"); len(v) > 0 {
+		if endIndex, err = strconv.Atoi(v); err != nil || endIndex <= beginIndex {
+			return nil, fmt.Errorf("Invalid endIndex %s", v)
+		}
+		if endIndex-beginIndex > 100 {
+			return nil, fmt.Errorf("Maximum index range of 100 exceeded")
+		}
+	} else {
+		endIndex = beginIndex + 100
+	}
+
+	total := len(matchlist.Matches)
+	if beginIndex > total {
+		beginIndex = total
+	}
+	if endIndex > total {
+		endIndex = total
+	}
+	matchlist.Matches = matchlist.Matches[beginIndex:endIndex]
+	matchlist.StartIndex, matchlist.EndIndex, matchlist.TotalGames = beginIndex, endIndex, total
+
+	return json.Marshal(matchlist)
+}
+
+// pageMatchIDs returns the part of a v5 match id list selected by start and count like the Riot API does:
+// count (default 20, at most 100) match ids starting at start (default 0).
+func pageMatchIDs(data []byte, r *http.Request) ([]byte, error) {
+	var matchIDs []string
+	if err := json.Unmarshal(data, &matchIDs); err != nil {
+		return nil, fmt.Errorf("Invalid match ids fixture: %s", err)
+	}
+
+	start, count := 0, 20
+	var err error
+	if v := r.URL.Query().Get("start"); len(v) > 0 {
+		if start, err = strconv.Atoi(v); err != nil || start < 0 {
+			return nil, fmt.Errorf("Invalid start %s", v)
+		}
+	}
+	if v := r.URL.Query().Get("count"); len(v) > 0 {
+		if count, err = strconv.Atoi(v); err != nil || count < 0 || count > 100 {
+			return nil, fmt.Errorf("Invalid count %s", v)
+		}
+	}
+
+	total := len(matchIDs)
+	if start > total {
+		start = total
+	}
+	end := start + count
+	if end > total {
+		end = total
+	}
+
+	return json.Marshal(matchIDs[start:end])
+}
+
+// writeStatus writes an error response with a body like the ones of the Riot API
+func writeStatus(w http.ResponseWriter, statusCode int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(statusCode)
+	fmt.Fprintf(w, `{"status":{"message":%q,"status_code":%d}}`, message, statusCode)
+}
+
+func lastChars(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[len(s)-n:]
+}
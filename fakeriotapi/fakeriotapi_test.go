@@ -0,0 +1,302 @@
+package fakeriotapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclientv4"
+	"git.abyle.org/hps/alolstats/riotclientv5"
+
+	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+func newTestServer(t *testing.T, cfg Config) *Server {
+	cfg.FixtureDir = "./testdata"
+	s, err := New(cfg)
+	if err != nil {
+		t.Fatalf("Could not create fake Riot API: %s", err)
+	}
+	return s
+}
+
+func get(s *Server, path string, key string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	if len(key) > 0 {
+		req.Header.Set("X-Riot-Token", key)
+	}
+	w := httptest.NewRecorder()
+	s.ServeHTTP(w, req)
+	return w
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(Config{FixtureDir: "./does-not-exist"}); err == nil {
+		t.Errorf("Could create fake Riot API without fixture directory")
+	}
+	if _, err := New(Config{FixtureDir: "./testdata", AppRateLimit: "20"}); err == nil {
+		t.Errorf("Could create fake Riot API with invalid Application Rate Limit")
+	}
+	if _, err := New(Config{FixtureDir: "./testdata", MethodRateLimits: map[string]string{"match-v4.getMatch": "0:10"}}); err == nil {
+		t.Errorf("Could create fake Riot API with invalid Method Rate Limit")
+	}
+}
+
+func Test_findRoute(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/lol/summoner/v4/summoners/by-name/Test Player", "summoner-v4.getBySummonerName"},
+		{"/lol/summoner/v4/summoners/abcd", "summoner-v4.getBySummonerId"},
+		{"/lol/match/v4/matchlists/by-account/account-1", "match-v4.getMatchlist"},
+		{"/lol/league-exp/v4/entries/RANKED_SOLO_5x5/GOLD/I", "league-exp-v4.getLeagueEntries"},
+		{"/lol/platform/v3/champion-rotations", "champion-v3.getChampionInfo"},
+		{"/lol/summoner/v4/summoners/", ""},
+		{"/lol/match/v5/matches/EUW1_1", "match-v5.getMatch"},
+		{"/lol/match/v5/matches/EUW1_1/timeline", "match-v5.getTimeline"},
+		{"/lol/match/v5/matches/by-puuid/puuid-1/ids", "match-v5.getMatchIdsByPUUID"},
+		{"/lol/match/v5/matches/by-puuid/puuid-1", ""},
+	}
+	for _, tt := range tests {
+		got := ""
+		if r, ok := findRoute(tt.path); ok {
+			got = r.name
+		}
+		if got != tt.want {
+			t.Errorf("findRoute(%s) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestServer_ServeHTTP(t *testing.T) {
+	s := newTestServer(t, Config{Keys: []string{"valid-key"}})
+
+	tests := []struct {
+		name       string
+		path       string
+		key        string
+		wantStatus int
+	}{
+		{"Missing key", "/lol/platform/v3/champion-rotations", "", http.StatusUnauthorized},
+		{"Invalid key", "/lol/platform/v3/champion-rotations", "other-key", http.StatusForbidden},
+		{"Unknown endpoint", "/lol/unknown/v4/things", "valid-key", http.StatusNotFound},
+		{"Missing fixture", "/lol/summoner/v4/summoners/by-name/Nobody", "valid-key", http.StatusNotFound},
+		{"Fixture", "/lol/platform/v3/champion-rotations", "valid-key", http.StatusOK},
+		{"Escaped path", "/lol/summoner/v4/summoners/by-name/Test%20Player", "valid-key", http.StatusOK},
+		{"Path traversal", "/lol/summoner/v4/summoners/by-name/..%2F..%2F..%2F..%2F..%2Ffakeriotapi_test", "valid-key", http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if w := get(s, tt.path, tt.key); w.Code != tt.wantStatus {
+				t.Errorf("GET %s returned %d, want %d: %s", tt.path, w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestServer_matchlistPaging(t *testing.T) {
+	s := newTestServer(t, Config{})
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantStart  int
+		wantEnd    int
+	}{
+		{"", http.StatusOK, 0, 100},
+		{"?beginIndex=100", http.StatusOK, 100, 150},
+		{"?beginIndex=20&endIndex=30", http.StatusOK, 20, 30},
+		{"?beginIndex=200&endIndex=300", http.StatusOK, 150, 150},
+		{"?beginIndex=0&endIndex=101", http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		w := get(s, "/lol/match/v4/matchlists/by-account/account-1"+tt.query, "key")
+		if w.Code != tt.wantStatus {
+			t.Errorf("GET matchlist%s returned %d, want %d", tt.query, w.Code, tt.wantStatus)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		matchlist := riotclient.MatchlistDTO{}
+		if err := json.Unmarshal(w.Body.Bytes(), &matchlist); err != nil {
+			t.Fatalf("Invalid matchlist response: %s", err)
+		}
+		if matchlist.StartIndex != tt.wantStart || matchlist.EndIndex != tt.wantEnd || len(matchlist.Matches) != tt.wantEnd-tt.wantStart ||
+			matchlist.TotalGames != 150 {
+			t.Errorf("GET matchlist%s returned matches %d to %d (%d matches, %d total), want %d to %d", tt.query,
+				matchlist.StartIndex, matchlist.EndIndex, len(matchlist.Matches), matchlist.TotalGames, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestServer_matchIDsPaging(t *testing.T) {
+	s := newTestServer(t, Config{})
+
+	tests := []struct {
+		query      string
+		wantStatus int
+		wantStart  int
+		wantEnd    int
+	}{
+		{"", http.StatusOK, 0, 20},
+		{"?start=20", http.StatusOK, 20, 30},
+		{"?start=5&count=10", http.StatusOK, 5, 15},
+		{"?start=100&count=10", http.StatusOK, 30, 30},
+		{"?count=101", http.StatusBadRequest, 0, 0},
+		{"?start=-1", http.StatusBadRequest, 0, 0},
+	}
+	for _, tt := range tests {
+		w := get(s, "/lol/match/v5/matches/by-puuid/puuid-1/ids"+tt.query, "key")
+		if w.Code != tt.wantStatus {
+			t.Errorf("GET match ids%s returned %d, want %d", tt.query, w.Code, tt.wantStatus)
+			continue
+		}
+		if w.Code != http.StatusOK {
+			continue
+		}
+		var matchIDs []string
+		if err := json.Unmarshal(w.Body.Bytes(), &matchIDs); err != nil {
+			t.Fatalf("Invalid match ids response: %s", err)
+		}
+		if len(matchIDs) != tt.wantEnd-tt.wantStart || (len(matchIDs) > 0 && matchIDs[0] != fmt.Sprintf("EUW1_%d", 3827449823-tt.wantStart)) {
+			t.Errorf("GET match ids%s returned %v, want match ids %d to %d", tt.query, matchIDs, tt.wantStart, tt.wantEnd)
+		}
+	}
+}
+
+func TestServer_rateLimits(t *testing.T) {
+	clock := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		return clock
+	}
+	defer func() {
+		now = time.Now
+	}()
+
+	s := newTestServer(t, Config{AppRateLimit: "3:1,5:10", MethodRateLimit: "100:10",
+		MethodRateLimits: map[string]string{"champion-v3.getChampionInfo": "2:10"}})
+
+	const rotations = "/lol/platform/v3/champion-rotations"
+	const summoner = "/lol/summoner/v4/summoners/by-name/Test%20Player"
+
+	w := get(s, rotations, "key-1")
+	if w.Code != http.StatusOK {
+		t.Fatalf("First request returned %d", w.Code)
+	}
+	wantHeader := map[string]string{
+		"X-App-Rate-Limit":          "3:1,5:10",
+		"X-App-Rate-Limit-Count":    "1:1,1:10",
+		"X-Method-Rate-Limit":       "2:10",
+		"X-Method-Rate-Limit-Count": "1:10",
+	}
+	for name, want := range wantHeader {
+		if got := w.Header().Get(name); got != want {
+			t.Errorf("Header %s = %s, want %s", name, got, want)
+		}
+	}
+
+	get(s, rotations, "key-1")
+	w = get(s, rotations, "key-1")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("X-Rate-Limit-Type") != "method" || w.Header().Get("Retry-After") != "10" {
+		t.Errorf("Request exceeding the Method Rate Limit returned %d, %v", w.Code, w.Header())
+	}
+
+	w = get(s, summoner, "key-1")
+	if w.Code != http.StatusOK || w.Header().Get("X-App-Rate-Limit-Count") != "3:1,3:10" {
+		t.Errorf("Request of another method returned %d, %v", w.Code, w.Header())
+	}
+	w = get(s, summoner, "key-1")
+	if w.Code != http.StatusTooManyRequests || w.Header().Get("X-Rate-Limit-Type") != "application" || w.Header().Get("Retry-After") != "1" {
+		t.Errorf("Request exceeding the Application Rate Limit returned %d, %v", w.Code, w.Header())
+	}
+
+	if w = get(s, summoner, "key-2"); w.Code != http.StatusOK {
+		t.Errorf("Request with another key returned %d, want limits per key", w.Code)
+	}
+
+	clock = clock.Add(time.Second)
+	if w = get(s, summoner, "key-1"); w.Code != http.StatusOK || w.Header().Get("X-App-Rate-Limit-Count") != "1:1,4:10" {
+		t.Errorf("Request after the 1s window returned %d, %v", w.Code, w.Header())
+	}
+}
+
+func TestServer_riotClientV4(t *testing.T) {
+	s := newTestServer(t, Config{AppRateLimit: "20:1,100:120", MethodRateLimit: "1000:10"})
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	rateLimit, _ := riotclientrl.New()
+	client, err := riotclientv4.NewClient(server.Client(),
+		config.RiotClient{APIVersion: "v4", Region: "euw1", Key: "key", BaseURL: server.URL + "/"}, nil, rateLimit)
+	if err != nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	client.Start()
+	defer client.Stop()
+
+	summoner, err := client.SummonerByName("Test Player")
+	if err != nil || summoner.AccountID != "account-1" {
+		t.Fatalf("SummonerByName() = %+v, %v", summoner, err)
+	}
+
+	matches, err := client.MatchesByAccountID(summoner.AccountID, map[string]string{"beginIndex": "0", "endIndex": "100"})
+	if err != nil || len(matches.Matches) != 100 {
+		t.Fatalf("MatchesByAccountID() returned %v", err)
+	}
+
+	match, err := client.MatchByID(uint64(matches.Matches[0].GameID))
+	if err != nil || match.GameVersion != "8.24.255.8524" {
+		t.Fatalf("MatchByID() = %+v, %v", match, err)
+	}
+
+	rotation, err := client.ChampionRotations()
+	if err != nil || !reflect.DeepEqual(rotation.FreeChampionIds, []int{1, 24, 36, 55, 62, 85, 110, 143}) {
+		t.Fatalf("ChampionRotations() = %+v, %v", rotation, err)
+	}
+
+	if _, err := client.MatchByID(1); riotclient.KindOf(err) != riotclient.ErrorNotFound {
+		t.Errorf("MatchByID() of unknown match returned %v, want Not Found", err)
+	}
+}
+
+func TestServer_riotClientV5(t *testing.T) {
+	s := newTestServer(t, Config{AppRateLimit: "20:1,100:120", MethodRateLimit: "1000:10"})
+	server := httptest.NewServer(s)
+	defer server.Close()
+
+	rateLimit, _ := riotclientrl.New()
+	client, err := riotclientv5.NewClient(server.Client(),
+		config.RiotClient{APIVersion: "v5", Region: "euw1", Key: "key", BaseURL: server.URL + "/"}, nil, rateLimit)
+	if err != nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	client.Start()
+	defer client.Stop()
+
+	matches, err := client.MatchesByAccountID("account-1", map[string]string{"beginIndex": "0", "endIndex": "20"})
+	if err != nil || len(matches.Matches) != 20 || matches.Matches[0].GameID != 3827449823 || matches.Matches[0].PlatformID != "EUW1" {
+		t.Fatalf("MatchesByAccountID() = %+v, %v", matches, err)
+	}
+
+	match, err := client.MatchByID(uint64(matches.Matches[0].GameID))
+	if err != nil || match.GameVersion != "11.14.385.9967" || len(match.Participants) != 1 {
+		t.Fatalf("MatchByID() = %+v, %v", match, err)
+	}
+
+	timeline, err := client.MatchTimeLineByID(uint64(matches.Matches[0].GameID))
+	if err != nil || timeline.FrameInterval != 60000 || len(timeline.Frames) != 2 {
+		t.Fatalf("MatchTimeLineByID() = %+v, %v", timeline, err)
+	}
+
+	if _, err := client.MatchByID(1); riotclient.KindOf(err) != riotclient.ErrorNotFound {
+		t.Errorf("MatchByID() of unknown match returned %v, want Not Found", err)
+	}
+}
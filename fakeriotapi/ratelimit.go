@@ -0,0 +1,105 @@
+package fakeriotapi
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// A limit is a Riot style rate limit: A window starts with the first request and allows a number of calls
+// until its period is over.
+type limit struct {
+	calls  uint32
+	period time.Duration
+
+	count uint32
+	start time.Time
+}
+
+// limits are the rate limits of one Application or Method, in the order they were given
+type limits []*limit
+
+// parseLimits parses rate limits in the format of the X-App-Rate-Limit header, e.g., 20:1,100:120.
+// An empty string means no limits.
+func parseLimits(s string) (limits, error) {
+	var ls limits
+	if len(strings.TrimSpace(s)) == 0 {
+		return ls, nil
+	}
+	for _, l := range strings.Split(s, ",") {
+		parts := strings.Split(strings.TrimSpace(l), ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Invalid rate limit %s, expected calls:seconds", l)
+		}
+		calls, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil || calls == 0 {
+			return nil, fmt.Errorf("Invalid number of calls in rate limit %s", l)
+		}
+		seconds, err := strconv.ParseUint(parts[1], 10, 32)
+		if err != nil || seconds == 0 {
+			return nil, fmt.Errorf("Invalid period in rate limit %s", l)
+		}
+		ls = append(ls, &limit{calls: uint32(calls), period: time.Duration(seconds) * time.Second})
+	}
+	return ls, nil
+}
+
+// clone returns the limits with empty windows
+func (ls limits) clone() limits {
+	c := make(limits, 0, len(ls))
+	for _, l := range ls {
+		c = append(c, &limit{calls: l.calls, period: l.period})
+	}
+	return c
+}
+
+// reset ends all windows whose period is over at time t
+func (ls limits) reset(t time.Time) {
+	for _, l := range ls {
+		if l.count > 0 && t.Sub(l.start) >= l.period {
+			l.count = 0
+		}
+	}
+}
+
+// retryAfter returns how long a request at time t has to wait until all limits allow it, 0 if it is allowed right away
+func (ls limits) retryAfter(t time.Time) time.Duration {
+	var wait time.Duration
+	for _, l := range ls {
+		if l.count >= l.calls {
+			if w := l.start.Add(l.period).Sub(t); w > wait {
+				wait = w
+			}
+		}
+	}
+	return wait
+}
+
+// add counts a request at time t
+func (ls limits) add(t time.Time) {
+	for _, l := range ls {
+		if l.count == 0 {
+			l.start = t
+		}
+		l.count++
+	}
+}
+
+// header returns the limits in the format of the X-App-Rate-Limit header
+func (ls limits) header() string {
+	parts := make([]string, 0, len(ls))
+	for _, l := range ls {
+		parts = append(parts, fmt.Sprintf("%d:%d", l.calls, int64(l.period/time.Second)))
+	}
+	return strings.Join(parts, ",")
+}
+
+// countHeader returns the counts of the current windows in the format of the X-App-Rate-Limit-Count header
+func (ls limits) countHeader() string {
+	parts := make([]string, 0, len(ls))
+	for _, l := range ls {
+		parts = append(parts, fmt.Sprintf("%d:%d", l.count, int64(l.period/time.Second)))
+	}
+	return strings.Join(parts, ",")
+}
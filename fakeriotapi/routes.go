@@ -0,0 +1,54 @@
+package fakeriotapi
+
+import "strings"
+
+// A route is an endpoint served by the fake Riot API. The name is the method name used by Riot for rate limiting.
+// An empty segment in the pattern matches any value, e.g., a Summoner ID.
+type route struct {
+	pattern []string
+	name    string
+}
+
+var routes = []route{
+	{[]string{"lol", "summoner", "v4", "summoners", "by-account", ""}, "summoner-v4.getByAccountId"},
+	{[]string{"lol", "summoner", "v4", "summoners", "by-name", ""}, "summoner-v4.getBySummonerName"},
+	{[]string{"lol", "summoner", "v4", "summoners", "by-puuid", ""}, "summoner-v4.getByPUUID"},
+	{[]string{"lol", "summoner", "v4", "summoners", ""}, "summoner-v4.getBySummonerId"},
+	{[]string{"lol", "match", "v4", "matches", ""}, "match-v4.getMatch"},
+	{[]string{"lol", "match", "v4", "matchlists", "by-account", ""}, "match-v4.getMatchlist"},
+	{[]string{"lol", "match", "v4", "timelines", "by-match", ""}, "match-v4.getMatchTimeline"},
+	{[]string{"lol", "match", "v5", "matches", ""}, "match-v5.getMatch"},
+	{[]string{"lol", "match", "v5", "matches", "", "timeline"}, "match-v5.getTimeline"},
+	{[]string{"lol", "match", "v5", "matches", "by-puuid", "", "ids"}, "match-v5.getMatchIdsByPUUID"},
+	{[]string{"lol", "league", "v4", "challengerleagues", "by-queue", ""}, "league-v4.getChallengerLeague"},
+	{[]string{"lol", "league", "v4", "grandmasterleagues", "by-queue", ""}, "league-v4.getGrandmasterLeague"},
+	{[]string{"lol", "league", "v4", "masterleagues", "by-queue", ""}, "league-v4.getMasterLeague"},
+	{[]string{"lol", "league", "v4", "positions", "by-summoner", ""}, "league-v4.getAllLeaguePositionsForSummoner"},
+	{[]string{"lol", "league-exp", "v4", "entries", "", "", ""}, "league-exp-v4.getLeagueEntries"},
+	{[]string{"lol", "spectator", "v4", "active-games", "by-summoner", ""}, "spectator-v4.getCurrentGameInfoBySummoner"},
+	{[]string{"lol", "spectator", "v4", "featured-games"}, "spectator-v4.getFeaturedGames"},
+	{[]string{"lol", "platform", "v3", "champion-rotations"}, "champion-v3.getChampionInfo"},
+}
+
+// findRoute returns the route serving the given URL path, false if there is none
+func findRoute(path string) (*route, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i := range routes {
+		if routes[i].matches(segments) {
+			return &routes[i], true
+		}
+	}
+	return nil, false
+}
+
+func (r *route) matches(segments []string) bool {
+	if len(r.pattern) != len(segments) {
+		return false
+	}
+	for i, p := range r.pattern {
+		if len(segments[i]) == 0 || (len(p) > 0 && p != segments[i]) {
+			return false
+		}
+	}
+	return true
+}
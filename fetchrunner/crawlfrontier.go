@@ -0,0 +1,167 @@
+package fetchrunner
+
+import (
+	"sync"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// Priorities of the accounts in the crawl frontier, accounts with a higher priority are crawled first
+const (
+	// Summoners seen in fetched matches
+	prioritySeen = 1
+	// Summoners seen in fetched matches whose tier has less matches than FetchMatchesPerTierTarget
+	priorityUnderrepresented = 2
+	// Summoners of the specified Leagues and Tiers
+	priorityLeague = 3
+	// Summoners specified by name
+	prioritySummoner = 4
+)
+
+// defaultCrawlBatchSize is how many accounts are read from the crawl frontier at once if nothing is configured
+const defaultCrawlBatchSize = 100
+
+func (f *FetchRunner) crawlBatchSize() int {
+	if f.config.FetchMatchesCrawlBatchSize > 0 {
+		return int(f.config.FetchMatchesCrawlBatchSize)
+	}
+	return defaultCrawlBatchSize
+}
+
+// addToCrawlFrontier adds accounts with their tiers to the crawl frontier of the region, keeping the progress of already known accounts
+func (f *FetchRunner) addToCrawlFrontier(accountIDs map[string]string, priority int) {
+	for accountID, tier := range accountIDs {
+		f.addCrawlAccount(accountID, priority, tier)
+	}
+}
+
+// addSeenToCrawlFrontier adds accounts seen in fetched matches with their tiers to the crawl frontier of the region.
+// Accounts whose tier has not reached FetchMatchesPerTierTarget, yet, are crawled before the others.
+func (f *FetchRunner) addSeenToCrawlFrontier(accountIDs map[string]string) {
+	for accountID, tier := range accountIDs {
+		priority := prioritySeen
+		if f.tierTargets != nil && f.tierTargets.underrepresented(tier) {
+			priority = priorityUnderrepresented
+		}
+		f.addCrawlAccount(accountID, priority, tier)
+	}
+}
+
+func (f *FetchRunner) addCrawlAccount(accountID string, priority int, tier string) {
+	if err := f.storage.AddCrawlAccount(f.config.Region, accountID, priority, tier); err != nil {
+		f.log.Errorf("Error adding Account ID %s to crawl frontier: %s", accountID, err)
+	}
+}
+
+// crawlsInFlight keeps track of the account crawls whose matches are not all downloaded, yet
+type crawlsInFlight struct {
+	mutex    sync.Mutex
+	accounts map[string]bool
+	wg       sync.WaitGroup
+	err      error // first error storing the progress of a crawl
+}
+
+// add registers the crawl of an account
+func (c *crawlsInFlight) add(accountID string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.accounts == nil {
+		c.accounts = make(map[string]bool)
+	}
+	c.accounts[accountID] = true
+	c.wg.Add(1)
+}
+
+// contains returns true if the crawl of an account is not finished, yet
+func (c *crawlsInFlight) contains(accountID string) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.accounts[accountID]
+}
+
+// done marks the crawl of an account as finished, err is the error storing its progress, if any
+func (c *crawlsInFlight) done(accountID string, err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.accounts, accountID)
+	if err != nil && c.err == nil {
+		c.err = err
+	}
+	c.wg.Done()
+}
+
+// error returns the first error storing the progress of a crawl
+func (c *crawlsInFlight) error() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.err
+}
+
+// wait blocks until all crawls are finished and clears the error
+func (c *crawlsInFlight) wait() {
+	c.wg.Wait()
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.err = nil
+}
+
+// crawlAccount lists the matches of an account of the crawl frontier and passes them to the match fetch workers, which store
+// its progress when all of them are handled. Summoners seen in the matches of specified Summoners, Leagues and Tiers are added
+// to the crawl frontier, if enabled. Their own matches are fetched with bulk priority.
+//
+// With FetchMatchesPerTierTarget only the queues in which the tier of the account has not reached the target are fetched,
+// except for Summoners specified by name. Accounts whose tier reached it in all queues are marked as crawled without fetching.
+func (f *FetchRunner) crawlAccount(entry storage.CrawlEntry, knownLatestVersion string) error {
+	queues := f.queueIDs
+	if f.tierTargets != nil && entry.Priority < prioritySummoner && isRankedTier(entry.Tier) {
+		queues = f.tierTargets.missingQueues(entry.Tier)
+		if len(queues) == 0 {
+			if entry.Priority == priorityUnderrepresented {
+				entry.Priority = prioritySeen
+			}
+			entry.LastCrawled = time.Now()
+			return f.storage.StoreCrawlEntry(&entry)
+		}
+	}
+
+	s := f.storage
+	number := f.config.FetchMatchesForLeaguesNumber
+	if entry.Priority >= prioritySummoner {
+		number = f.config.FetchMatchesForSummonersNumber
+	} else if entry.Priority < priorityLeague {
+		s = f.bulkStorage
+	}
+
+	var seenAccountIDs map[string]string
+	if entry.Priority >= priorityLeague && f.config.FetchMatchesForSeenSummoners {
+		seenAccountIDs = make(map[string]string)
+	}
+
+	f.crawls.add(entry.AccountID)
+	f.fetchSummonerMatches(newAccountCrawl(s, entry, seenAccountIDs, knownLatestVersion), queues, uint32(number))
+	return nil
+}
+
+// finishCrawl stores the progress of an account crawl whose matches are all handled
+func (f *FetchRunner) finishCrawl(crawl *accountCrawl) {
+	if f.shouldWorkersStop() {
+		// Not marked as crawled, so that it is crawled again when the FetchRunner is started again
+		f.crawls.done(crawl.entry.AccountID, nil)
+		return
+	}
+
+	f.addSeenToCrawlFrontier(crawl.seenAccountIDs)
+
+	entry := crawl.entry
+	entry.LastCrawled = time.Now()
+	// Otherwise the matches which could not be fetched would never be tried again
+	if !crawl.failed && crawl.newest > entry.LastMatchTimestamp {
+		entry.LastMatchTimestamp = crawl.newest
+	}
+	err := f.storage.StoreCrawlEntry(&entry)
+	if err != nil {
+		f.log.Errorf("Error storing crawl progress of Account ID %s: %s", entry.AccountID, err)
+	}
+	f.crawls.done(entry.AccountID, err)
+}
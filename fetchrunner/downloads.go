@@ -0,0 +1,246 @@
+package fetchrunner
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// downloads deduplicates downloads by key, so that two workers never download the same thing at the same time
+type downloads struct {
+	mutex    sync.Mutex
+	inFlight map[string]chan struct{}
+}
+
+// matchDownloads is shared by all FetchRunners, as they may see the same matches
+var matchDownloads = &downloads{inFlight: make(map[string]chan struct{})}
+
+// acquire blocks while another worker downloads key and reserves it for the caller. It has to be released afterwards.
+func (d *downloads) acquire(key string) {
+	for {
+		d.mutex.Lock()
+		done, ok := d.inFlight[key]
+		if !ok {
+			d.inFlight[key] = make(chan struct{})
+			d.mutex.Unlock()
+			return
+		}
+		d.mutex.Unlock()
+		<-done
+	}
+}
+
+// release marks the download of key as finished and wakes up the workers waiting for it
+func (d *downloads) release(key string) {
+	d.mutex.Lock()
+	defer d.mutex.Unlock()
+
+	if done, ok := d.inFlight[key]; ok {
+		close(done)
+		delete(d.inFlight, key)
+	}
+}
+
+// defaultFetchConcurrency is how many match fetch workers are started if nothing is configured
+const defaultFetchConcurrency = 8
+
+func (f *FetchRunner) fetchConcurrency() int {
+	if f.config.FetchMatchesConcurrency > 0 {
+		return int(f.config.FetchMatchesConcurrency)
+	}
+	return defaultFetchConcurrency
+}
+
+// matchJob is a match of an account crawl which shall be downloaded by the match fetch workers
+type matchJob struct {
+	crawl *accountCrawl
+	index int // position in the match list of the account, newest first
+	match riotclient.MatchReferenceDTO
+}
+
+// accountCrawl is the crawl of an account of the crawl frontier. Its matches are listed by the SummonerMatchesWorker and
+// downloaded by the match fetch workers. It is finished when all its matches are listed and handled by the workers.
+type accountCrawl struct {
+	s                  *storage.Storage
+	entry              storage.CrawlEntry
+	seenAccountIDs     map[string]string // nil if seen Summoners are not collected
+	knownLatestVersion string
+
+	mutex     sync.Mutex
+	pending   int   // listed matches which were not handled by the workers, yet
+	listed    bool  // all matches are listed
+	failed    bool  // not all matches could be listed or fetched
+	stopIndex int   // matches after this position in the match list are not fetched, -1 if all are fetched
+	newest    int64 // creation time of the newest fetched match in ms since epoch
+}
+
+func newAccountCrawl(s *storage.Storage, entry storage.CrawlEntry, seenAccountIDs map[string]string, knownLatestVersion string) *accountCrawl {
+	return &accountCrawl{
+		s:                  s,
+		entry:              entry,
+		seenAccountIDs:     seenAccountIDs,
+		knownLatestVersion: knownLatestVersion,
+		stopIndex:          -1,
+	}
+}
+
+// add counts a listed match which has to be handled by the workers
+func (c *accountCrawl) add() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.pending++
+}
+
+// done marks a listed match as handled and returns true if the crawl is finished
+func (c *accountCrawl) done() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.pending--
+	return c.listed && c.pending == 0
+}
+
+// listDone marks the listing of the matches as finished and returns true if the crawl is finished
+func (c *accountCrawl) listDone(complete bool) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.listed = true
+	c.failed = c.failed || !complete
+	return c.pending == 0
+}
+
+// stopped returns true if no further matches shall be listed
+func (c *accountCrawl) stopped() bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopIndex >= 0
+}
+
+// skip returns true if the match at index of the match list shall not be fetched anymore
+func (c *accountCrawl) skip(index int) bool {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return c.stopIndex >= 0 && index > c.stopIndex
+}
+
+// stopAfter stops fetching the matches after index of the match list, c.mutex has to be held
+func (c *accountCrawl) stopAfter(index int) {
+	if c.stopIndex < 0 || index < c.stopIndex {
+		c.stopIndex = index
+	}
+}
+
+// startMatchFetchWorkers starts the workers downloading the matches of all account crawls of the FetchRunner
+func (f *FetchRunner) startMatchFetchWorkers() {
+	f.matchJobs = make(chan matchJob, matchlistPageSize)
+	for i := 0; i < f.fetchConcurrency(); i++ {
+		f.fetchWG.Add(1)
+		go f.matchFetchWorker()
+	}
+}
+
+// stopMatchFetchWorkers stops the match fetch workers after they handled the remaining jobs. No jobs may be added anymore.
+func (f *FetchRunner) stopMatchFetchWorkers() {
+	close(f.matchJobs)
+	f.fetchWG.Wait()
+}
+
+func (f *FetchRunner) matchFetchWorker() {
+	defer f.fetchWG.Done()
+
+	for job := range f.matchJobs {
+		f.fetchMatchJob(job)
+		if job.crawl.done() {
+			f.finishCrawl(job.crawl)
+		}
+	}
+}
+
+// enqueueMatch passes a listed match to the match fetch workers. It blocks while all of them are busy and returns false
+// if the match was not passed because the workers are stopping.
+func (f *FetchRunner) enqueueMatch(job matchJob) bool {
+	job.crawl.add()
+	select {
+	case f.matchJobs <- job:
+		return true
+	case <-f.stopWorkers:
+		job.crawl.done()
+		return false
+	}
+}
+
+// fetchMatchJob downloads and stores a match of an account crawl. The Account IDs of the other participants are added to
+// the seen Summoners of the crawl together with their tiers. With FetchOnlyLatestGameVersion a match of another game version
+// stops fetching the older matches of the account.
+func (f *FetchRunner) fetchMatchJob(job matchJob) {
+	crawl := job.crawl
+	if f.shouldWorkersStop() || crawl.skip(job.index) {
+		return
+	}
+
+	match, err := f.downloadMatch(crawl.s, uint64(job.match.GameID))
+	if err != nil {
+		if riotclient.KindOf(err) == riotclient.ErrorNotFound {
+			return
+		}
+		f.log.Errorf("Error fetching match %d of Account ID %s: %s", job.match.GameID, crawl.entry.AccountID, err)
+		crawl.mutex.Lock()
+		crawl.failed = true
+		crawl.stopAfter(job.index)
+		crawl.mutex.Unlock()
+		return
+	}
+	timestamp := f.matchTimestamp(crawl.s, job.match, match)
+
+	crawl.mutex.Lock()
+	defer crawl.mutex.Unlock()
+
+	if timestamp > crawl.newest {
+		crawl.newest = timestamp
+	}
+	if match == nil || !f.matchFilter.Accepts(match) {
+		return
+	}
+	if f.tierTargets != nil {
+		f.tierTargets.add(match)
+	}
+	if crawl.seenAccountIDs != nil {
+		tiers := make(map[int]string)
+		for _, participant := range match.Participants {
+			tiers[participant.ParticipantID] = strings.ToUpper(strings.TrimSpace(participant.HighestAchievedSeasonTier))
+		}
+		for _, participant := range match.ParticipantIdentities {
+			// API v5 does not provide account ids of participants
+			if len(participant.Player.AccountID) > 0 && participant.Player.AccountID != crawl.entry.AccountID {
+				crawl.seenAccountIDs[participant.Player.AccountID] = tiers[participant.ParticipantID]
+			}
+		}
+	}
+	if f.config.FetchOnlyLatestGameVersion && !f.checkGameVersionsEqual(match.GameVersion, crawl.knownLatestVersion) {
+		f.log.Debugf("Skipping remaining matches for Summoner %s because we encountered a game version not beeing the latest (latest: %s, seen %s)", crawl.entry.AccountID, crawl.knownLatestVersion, match.GameVersion)
+		crawl.stopAfter(job.index)
+	}
+}
+
+// downloadMatch downloads and stores a match and its timeline if it was not stored, yet
+func (f *FetchRunner) downloadMatch(s *storage.Storage, gameID uint64) (*riotclient.MatchDTO, error) {
+	key := fmt.Sprintf("%s/%d", f.config.Region, gameID)
+	matchDownloads.acquire(key)
+	defer matchDownloads.release(key)
+
+	match, err := s.RegionalFetchAndStoreFilteredMatch(f.config.Region, gameID, f.matchFilter)
+	if err != nil || match == nil || !f.matchFilter.Accepts(match) {
+		return match, err
+	}
+
+	if f.config.FetchTimeLines {
+		_, err := s.RegionalFetchAndStoreMatchTimeLine(match)
+		if err != nil {
+			f.log.Errorf("Error fetching or storing timeline data: %s", err)
+		}
+	}
+
+	return match, nil
+}
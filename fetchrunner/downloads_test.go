@@ -0,0 +1,46 @@
+package fetchrunner
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+func Test_downloads(t *testing.T) {
+	d := &downloads{inFlight: make(map[string]chan struct{})}
+
+	var mutex sync.Mutex
+	active := make(map[string]int)
+	maxActive := 0
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		key := []string{"euw1/1", "euw1/2"}[i%2]
+		wg.Add(1)
+		go func(key string) {
+			defer wg.Done()
+			d.acquire(key)
+			mutex.Lock()
+			active[key]++
+			if active[key] > maxActive {
+				maxActive = active[key]
+			}
+			mutex.Unlock()
+
+			time.Sleep(time.Millisecond)
+
+			mutex.Lock()
+			active[key]--
+			mutex.Unlock()
+			d.release(key)
+		}(key)
+	}
+	wg.Wait()
+
+	if maxActive != 1 {
+		t.Errorf("Got %d downloads of the same key at the same time, want 1", maxActive)
+	}
+	if len(d.inFlight) != 0 {
+		t.Errorf("Got %d downloads in flight after all were released, want 0", len(d.inFlight))
+	}
+}
@@ -2,13 +2,17 @@
 package fetchrunner
 
 import (
+	"context"
 	"fmt"
+	"math/rand"
 	"sync"
+	"time"
 
-	"github.com/sirupsen/logrus"
 	"git.abyle.org/hps/alolstats/config"
 	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
 	"git.abyle.org/hps/alolstats/storage"
+	"github.com/sirupsen/logrus"
 )
 
 type stats struct {
@@ -17,30 +21,48 @@ type stats struct {
 
 // FetchRunner automatically fetches summoner and match data based on specified criteras
 type FetchRunner struct {
-	config            config.FetchRunner
-	storage           *storage.Storage
-	log               *logrus.Entry
-	stats             stats
-	isStarted         bool
-	workersWG         sync.WaitGroup
-	stopWorkers       chan struct{}
-	shouldWorkersStop bool
+	config        config.FetchRunner
+	storage       *storage.Storage
+	bulkStorage   *storage.Storage // used for the backfill of Summoners seen in fetched matches
+	log           *logrus.Entry
+	stats         stats
+	isStarted     bool
+	workersWG     sync.WaitGroup
+	stopWorkers   chan struct{}
+	workersCtx    context.Context    // canceled when the workers shall stop
+	cancelWorkers context.CancelFunc // cancels the Riot API calls of the workers
+	rnd           *rand.Rand
+	queueIDs      []int               // queues whose match lists are fetched
+	matchFilter   storage.MatchFilter // decides which fetched matches are stored
+	matchJobs     chan matchJob       // matches listed by the SummonerMatchesWorker for the match fetch workers
+	fetchWG       sync.WaitGroup      // match fetch workers
+	crawls        crawlsInFlight      // account crawls whose matches are not all downloaded, yet
+	tierTargets   *tierTargets        // matches per tier and queue of the current run, nil if disabled
 }
 
-// NewFetchRunner creates a new FetchRunner
+// NewFetchRunner creates a new FetchRunner. Its Riot API calls are done with background priority,
+// so that they do not delay interactive requests.
 func NewFetchRunner(cfg config.FetchRunner, storage *storage.Storage) (*FetchRunner, error) {
 	name := fmt.Sprintf("FetchRunner [%s]", cfg.Region)
 	sr := &FetchRunner{
-		storage:   storage,
-		log:       logging.Get(name),
-		isStarted: false,
-		workersWG: sync.WaitGroup{},
+		storage:     storage.WithPriority(riotclient.PriorityBackground),
+		bulkStorage: storage.WithPriority(riotclient.PriorityBulk),
+		log:         logging.Get(name),
+		isStarted:   false,
+		workersWG:   sync.WaitGroup{},
+		rnd:         rand.New(rand.NewSource(time.Now().UnixNano())),
 	}
 	if cfg.UpdateIntervalSummonerMatches <= 0 {
 		return nil, fmt.Errorf("The specified UpdateIntervalSummonerMatches is too small (%d min). Must be > 0 minutes", cfg.UpdateIntervalSummonerMatches)
 	}
 	sr.config = cfg
 
+	var err error
+	sr.queueIDs, sr.matchFilter, err = newMatchFilter(cfg)
+	if err != nil {
+		return nil, err
+	}
+
 	return sr, nil
 }
 
@@ -48,8 +70,11 @@ func NewFetchRunner(cfg config.FetchRunner, storage *storage.Storage) (*FetchRun
 func (f *FetchRunner) Start() {
 	if !f.isStarted {
 		f.log.Print("Starting FetchRunner")
-		f.shouldWorkersStop = false
 		f.stopWorkers = make(chan struct{})
+		f.workersCtx, f.cancelWorkers = context.WithCancel(context.Background())
+		f.storage = f.storage.WithContext(f.workersCtx)
+		f.bulkStorage = f.bulkStorage.WithContext(f.workersCtx)
+		f.startMatchFetchWorkers()
 		go f.summonerMatchesWorker()
 		if f.config.UpdateIntervalFreeRotation > 0 {
 			go f.freeRotationWorker()
@@ -64,11 +89,17 @@ func (f *FetchRunner) Start() {
 func (f *FetchRunner) Stop() {
 	if f.isStarted {
 		f.log.Print("Stopping FetchRunner")
-		f.shouldWorkersStop = true
+		f.cancelWorkers()
 		close(f.stopWorkers)
 		f.workersWG.Wait()
+		f.stopMatchFetchWorkers()
 		f.isStarted = false
 	} else {
 		f.log.Printf("FetchRunner already stopped")
 	}
 }
+
+// shouldWorkersStop returns true if Stop was called, it is safe to call from the workers
+func (f *FetchRunner) shouldWorkersStop() bool {
+	return f.workersCtx != nil && f.workersCtx.Err() != nil
+}
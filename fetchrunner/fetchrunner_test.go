@@ -1,9 +1,44 @@
 package fetchrunner
 
 import (
+	"context"
 	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
 )
 
 func TestCreatingNewFetchRunner(t *testing.T) {
 
 }
+
+func TestFetchRunner_shouldWorkersStop(t *testing.T) {
+	f := &FetchRunner{}
+	if f.shouldWorkersStop() {
+		t.Errorf("Workers of a FetchRunner which was never started shall not stop")
+	}
+
+	f.workersCtx, f.cancelWorkers = context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		for !f.shouldWorkersStop() {
+			time.Sleep(time.Millisecond)
+		}
+		close(stopped)
+	}()
+	f.cancelWorkers()
+
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Worker did not notice that it shall stop")
+	}
+
+	// No matches are fetched anymore, the storage would be used otherwise
+	crawl := newAccountCrawl(nil, storage.CrawlEntry{AccountID: "a1"}, nil, "")
+	f.fetchMatchJob(matchJob{crawl: crawl, match: riotclient.MatchReferenceDTO{GameID: 1}})
+	if crawl.newest != 0 || crawl.failed {
+		t.Errorf("Match was fetched after stopping: %+v", crawl)
+	}
+}
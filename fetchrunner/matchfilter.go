@@ -0,0 +1,32 @@
+package fetchrunner
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// newMatchFilter returns the queues whose match lists are fetched and the filter deciding which fetched matches are stored
+func newMatchFilter(cfg config.FetchRunner) ([]int, storage.MatchFilter, error) {
+	queueIDs := cfg.FetchMatchesQueueIDs
+	if len(queueIDs) == 0 {
+		queueIDs = analyzedQueues
+	}
+
+	filter := storage.MatchFilter{
+		QueueIDs:  queueIDs,
+		MapIDs:    cfg.FetchMatchesMapIDs,
+		SeasonIDs: cfg.FetchMatchesSeasonIDs,
+	}
+	if len(cfg.FetchMatchesMinGameCreation) > 0 {
+		minGameCreation, err := time.Parse("2006-01-02", cfg.FetchMatchesMinGameCreation)
+		if err != nil {
+			return nil, storage.MatchFilter{}, fmt.Errorf("The specified FetchMatchesMinGameCreation %s is not a valid date: %s", cfg.FetchMatchesMinGameCreation, err)
+		}
+		filter.MinGameCreation = minGameCreation.UnixNano() / int64(time.Millisecond)
+	}
+
+	return queueIDs, filter, nil
+}
@@ -0,0 +1,75 @@
+package fetchrunner
+
+import (
+	"reflect"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func Test_newMatchFilter(t *testing.T) {
+	queueIDs, filter, err := newMatchFilter(config.FetchRunner{})
+	if err != nil {
+		t.Fatalf("newMatchFilter() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(queueIDs, analyzedQueues) || !filter.Accepts(&riotclient.MatchDTO{QueueID: 420, MapID: 12, SeasonID: 11}) {
+		t.Errorf("newMatchFilter() without config = %v, %+v, want analyzed queues and all maps and seasons", queueIDs, filter)
+	}
+
+	queueIDs, filter, err = newMatchFilter(config.FetchRunner{
+		FetchMatchesQueueIDs:        []int{420},
+		FetchMatchesMapIDs:          []int{11},
+		FetchMatchesSeasonIDs:       []int{13},
+		FetchMatchesMinGameCreation: "2019-01-23",
+	})
+	if err != nil {
+		t.Fatalf("newMatchFilter() returned error: %s", err)
+	}
+	if !reflect.DeepEqual(queueIDs, []int{420}) {
+		t.Errorf("newMatchFilter() queues = %v, want [420]", queueIDs)
+	}
+
+	tests := []struct {
+		match riotclient.MatchDTO
+		want  bool
+	}{
+		{riotclient.MatchDTO{QueueID: 420, MapID: 11, SeasonID: 13, GameCreation: 1548201600000}, true},
+		{riotclient.MatchDTO{QueueID: 450, MapID: 11, SeasonID: 13, GameCreation: 1548201600000}, false},
+		{riotclient.MatchDTO{QueueID: 420, MapID: 12, SeasonID: 13, GameCreation: 1548201600000}, false},
+		{riotclient.MatchDTO{QueueID: 420, MapID: 11, SeasonID: 11, GameCreation: 1548201600000}, false},
+		{riotclient.MatchDTO{QueueID: 420, MapID: 11, SeasonID: 13, GameCreation: 1548201599999}, false},
+	}
+	for _, tt := range tests {
+		if got := filter.Accepts(&tt.match); got != tt.want {
+			t.Errorf("Accepts(%+v) = %v, want %v", tt.match, got, tt.want)
+		}
+	}
+
+	if _, _, err := newMatchFilter(config.FetchRunner{FetchMatchesMinGameCreation: "23.01.2019"}); err == nil {
+		t.Errorf("newMatchFilter() with invalid date returned no error")
+	}
+}
+
+func TestFetchRunner_matchlistFilter(t *testing.T) {
+	f := &FetchRunner{}
+	tests := []struct {
+		minGameCreation    int64
+		lastMatchTimestamp int64
+		want               storage.MatchlistFilter
+	}{
+		// Never crawled accounts do not restrict the begin time
+		{0, 0, storage.MatchlistFilter{Queues: []int{420}}},
+		{0, 1552564800000, storage.MatchlistFilter{Queues: []int{420}, BeginTime: 1552564800001}},
+		{1548201600000, 0, storage.MatchlistFilter{Queues: []int{420}, BeginTime: 1548201600000}},
+		{1548201600000, 1552564800000, storage.MatchlistFilter{Queues: []int{420}, BeginTime: 1552564800001}},
+		{1552564800000, 1548201600000, storage.MatchlistFilter{Queues: []int{420}, BeginTime: 1552564800000}},
+	}
+	for _, tt := range tests {
+		f.matchFilter.MinGameCreation = tt.minGameCreation
+		if got := f.matchlistFilter([]int{420}, tt.lastMatchTimestamp); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("matchlistFilter([420], %d) with MinGameCreation %d = %+v, want %+v", tt.lastMatchTimestamp, tt.minGameCreation, got, tt.want)
+		}
+	}
+}
@@ -2,13 +2,16 @@ package fetchrunner
 
 import (
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
 	"git.abyle.org/hps/alolstats/utils"
 )
 
-func (f *FetchRunner) getLeagueSummonerAccountIDs(league string, queue string, accountIDs map[string]bool) error {
+func (f *FetchRunner) getLeagueSummonerAccountIDs(league string, queue string, accountIDs map[string]string) error {
 	leagueData, err := f.storage.GetRegionalLeagueByQueue(f.config.Region, league, queue)
 	if err != nil {
 		return fmt.Errorf("Error getting League %s: %s", league, err)
@@ -20,70 +23,155 @@ func (f *FetchRunner) getLeagueSummonerAccountIDs(league string, queue string, a
 			f.log.Warnf("Could not get Summoner for Summoner ID %s: %s", leagueEntry.SummonerID, err)
 			continue
 		}
-		accountIDs[summoner.AccountID] = true
+		accountIDs[summoner.AccountID] = strings.ToUpper(leagueData.Tier)
 	}
 
 	return nil
 }
 
-func (f *FetchRunner) fetchSummonerMatchesByName(summonerName string, number uint32, seenAccountIDs map[string]bool, knownLatestVersion string) {
+func (f *FetchRunner) getSummonerAccountIDByName(summonerName string, accountIDs map[string]string) error {
 	summoner, err := f.storage.GetRegionalSummonerByName(f.config.Region, summonerName, false)
 	if err != nil {
-		f.log.Errorf("Error fetching summoner matches: Could not get Summoner Data for Summoner %s", summonerName)
-		return
+		return fmt.Errorf("Could not get Summoner Data for Summoner %s: %s", summonerName, err)
 	}
-	accountID := summoner.AccountID
+	accountIDs[summoner.AccountID] = ""
 
-	f.fetchSummonerMatchesByAccountID(accountID, number, seenAccountIDs, knownLatestVersion)
+	return nil
 }
 
-func (f *FetchRunner) fetchSummonerMatchesByAccountID(accountID string, number uint32, seenAccountIDs map[string]bool, knownLatestVersion string) {
-	stop := false
-	startIndex := uint32(0)
-	endIndex := uint32(100)
-	if number < endIndex {
-		endIndex = number
+// analyzedQueues are the queues whose matches are analyzed by the StatsRunner, they are fetched if no queues are configured
+var analyzedQueues = []int{400, 420, 430, 440}
+
+// matchlistPageSize is the maximum number of matches the Riot API returns per match list request
+const matchlistPageSize = 100
+
+// fetchSummonerMatches lists the last number matches (all if 0) of the Summoner of an account crawl in the given queues and passes
+// them to the match fetch workers, which finish the crawl when all of them are handled. Only matches created after the newest match
+// of the previous crawl are listed. Listing stops when the workers decide that no older matches shall be fetched.
+//
+// Match lists cannot be filtered by map and API v5 does not filter them by season. Such matches are dropped before they are stored.
+func (f *FetchRunner) fetchSummonerMatches(crawl *accountCrawl, queues []int, number uint32) {
+	index := 0
+	complete := f.listMatches(crawl.s, crawl.entry.AccountID, number, f.matchlistFilter(queues, crawl.entry.LastMatchTimestamp), func(matches []riotclient.MatchReferenceDTO) bool {
+		for _, matchInfo := range matches {
+			if crawl.stopped() || !f.enqueueMatch(matchJob{crawl: crawl, index: index, match: matchInfo}) {
+				return false
+			}
+			index++
+		}
+		return true
+	})
+
+	if crawl.listDone(complete) {
+		f.finishCrawl(crawl)
 	}
-	for !stop {
-		matches, err := f.storage.GetRegionalMatchesByAccountID(f.config.Region, accountID, startIndex, endIndex)
-		if err != nil {
-			f.log.Errorf("Error getting the current match list for Summoner: %s", err)
+}
+
+// matchlistFilter returns the filter for the match lists of the queues, restricted to matches created after lastMatchTimestamp (ms since epoch) if it is > 0
+func (f *FetchRunner) matchlistFilter(queues []int, lastMatchTimestamp int64) storage.MatchlistFilter {
+	filter := storage.MatchlistFilter{Queues: queues, BeginTime: f.matchFilter.MinGameCreation, Season: f.matchFilter.SeasonIDs}
+	if lastMatchTimestamp > 0 && lastMatchTimestamp >= filter.BeginTime {
+		filter.BeginTime = lastMatchTimestamp + 1
+	}
+	return filter
+}
+
+// listMatches passes the references of the last number matches (all if 0) of a Summoner which pass the filter to visit, newest first,
+// until visit returns false. It returns false if not all match lists could be fetched.
+//
+// If the client can filter a match list by several queues, e.g., API v4, the pages of one match list are passed as they are fetched.
+// Otherwise, e.g., API v5, the match lists of the queues are fetched one after another and the newest matches of all of them are passed.
+func (f *FetchRunner) listMatches(s *storage.Storage, accountID string, number uint32, filter storage.MatchlistFilter, visit func(matches []riotclient.MatchReferenceDTO) bool) bool {
+	if len(filter.Queues) <= 1 || s.RegionalMultipleMatchlistQueues(f.config.Region) {
+		return f.walkMatchlist(s, accountID, number, filter, visit)
+	}
+
+	complete := true
+	var matches []riotclient.MatchReferenceDTO
+	for _, queue := range filter.Queues {
+		queueFilter := filter
+		queueFilter.Queues = []int{queue}
+		if !f.walkMatchlist(s, accountID, number, queueFilter, func(page []riotclient.MatchReferenceDTO) bool {
+			matches = append(matches, page...)
+			return true
+		}) {
+			complete = false
+		}
+		if f.shouldWorkersStop() {
+			return false
+		}
+	}
+
+	matches = newestMatches(matches, number)
+	for start := 0; start < len(matches); start += matchlistPageSize {
+		end := start + matchlistPageSize
+		if end > len(matches) {
+			end = len(matches)
+		}
+		if !visit(matches[start:end]) {
 			break
 		}
-		for _, matchInfo := range matches.Matches {
-			match, err := f.storage.RegionalFetchAndStoreMatch(f.config.Region, uint64(matchInfo.GameID))
-			if match != nil && err == nil && seenAccountIDs != nil {
-				for _, participant := range match.ParticipantIdentities {
-					if participant.Player.AccountID != accountID {
-						seenAccountIDs[participant.Player.AccountID] = true
-					}
-				}
-			}
-			if f.config.FetchTimeLines && match != nil && err == nil {
-				_, err := f.storage.RegionalFetchAndStoreMatchTimeLine(match)
-				if err != nil {
-					f.log.Errorf("Error fetching or storing timeline data: %s", err)
-				}
-			}
-			if f.config.FetchOnlyLatestGameVersion && match != nil && err == nil {
-				if !f.checkGameVersionsEqual(match.GameVersion, knownLatestVersion) {
-					f.log.Debugf("Skipping remaining matches for Summoner %s because we encountered a game version not beeing the latest (latest: %s, seen %s)", accountID, knownLatestVersion, match.GameVersion)
-					return
-				}
+	}
+
+	return complete
+}
+
+// walkMatchlist fetches the pages of a match list of a Summoner which pass the filter, at most number matches (all if 0), and passes
+// them to visit until it returns false. It returns false if not all pages could be fetched.
+func (f *FetchRunner) walkMatchlist(s *storage.Storage, accountID string, number uint32, filter storage.MatchlistFilter, visit func(matches []riotclient.MatchReferenceDTO) bool) bool {
+	for startIndex := uint32(0); number == 0 || startIndex < number; startIndex += matchlistPageSize {
+		endIndex := startIndex + matchlistPageSize
+		if number > 0 && endIndex > number {
+			endIndex = number
+		}
+		matches, err := s.GetRegionalMatchesByAccountID(f.config.Region, accountID, startIndex, endIndex, filter)
+		if err != nil {
+			if riotclient.KindOf(err) == riotclient.ErrorNotFound {
+				// The Riot API answers with 404 when there are no (more) matches
+				return true
 			}
+			f.log.Errorf("Error getting the current match list for Summoner: %s", err)
+			return false
 		}
-		if len(matches.Matches) == 0 || (endIndex+1) >= uint32(matches.TotalGames) {
-			stop = true
+		if len(matches.Matches) > 0 && !visit(matches.Matches) {
+			return true
 		}
-		if number > 0 && uint32(endIndex+1) > number {
-			stop = true
+		if len(matches.Matches) < int(endIndex-startIndex) || int(endIndex) >= matches.TotalGames {
+			return true
 		}
-		startIndex += 100
-		endIndex += 100
-		if endIndex > number {
-			endIndex = number
+		if f.shouldWorkersStop() {
+			return false
 		}
 	}
+	return true
+}
+
+// newestMatches sorts match references of several match lists from newest to oldest by their game ids, which increase over time,
+// and returns the first number of them (all if 0)
+func newestMatches(matches []riotclient.MatchReferenceDTO, number uint32) []riotclient.MatchReferenceDTO {
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].GameID > matches[j].GameID
+	})
+	if number > 0 && len(matches) > int(number) {
+		matches = matches[:number]
+	}
+	return matches
+}
+
+// matchTimestamp returns the creation time of a match in ms since epoch. Match lists of API v5 have no timestamps,
+// then it is taken from the fetched match or, if the match was already stored, from storage.
+func (f *FetchRunner) matchTimestamp(s *storage.Storage, matchInfo riotclient.MatchReferenceDTO, match *riotclient.MatchDTO) int64 {
+	if matchInfo.Timestamp > 0 {
+		return matchInfo.Timestamp
+	}
+	if match != nil {
+		return match.GameCreation
+	}
+	stored, err := s.GetRegionalMatch(f.config.Region, uint64(matchInfo.GameID))
+	if err != nil {
+		return 0
+	}
+	return stored.GameCreation
 }
 
 func (f *FetchRunner) checkGameVersionsEqual(latestSeenGameVersion string, knownLatestVersion string) bool {
@@ -135,22 +223,28 @@ WaitLoop:
 				}
 			}
 
-			additionalAccountIDs := make(map[string]bool)
+			f.tierTargets = f.newRunTierTargets()
+			f.logTierProgress(f.tierTargets)
+
+			summonerAccountIDs := make(map[string]string)
 			if len(f.config.FetchMatchesForSummoners) > 0 {
-				f.log.Infof("Fetching matches for specified Summoners")
+				f.log.Infof("Getting Summoner Account IDs for specified Summoners")
 				for _, summonerName := range f.config.FetchMatchesForSummoners {
-					if f.shouldWorkersStop {
+					if f.shouldWorkersStop() {
 						elapsed := time.Since(start)
 						f.log.Infof("Canceled SummonerMatchesWorker run. Took %s", elapsed)
 						nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
 						continue WaitLoop
 					}
-					f.fetchSummonerMatchesByName(summonerName, uint32(f.config.FetchMatchesForSummonersNumber), additionalAccountIDs, knownLatestVersion)
+					err := f.getSummonerAccountIDByName(summonerName, summonerAccountIDs)
+					if err != nil {
+						f.log.Errorf("Error fetching summoner matches: %s", err)
+					}
 				}
 			}
+			f.addToCrawlFrontier(summonerAccountIDs, prioritySummoner)
 
-			f.log.Infof("Fetching matches for specified Leagues")
-			accountIDs := make(map[string]bool)
+			accountIDs := make(map[string]string)
 			for _, league := range f.config.FetchMatchesForLeagues {
 				if len(f.config.FetchMatchesForLeagueQueues) > 0 {
 
@@ -161,7 +255,7 @@ WaitLoop:
 							f.log.Errorf("Error fetching Account IDs for league %s queue %s: %s", league, queue, err)
 							continue
 						}
-						if f.shouldWorkersStop {
+						if f.shouldWorkersStop() {
 							elapsed := time.Since(start)
 							f.log.Infof("Canceled SummonerMatchesWorker run. Took %s", elapsed)
 							nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
@@ -171,33 +265,84 @@ WaitLoop:
 				}
 			}
 
-			f.log.Infof("Found %d unique Account IDs in specified Leagues. Fetching matches", len(accountIDs))
-			for accountID := range accountIDs {
-				if f.shouldWorkersStop {
-					elapsed := time.Since(start)
-					f.log.Infof("Canceled SummonerMatchesWorker run. Took %s", elapsed)
-					nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
-					continue WaitLoop
+			if len(f.config.FetchMatchesForTiers) > 0 && f.config.FetchMatchesForTiersSampleSize > 0 {
+				f.log.Infof("Sampling Summoners for specified Tiers")
+				for _, queue := range f.config.FetchMatchesForLeagueQueues {
+					for _, tier := range f.config.FetchMatchesForTiers {
+						for _, division := range f.tierDivisions() {
+							f.log.Infof("Sampling Summoner Account IDs for Tier %s %s and Queue %s", tier, division, queue)
+							err := f.getTierSampleSummonerAccountIDs(queue, tier, division, accountIDs)
+							if err != nil {
+								f.log.Errorf("Error sampling Account IDs for tier %s %s queue %s: %s", tier, division, queue, err)
+								continue
+							}
+							if f.shouldWorkersStop() {
+								elapsed := time.Since(start)
+								f.log.Infof("Canceled SummonerMatchesWorker run. Took %s", elapsed)
+								nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
+								continue WaitLoop
+							}
+						}
+					}
 				}
-				f.fetchSummonerMatchesByAccountID(accountID, uint32(f.config.FetchMatchesForLeaguesNumber), additionalAccountIDs, knownLatestVersion)
 			}
 
-			for accountID := range accountIDs {
-				delete(additionalAccountIDs, accountID)
-			}
+			f.log.Infof("Found %d unique Account IDs in specified Leagues and Tiers", len(accountIDs))
+			f.addToCrawlFrontier(accountIDs, priorityLeague)
 
+			// Accounts crawled within the update interval were crawled by this run or by an interrupted one,
+			// e.g., before a restart, so they are skipped
+			crawledBefore := start.Add(-time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches))
+			minPriority := priorityLeague
 			if f.config.FetchMatchesForSeenSummoners {
-				f.log.Infof("Found %d additional unique Account IDs in fetched matches. Fetching matches", len(additionalAccountIDs))
-				for accountID := range additionalAccountIDs {
-					if f.shouldWorkersStop {
+				minPriority = prioritySeen
+			}
+
+			f.log.Infof("Fetching matches for Summoners in crawl frontier")
+			crawled := 0
+		CrawlLoop:
+			for {
+				if err := f.crawls.error(); err != nil {
+					// The accounts would be returned again and again without their progress
+					f.log.Errorf("Error storing crawl progress, stopping crawl: %s", err)
+					break
+				}
+				batch, err := f.storage.GetCrawlBatch(f.config.Region, minPriority, crawledBefore, f.crawlBatchSize())
+				if err != nil {
+					f.log.Errorf("Error getting next Summoners from crawl frontier: %s", err)
+					break
+				}
+				if len(batch) == 0 {
+					break
+				}
+				started := 0
+				for _, entry := range batch {
+					if f.shouldWorkersStop() {
 						elapsed := time.Since(start)
-						f.log.Infof("Canceled SummonerMatchesWorker run. Took %s", elapsed)
+						f.log.Infof("Canceled SummonerMatchesWorker run after crawling %d Summoners. Took %s", crawled, elapsed)
 						nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
 						continue WaitLoop
 					}
-					f.fetchSummonerMatchesByAccountID(accountID, uint32(f.config.FetchMatchesForLeaguesNumber), nil, knownLatestVersion)
+					if f.crawls.contains(entry.AccountID) {
+						// Its matches are still being downloaded
+						continue
+					}
+					if err := f.crawlAccount(entry, knownLatestVersion); err != nil {
+						// The account would be returned again and again without its progress
+						f.log.Errorf("Error storing crawl progress of Account ID %s, stopping crawl: %s", entry.AccountID, err)
+						break CrawlLoop
+					}
+					started++
+					crawled++
+				}
+				if started == 0 {
+					// All accounts of the batch are still being crawled, they are returned until their progress is stored
+					f.crawls.wait()
 				}
 			}
+			f.crawls.wait()
+			f.log.Infof("Crawled %d Summoners", crawled)
+			f.logTierProgress(f.tierTargets)
 
 			nextUpdate = time.Minute * time.Duration(f.config.UpdateIntervalSummonerMatches)
 
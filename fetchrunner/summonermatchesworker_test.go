@@ -0,0 +1,217 @@
+package fetchrunner
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/memorybackend"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// matchlistClient is a Riot client serving match lists of the matches per queue like the Riot API does
+type matchlistClient struct {
+	riotclient.Client
+
+	multipleQueues bool
+	matches        map[int][]riotclient.MatchReferenceDTO // per queue, newest first
+	requests       []string
+
+	mutex   sync.Mutex
+	fetched map[int64]bool // game ids of the fetched matches
+}
+
+// MatchByID returns matches created at 1000 times the game id. Matches with game ids up to 200 are of game version 9.9, the newer ones of 9.10.
+func (c *matchlistClient) MatchByID(id uint64) (*riotclient.MatchDTO, error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if c.fetched == nil {
+		c.fetched = make(map[int64]bool)
+	}
+	c.fetched[int64(id)] = true
+
+	gameVersion := "9.10.1.1"
+	if id <= 200 {
+		gameVersion = "9.9.1.1"
+	}
+	return &riotclient.MatchDTO{GameID: int64(id), PlatformID: "EUW1", QueueID: 420, MapID: 11, GameVersion: gameVersion, GameCreation: int64(id) * 1000}, nil
+}
+
+func (c *matchlistClient) MultipleMatchlistQueues() bool {
+	return c.multipleQueues
+}
+
+func (c *matchlistClient) MatchesByAccountID(accountID string, args map[string]string) (*riotclient.MatchlistDTO, error) {
+	c.requests = append(c.requests, fmt.Sprintf("%s %s-%s", args["queue"], args["beginIndex"], args["endIndex"]))
+	if strings.Contains(args["queue"], ",") && !c.multipleQueues {
+		return nil, fmt.Errorf("Several queues are not supported")
+	}
+
+	var matches []riotclient.MatchReferenceDTO
+	for _, queue := range strings.Split(args["queue"], ",") {
+		id, _ := strconv.Atoi(queue)
+		matches = append(matches, c.matches[id]...)
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].GameID > matches[j].GameID })
+
+	begin, _ := strconv.Atoi(args["beginIndex"])
+	end, _ := strconv.Atoi(args["endIndex"])
+	if end-begin > 100 || end <= begin {
+		return nil, fmt.Errorf("Invalid index range %d-%d", begin, end)
+	}
+	total := len(matches)
+	if begin >= total {
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "Not found")
+	}
+	if end > total {
+		end = total
+	}
+	return &riotclient.MatchlistDTO{Matches: matches[begin:end], StartIndex: begin, EndIndex: end, TotalGames: total}, nil
+}
+
+// testQueueMatches returns the game ids 1 to 250 in the queues 420 and 440. Of the newest 100 matches every second one is of 440.
+func testQueueMatches() map[int][]riotclient.MatchReferenceDTO {
+	matches := make(map[int][]riotclient.MatchReferenceDTO)
+	for id := int64(250); id >= 1; id-- {
+		queue := 420
+		if id > 150 && id%2 == 1 {
+			queue = 440
+		}
+		matches[queue] = append(matches[queue], riotclient.MatchReferenceDTO{GameID: id, Queue: queue})
+	}
+	return matches
+}
+
+func newMatchlistTestRunner(t *testing.T, client *matchlistClient) (*FetchRunner, *storage.Storage) {
+	s, err := storage.NewStorage(config.LoLStorage{DefaultRiotClient: "euw1"}, map[string]riotclient.Client{"euw1": client}, nil)
+	if err != nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+	return &FetchRunner{config: config.FetchRunner{Region: "euw1"}, log: logging.Get("FetchRunner [test]")}, s
+}
+
+func TestFetchRunner_listMatches(t *testing.T) {
+	tests := []struct {
+		name           string
+		multipleQueues bool
+		number         uint32
+		wantRequests   []string
+		wantMatches    int
+		wantNewest     int64
+		wantOldest     int64
+	}{
+		{"One request for all queues, all matches", true, 0, []string{"420,440 0-100", "420,440 100-200", "420,440 200-300"}, 250, 250, 1},
+		{"One request for all queues, number per account", true, 150, []string{"420,440 0-100", "420,440 100-150"}, 150, 250, 101},
+		{"One request per queue, number per account", false, 120, []string{"420 0-100", "420 100-120", "440 0-100"}, 120, 250, 131},
+		{"One request per queue, all matches", false, 0, []string{"420 0-100", "420 100-200", "440 0-100"}, 250, 250, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &matchlistClient{multipleQueues: tt.multipleQueues, matches: testQueueMatches()}
+			f, s := newMatchlistTestRunner(t, client)
+
+			var matches []riotclient.MatchReferenceDTO
+			complete := f.listMatches(s, "a1", tt.number, storage.MatchlistFilter{Queues: []int{420, 440}}, func(page []riotclient.MatchReferenceDTO) bool {
+				if len(page) > matchlistPageSize {
+					t.Errorf("Got %d matches at once, want at most %d", len(page), matchlistPageSize)
+				}
+				matches = append(matches, page...)
+				return true
+			})
+			if !complete {
+				t.Errorf("listMatches returned incomplete")
+			}
+			if strings.Join(client.requests, "; ") != strings.Join(tt.wantRequests, "; ") {
+				t.Errorf("Got requests %v, want %v", client.requests, tt.wantRequests)
+			}
+			if len(matches) != tt.wantMatches {
+				t.Fatalf("Got %d matches, want %d", len(matches), tt.wantMatches)
+			}
+			if matches[0].GameID != tt.wantNewest || matches[len(matches)-1].GameID != tt.wantOldest {
+				t.Errorf("Got matches %d to %d, want %d to %d", matches[0].GameID, matches[len(matches)-1].GameID, tt.wantNewest, tt.wantOldest)
+			}
+			for i := 1; i < len(matches); i++ {
+				if matches[i].GameID >= matches[i-1].GameID {
+					t.Fatalf("Matches are not sorted from newest to oldest: %d before %d", matches[i-1].GameID, matches[i].GameID)
+				}
+			}
+		})
+	}
+}
+
+func TestFetchRunner_crawlAccount(t *testing.T) {
+	tests := []struct {
+		name              string
+		onlyLatestVersion bool
+		wantFetchedMin    int
+		wantFetchedMax    int
+	}{
+		{"All matches", false, 250, 250},
+		// The matches up to 200 are of an older game version, the first one of them stops fetching the older ones.
+		// Matches in flight on the other workers at that time are fetched nevertheless.
+		{"Only latest game version", true, 51, 51 + 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := memorybackend.NewBackend()
+			if err != nil {
+				t.Fatalf("Could not create memory backend: %s", err)
+			}
+			client := &matchlistClient{multipleQueues: true, matches: testQueueMatches()}
+			s, err := storage.NewStorage(config.LoLStorage{DefaultRiotClient: "euw1"}, map[string]riotclient.Client{"euw1": client}, backend)
+			if err != nil {
+				t.Fatalf("Could not get a new Storage: %s", err)
+			}
+
+			f := &FetchRunner{
+				config: config.FetchRunner{
+					Region:                     "euw1",
+					FetchMatchesConcurrency:    4,
+					FetchOnlyLatestGameVersion: tt.onlyLatestVersion,
+				},
+				storage:     s,
+				bulkStorage: s,
+				log:         logging.Get("FetchRunner [test]"),
+				queueIDs:    []int{420, 440},
+				stopWorkers: make(chan struct{}),
+			}
+			f.workersCtx, f.cancelWorkers = context.WithCancel(context.Background())
+			defer f.cancelWorkers()
+			f.startMatchFetchWorkers()
+			defer f.stopMatchFetchWorkers()
+
+			entry := storage.CrawlEntry{Region: "euw1", AccountID: "a1", Priority: priorityLeague}
+			if err := f.crawlAccount(entry, "9.10"); err != nil {
+				t.Fatalf("crawlAccount returned error: %s", err)
+			}
+			f.crawls.wait()
+
+			client.mutex.Lock()
+			fetched := len(client.fetched)
+			for id := int64(201); id <= 250; id++ {
+				if !client.fetched[id] {
+					t.Errorf("Match %d of the latest game version was not fetched", id)
+				}
+			}
+			client.mutex.Unlock()
+			if fetched < tt.wantFetchedMin || fetched > tt.wantFetchedMax {
+				t.Errorf("Fetched %d matches, want %d to %d", fetched, tt.wantFetchedMin, tt.wantFetchedMax)
+			}
+
+			stored, err := backend.GetCrawlEntry("euw1", "a1")
+			if err != nil {
+				t.Fatalf("Crawl progress was not stored: %s", err)
+			}
+			if stored.LastCrawled.IsZero() || stored.LastMatchTimestamp != 250000 {
+				t.Errorf("Stored crawl progress %+v, want the newest match 250000", stored)
+			}
+		})
+	}
+}
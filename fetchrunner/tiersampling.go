@@ -0,0 +1,77 @@
+package fetchrunner
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// allDivisions are the divisions sampled when no divisions are specified in the config
+var allDivisions = []string{"I", "II", "III", "IV"}
+
+// sampleLeagueEntries randomly picks n of the active league entries. All active entries are returned if there are not more than n.
+func sampleLeagueEntries(entries []riotclient.LeagueEntryDTO, n int, rnd *rand.Rand) []riotclient.LeagueEntryDTO {
+	active := make([]riotclient.LeagueEntryDTO, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Inactive {
+			active = append(active, entry)
+		}
+	}
+
+	if len(active) <= n {
+		return active
+	}
+
+	sample := make([]riotclient.LeagueEntryDTO, 0, n)
+	for _, i := range rnd.Perm(len(active))[:n] {
+		sample = append(sample, active[i])
+	}
+
+	return sample
+}
+
+// tierDivisions returns the divisions which shall be sampled per tier
+func (f *FetchRunner) tierDivisions() []string {
+	if len(f.config.FetchMatchesForTierDivisions) > 0 {
+		return f.config.FetchMatchesForTierDivisions
+	}
+	return allDivisions
+}
+
+// getTierSampleSummonerAccountIDs adds the Account IDs of a random sample of Summoners placed in a tier and division of a queue to accountIDs,
+// together with the tier
+func (f *FetchRunner) getTierSampleSummonerAccountIDs(queue string, tier string, division string, accountIDs map[string]string) error {
+	pages := int(f.config.FetchMatchesForTiersPages)
+	if pages < 1 {
+		pages = 1
+	}
+
+	var entries []riotclient.LeagueEntryDTO
+	for page := 1; page <= pages; page++ {
+		pageEntries, err := f.storage.GetRegionalLeagueEntries(f.config.Region, queue, tier, division, page)
+		if err != nil {
+			if len(entries) == 0 {
+				return fmt.Errorf("Error getting League Entries for %s %s: %s", tier, division, err)
+			}
+			f.log.Warnf("Error getting page %d of League Entries for %s %s, sampling from the first %d pages: %s", page, tier, division, page-1, err)
+			break
+		}
+		if len(pageEntries) == 0 {
+			break
+		}
+		entries = append(entries, pageEntries...)
+	}
+
+	for _, entry := range sampleLeagueEntries(entries, int(f.config.FetchMatchesForTiersSampleSize), f.rnd) {
+		summoner, err := f.storage.GetRegionalSummonerBySummonerID(f.config.Region, entry.SummonerID, false)
+		if err != nil {
+			f.log.Warnf("Could not get Summoner for Summoner ID %s: %s", entry.SummonerID, err)
+			continue
+		}
+		accountIDs[summoner.AccountID] = strings.ToUpper(tier)
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package fetchrunner
+
+import (
+	"math/rand"
+	"strconv"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func TestSampleLeagueEntries(t *testing.T) {
+	rnd := rand.New(rand.NewSource(42))
+
+	var entries []riotclient.LeagueEntryDTO
+	for i := 0; i < 10; i++ {
+		entries = append(entries, riotclient.LeagueEntryDTO{SummonerID: strconv.Itoa(i), Inactive: i%2 == 1})
+	}
+
+	all := sampleLeagueEntries(entries, 10, rnd)
+	if len(all) != 5 {
+		t.Fatalf("Expected all 5 active entries, got %d", len(all))
+	}
+	for _, entry := range all {
+		if entry.Inactive {
+			t.Errorf("Inactive entry %s was sampled", entry.SummonerID)
+		}
+	}
+
+	sample := sampleLeagueEntries(entries, 3, rnd)
+	if len(sample) != 3 {
+		t.Fatalf("Expected 3 sampled entries, got %d", len(sample))
+	}
+	seen := make(map[string]bool)
+	for _, entry := range sample {
+		if entry.Inactive {
+			t.Errorf("Inactive entry %s was sampled", entry.SummonerID)
+		}
+		if seen[entry.SummonerID] {
+			t.Errorf("Entry %s was sampled twice", entry.SummonerID)
+		}
+		seen[entry.SummonerID] = true
+	}
+
+	if len(sampleLeagueEntries(nil, 3, rnd)) != 0 {
+		t.Errorf("Expected empty sample for no entries")
+	}
+}
+
+func TestTierDivisions(t *testing.T) {
+	f := &FetchRunner{}
+	if divisions := f.tierDivisions(); len(divisions) != 4 {
+		t.Errorf("Expected all divisions by default, got %v", divisions)
+	}
+
+	f.config.FetchMatchesForTierDivisions = []string{"I"}
+	if divisions := f.tierDivisions(); len(divisions) != 1 || divisions[0] != "I" {
+		t.Errorf("Expected configured divisions, got %v", divisions)
+	}
+}
@@ -0,0 +1,145 @@
+package fetchrunner
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/utils"
+)
+
+// rankedTiers are the tiers which have a target number of matches, from lowest to highest
+var rankedTiers = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER"}
+
+// tierTargets counts the matches of a game version per tier and queue, the tier of a match is determined like in the StatsRunner.
+// It is safe for concurrent use by the match fetch workers.
+type tierTargets struct {
+	gameVersion string // major.minor, e.g., 9.10
+	target      uint32
+	queues      []int
+
+	mutex  sync.Mutex
+	counts map[string]map[int]uint32 // [tier][queue]
+}
+
+func newTierTargets(gameVersion string, target uint32, queues []int) *tierTargets {
+	return &tierTargets{
+		gameVersion: gameVersion,
+		target:      target,
+		queues:      queues,
+		counts:      make(map[string]map[int]uint32),
+	}
+}
+
+// add counts a match if it is of the game version of the targets
+func (t *tierTargets) add(match *riotclient.MatchDTO) {
+	version, err := utils.SplitNumericMatchVersion(match.GameVersion)
+	if err != nil || fmt.Sprintf("%d.%d", version[0], version[1]) != t.gameVersion {
+		return
+	}
+
+	tier := riotclient.MatchTier(match.Participants)
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if t.counts[tier] == nil {
+		t.counts[tier] = make(map[int]uint32)
+	}
+	t.counts[tier][match.QueueID]++
+}
+
+// missingQueues returns the queues in which less matches than the target are known for a tier.
+// There are none for tiers without a target, e.g., UNRANKED.
+func (t *tierTargets) missingQueues(tier string) []int {
+	tier = strings.ToUpper(strings.TrimSpace(tier))
+	if !isRankedTier(tier) {
+		return nil
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	var queues []int
+	for _, queue := range t.queues {
+		if t.counts[tier][queue] < t.target {
+			queues = append(queues, queue)
+		}
+	}
+	return queues
+}
+
+// underrepresented returns true if less matches than the target are known for a tier in one of the queues
+func (t *tierTargets) underrepresented(tier string) bool {
+	return len(t.missingQueues(tier)) > 0
+}
+
+// progress describes for every tier how many matches per queue are known compared to the target, e.g., "GOLD: 420 123/500, 440 500/500"
+func (t *tierTargets) progress() []string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	lines := make([]string, 0, len(rankedTiers))
+	for _, tier := range rankedTiers {
+		queues := make([]string, 0, len(t.queues))
+		for _, queue := range t.queues {
+			queues = append(queues, fmt.Sprintf("%d %d/%d", queue, t.counts[tier][queue], t.target))
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", tier, strings.Join(queues, ", ")))
+	}
+	return lines
+}
+
+func isRankedTier(tier string) bool {
+	for _, rankedTier := range rankedTiers {
+		if tier == rankedTier {
+			return true
+		}
+	}
+	return false
+}
+
+// newRunTierTargets creates the tier targets for a run of the SummonerMatchesWorker from the stored matches of the
+// game version LatestGameVersionForFetching. It returns nil if the tier targets are disabled.
+func (f *FetchRunner) newRunTierTargets() *tierTargets {
+	if f.config.FetchMatchesPerTierTarget == 0 {
+		return nil
+	}
+	version, err := utils.SplitNumericVersion(f.config.LatestGameVersionForFetching)
+	if err != nil {
+		f.log.Warnf("LatestGameVersionForFetching specified in config is invalid, disabling FetchMatchesPerTierTarget, err was: %s", err)
+		return nil
+	}
+
+	targets := newTierTargets(fmt.Sprintf("%d.%d", version[0], version[1]), f.config.FetchMatchesPerTierTarget, f.queueIDs)
+
+	cur, err := f.storage.GetStoredMatchesCursorByGameVersion(f.config.Region, fmt.Sprintf("%d\\.%d\\.", version[0], version[1]))
+	if err != nil {
+		f.log.Errorf("Error counting the stored matches per tier, disabling FetchMatchesPerTierTarget for this run: %s", err)
+		return nil
+	}
+	defer cur.Close()
+
+	for cur.Next() {
+		match := &riotclient.MatchDTO{}
+		if err := cur.Decode(match); err != nil {
+			f.log.Errorf("Error decoding match: %s", err)
+			continue
+		}
+		if f.matchFilter.Accepts(match) {
+			targets.add(match)
+		}
+	}
+
+	return targets
+}
+
+// logTierProgress reports how many matches per tier and queue are known compared to the target
+func (f *FetchRunner) logTierProgress(targets *tierTargets) {
+	if targets == nil {
+		return
+	}
+	f.log.Infof("Matches per tier and queue for game version %s (target %d):", targets.gameVersion, targets.target)
+	for _, line := range targets.progress() {
+		f.log.Infof("  %s", line)
+	}
+}
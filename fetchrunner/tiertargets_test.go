@@ -0,0 +1,57 @@
+package fetchrunner
+
+import (
+	"reflect"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func tierMatch(gameVersion string, queue int, tiers ...string) *riotclient.MatchDTO {
+	match := &riotclient.MatchDTO{GameVersion: gameVersion, QueueID: queue}
+	for i, tier := range tiers {
+		match.Participants = append(match.Participants, riotclient.ParticipantDTO{ParticipantID: i + 1, HighestAchievedSeasonTier: tier})
+	}
+	return match
+}
+
+func TestTierTargets(t *testing.T) {
+	targets := newTierTargets("9.10", 2, []int{420, 440})
+
+	targets.add(tierMatch("9.10.277.1234", 420, "GOLD", "GOLD", "SILVER"))
+	targets.add(tierMatch("9.10.277.1234", 420, "gold ", "GOLD"))
+	targets.add(tierMatch("9.10.277.1234", 440, "GOLD"))
+	// Other game versions and invalid ones are not counted
+	targets.add(tierMatch("9.9.275.1234", 440, "GOLD"))
+	targets.add(tierMatch("invalid", 440, "GOLD"))
+	targets.add(tierMatch("9.10.277.1234", 420, ""))
+
+	if queues := targets.missingQueues("GOLD"); !reflect.DeepEqual(queues, []int{440}) {
+		t.Errorf("missingQueues(GOLD) = %v, want [440]", queues)
+	}
+	if queues := targets.missingQueues("bronze"); !reflect.DeepEqual(queues, []int{420, 440}) {
+		t.Errorf("missingQueues(bronze) = %v, want [420 440]", queues)
+	}
+	// Tiers without a target are never under-represented
+	for _, tier := range []string{"UNRANKED", ""} {
+		if targets.underrepresented(tier) {
+			t.Errorf("Tier %q is under-represented", tier)
+		}
+	}
+	if !targets.underrepresented("GOLD") {
+		t.Errorf("Tier GOLD is not under-represented")
+	}
+
+	targets.add(tierMatch("9.10.277.1234", 440, "GOLD"))
+	if targets.underrepresented("GOLD") {
+		t.Errorf("Tier GOLD is still under-represented after reaching the target")
+	}
+
+	progress := targets.progress()
+	if len(progress) != len(rankedTiers) {
+		t.Fatalf("Expected progress for %d tiers, got %d", len(rankedTiers), len(progress))
+	}
+	if progress[3] != "GOLD: 420 2/2, 440 2/2" || progress[0] != "IRON: 420 0/2, 440 0/2" {
+		t.Errorf("Wrong progress: %v", progress)
+	}
+}
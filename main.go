@@ -10,15 +10,20 @@ import (
 	"strings"
 
 	"git.abyle.org/hps/alolstats/api"
+	"git.abyle.org/hps/alolstats/boltbackend"
 	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/dragontail"
 	"git.abyle.org/hps/alolstats/fetchrunner"
 	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/memorybackend"
 	"git.abyle.org/hps/alolstats/mongobackend"
 	"git.abyle.org/hps/alolstats/riotclient"
 	"git.abyle.org/hps/alolstats/riotclientv4"
+	"git.abyle.org/hps/alolstats/riotclientv5"
 	"git.abyle.org/hps/alolstats/statsrunner"
 	"git.abyle.org/hps/alolstats/storage"
 
+	riotclientcassette "git.abyle.org/hps/alolstats/riotclient/cassette"
 	riotclientdd "git.abyle.org/hps/alolstats/riotclient/datadragon"
 	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
 
@@ -40,6 +45,9 @@ var compTime string
 var configPath string
 var loggingLevel string
 var logFile string
+var dragontailFile string
+var dragontailVersion string
+var dragontailLanguage string
 
 var interrupt chan os.Signal
 
@@ -49,6 +57,9 @@ func init() {
 	flag.StringVar(&configPath, "c", defaultConfigPath, "Path to toml config file")
 	flag.StringVar(&loggingLevel, "l", defaultLoggingLevel, "Logging level (panic, fatal, error, warn/warning, info or debug)")
 	flag.StringVar(&logFile, "L", "", "Log file to use")
+	flag.StringVar(&dragontailFile, "import-dragontail", "", "Import the static data of a Data Dragon dragontail .tgz archive into the storage backend and exit")
+	flag.StringVar(&dragontailVersion, "dragontail-version", "", "Game version to import from the dragontail archive (default: all versions in the archive)")
+	flag.StringVar(&dragontailLanguage, "dragontail-champions-language", "en_US", "Language of the champions imported from the dragontail archive")
 	flag.Parse()
 }
 
@@ -72,6 +83,20 @@ func storageBackendCreator(cfg config.StorageBackend) (storage.Backend, error) {
 			return nil, err
 		}
 		return backend, nil
+	case "memory":
+		backend, err := memorybackend.NewBackend()
+		if err != nil {
+			log.Errorln("Error creating the Memory Storage Backend:" + err.Error())
+			return nil, err
+		}
+		return backend, nil
+	case "bolt":
+		backend, err := boltbackend.NewBackend(cfg.BoltBackend)
+		if err != nil {
+			log.Errorln("Error creating the Bolt Storage Backend:" + err.Error())
+			return nil, err
+		}
+		return backend, nil
 	default:
 		return nil, fmt.Errorf("Unknown storage backend specified in config: %s", cfg.Backend)
 	}
@@ -83,8 +108,17 @@ func riotClientCreator(cfg config.RiotClient) (riotclient.Client, error) {
 	switch version {
 	case "v3":
 		return nil, fmt.Errorf("API v3 is not supported anymore")
-	case "v4":
+	case "v4", "v5":
 		httpClient := &http.Client{}
+		if len(cfg.Cassette.Mode) > 0 {
+			transport, err := riotclientcassette.New(cfg.Cassette, http.DefaultTransport)
+			if err != nil {
+				log.Errorln("Error creating Riot Client Cassette:" + err.Error())
+				return nil, err
+			}
+			log.Warnf("Riot Client for %s uses cassette %s in %s mode", cfg.Region, cfg.Cassette.File, cfg.Cassette.Mode)
+			httpClient.Transport = transport
+		}
 		ddragon, err := riotclientdd.New(httpClient, cfg)
 		if err != nil {
 			log.Errorln("Error creating Riot Client Data Dragon:" + err.Error())
@@ -96,6 +130,16 @@ func riotClientCreator(cfg config.RiotClient) (riotclient.Client, error) {
 			return nil, err
 		}
 
+		if version == "v5" {
+			riotClient, err := riotclientv5.NewClient(httpClient, cfg, ddragon, rateLimit)
+			if err != nil {
+				log.Errorln("Error creating RiotClient APIVersion V5:" + err.Error())
+				return nil, err
+			}
+
+			return riotClient, nil
+		}
+
 		riotClient, err := riotclientv4.NewClient(httpClient, cfg, ddragon, rateLimit)
 		if err != nil {
 			log.Errorln("Error creating RiotClient APIVersion V4:" + err.Error())
@@ -135,6 +179,33 @@ func main() {
 	}
 	api.AttachModuleGet("/status", statusEndpoint)
 
+	backend, err := storageBackendCreator(cfg.StorageBackend)
+	if err != nil {
+		log.Fatalf("Error creating the Storage Backend: %s", err)
+	}
+	err = backend.Connect()
+	if err != nil {
+		log.Fatalf("Error connecting the Storage Backend: %s", err)
+	}
+
+	if len(dragontailFile) > 0 {
+		summary, err := dragontail.ImportFile(dragontailFile, dragontailVersion, dragontailLanguage, backend)
+		if err != nil {
+			log.Fatalf("Error importing dragontail archive %s: %s", dragontailFile, err)
+		}
+		log.Printf("Imported %d files of game versions %s in languages %s from dragontail archive %s",
+			summary.Files, strings.Join(summary.Versions, ", "), strings.Join(summary.Languages, ", "), dragontailFile)
+		if len(summary.ChampionsVersion) > 0 {
+			log.Printf("Imported champions of game version %s in language %s", summary.ChampionsVersion, dragontailLanguage)
+		} else {
+			log.Warnf("No champions in language %s found in dragontail archive", dragontailLanguage)
+		}
+		if err := backend.Close(); err != nil {
+			log.Errorf("Error closing the Storage Backend: %s", err)
+		}
+		return
+	}
+
 	clients := make(map[string]riotclient.Client)
 	for name, clientConfig := range cfg.RiotClient {
 		client, err := riotClientCreator(clientConfig)
@@ -145,15 +216,6 @@ func main() {
 		clients[name] = client
 	}
 
-	backend, err := storageBackendCreator(cfg.StorageBackend)
-	if err != nil {
-		log.Fatalf("Error creating the Storage Backend: %s", err)
-	}
-	err = backend.Connect()
-	if err != nil {
-		log.Fatalf("Error connecting the Storage Backend: %s", err)
-	}
-
 	storage, err := storage.NewStorage(cfg.LoLStorage, clients, backend)
 	if err != nil {
 		log.Fatalf("Error creating the Storage: %s", err)
@@ -193,6 +255,9 @@ func main() {
 			for _, client := range clients {
 				client.Stop()
 			}
+			if err := backend.Close(); err != nil {
+				log.Errorf("Error closing the Storage Backend: %s", err)
+			}
 			log.Printf("Storage handeled %d requests since startup", storage.GetHandeledRequests())
 			log.Printf("StatsRunner handeled %d requests since startup", statsRunner.GetHandeledRequests())
 			log.Println("ALoLStats gracefully shut down")
@@ -0,0 +1,65 @@
+package memorybackend
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetChampions gets the champions list from storage
+func (b *Backend) GetChampions() (riotclient.ChampionsList, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	championsList := make(riotclient.ChampionsList)
+	for _, champion := range b.champions {
+		championsList[champion.ID] = champion
+	}
+
+	clonedList := make(riotclient.ChampionsList)
+	if err := clone(championsList, &clonedList); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return clonedList, nil
+}
+
+// GetChampionsTimeStamp gets the timestamp of the stored champions list
+func (b *Backend) GetChampionsTimeStamp() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	if len(b.champions) == 0 {
+		return time.Time{}
+	}
+
+	// Find oldest champ time
+	oldest := time.Now()
+	for _, champion := range b.champions {
+		if oldest.Sub(champion.Timestamp) > 0 {
+			oldest = champion.Timestamp
+		}
+	}
+
+	return oldest
+}
+
+// StoreChampions stores a new champions list
+func (b *Backend) StoreChampions(championList riotclient.ChampionsList) error {
+	b.log.Debugf("Storing Champions in storage")
+
+	storedList := make(riotclient.ChampionsList)
+	if err := clone(championList, &storedList); err != nil {
+		return fmt.Errorf("There were errors saving the champions: %s", err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, champion := range storedList {
+		b.champions[champion.Key] = champion
+	}
+
+	return nil
+}
@@ -0,0 +1,40 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetChampionDetails gets the full data of a champion for a game version and language from storage
+func (b *Backend) GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.championDetails[key(championID, gameVersion, language)]
+	if !ok {
+		return nil, fmt.Errorf("No Champion Details found for Champion ID %s, GameVersion %s and Language %s", championID, gameVersion, language)
+	}
+
+	championDetails := riotclient.ChampionDetails{}
+	if err := clone(stored, &championDetails); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Details for Champion ID %s, GameVersion %s and Language %s: %s", championID, gameVersion, language, err)
+	}
+
+	return &championDetails, nil
+}
+
+// StoreChampionDetails stores the full data of a champion for a game version and language
+func (b *Backend) StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error {
+	stored := riotclient.ChampionDetails{}
+	if err := clone(championDetails, &stored); err != nil {
+		return fmt.Errorf("Error saving Champion Details %s for gameversion %s and language %s: %s", championDetails.ID, gameVersion, language, err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.championDetails[key(stored.ID, gameVersion, language)] = stored
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package memorybackend
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetChampionMasteries returns the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteries(summonerID string) (*storage.ChampionMasteries, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.championMasteries[summonerID]
+	if !ok {
+		return nil, fmt.Errorf("Champion Masteries for Summoner ID %s not found in storage backend", summonerID)
+	}
+
+	masteries := storage.ChampionMasteries{}
+	if err := clone(stored, &masteries); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &masteries, nil
+}
+
+// GetChampionMasteriesTimeStamp gets the timestamp of the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteriesTimeStamp(summonerID string) time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.championMasteries[summonerID].ChampionMasteryDTOList.Timestamp
+}
+
+// StoreChampionMasteries stores the Champion Masteries of a Summoner, replacing previously stored ones
+func (b *Backend) StoreChampionMasteries(masteries *storage.ChampionMasteries) error {
+	b.log.Debugf("Storing Champion Masteries for Summoner ID %s in storage", masteries.SummonerID)
+
+	stored := storage.ChampionMasteries{}
+	if err := clone(masteries, &stored); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.championMasteries[masteries.SummonerID] = stored
+
+	return nil
+}
@@ -0,0 +1,18 @@
+package memorybackend
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/storage"
+	"git.abyle.org/hps/alolstats/storage/backendtest"
+)
+
+func TestConformance(t *testing.T) {
+	backendtest.Run(t, func(t *testing.T) (storage.Backend, func()) {
+		backend, err := NewBackend()
+		if err != nil {
+			t.Fatalf("Could not get a new Memory Backend: %s", err)
+		}
+		return backend, func() {}
+	})
+}
@@ -0,0 +1,52 @@
+package memorybackend
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetCrawlEntry returns the crawl entry of an account in a region
+func (b *Backend) GetCrawlEntry(region string, accountID string) (*storage.CrawlEntry, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	entry, ok := b.crawlFrontier[key(region, accountID)]
+	if !ok {
+		return nil, fmt.Errorf("No crawl entry found for Account ID %s in region %s", accountID, region)
+	}
+
+	return &entry, nil
+}
+
+// GetCrawlEntries returns up to limit crawl entries of a region with at least minPriority which were not crawled since crawledBefore
+func (b *Backend) GetCrawlEntries(region string, minPriority int, crawledBefore time.Time, limit int) ([]storage.CrawlEntry, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	entries := []storage.CrawlEntry{}
+	for _, entry := range b.crawlFrontier {
+		if entry.Region == region && entry.Priority >= minPriority && entry.LastCrawled.Before(crawledBefore) {
+			entries = append(entries, entry)
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool { return storage.CrawlEntryLess(entries[i], entries[j]) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+
+	return entries, nil
+}
+
+// StoreCrawlEntry stores a crawl entry, replacing the stored one of the same account and region
+func (b *Backend) StoreCrawlEntry(entry *storage.CrawlEntry) error {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.crawlFrontier[key(entry.Region, entry.AccountID)] = *entry
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package memorybackend
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetFreeRotation gets the stored free champions rotation
+func (b *Backend) GetFreeRotation() (*riotclient.FreeRotation, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	freeRotation := riotclient.FreeRotation{}
+	if err := clone(b.freeRotation, &freeRotation); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &freeRotation, nil
+}
+
+// GetFreeRotationTimeStamp gets the timestamp of the stored free champions rotation
+func (b *Backend) GetFreeRotationTimeStamp() time.Time {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.freeRotation.Timestamp
+}
+
+// StoreFreeRotation stores a new free champions rotation list
+func (b *Backend) StoreFreeRotation(data *riotclient.FreeRotation) error {
+	b.log.Debugf("Storing Free Rotation in storage")
+
+	freeRotation := riotclient.FreeRotation{}
+	if err := clone(data, &freeRotation); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.freeRotation = freeRotation
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetMatchesCursorByGameVersion returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (b *Backend) GetMatchesCursorByGameVersion(platformID string, gameVersion string) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	return newMatchCursor(b.findMatches(func(match *riotclient.MatchDTO) bool {
+		return isOnPlatform(match, platformID) &&
+			versionMatcher.MatchString(match.GameVersion)
+	})), nil
+}
+
+// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	return newMatchCursor(b.findMatches(func(match *riotclient.MatchDTO) bool {
+		return isOnPlatform(match, platformID) &&
+			versionMatcher.MatchString(match.GameVersion) &&
+			uint64(match.MapID) == mapID &&
+			uint64(match.QueueID) <= ltequeue && uint64(match.QueueID) >= gtequeue &&
+			hasChampion(match, championID)
+	})), nil
+}
+
+// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	return newMatchCursor(b.findMatches(func(match *riotclient.MatchDTO) bool {
+		return isOnPlatform(match, platformID) &&
+			versionMatcher.MatchString(match.GameVersion) &&
+			uint64(match.MapID) == mapID &&
+			uint64(match.QueueID) <= ltequeue && uint64(match.QueueID) >= gtequeue
+	})), nil
+}
+
+// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue id
+func (b *Backend) GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueID uint64) (storage.QueryCursor, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	return newMatchCursor(b.findMatches(func(match *riotclient.MatchDTO) bool {
+		return isOnPlatform(match, platformID) &&
+			versionMatcher.MatchString(match.GameVersion) &&
+			uint64(match.MapID) == mapID &&
+			uint64(match.QueueID) == queueID
+	})), nil
+}
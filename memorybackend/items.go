@@ -0,0 +1,44 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetItems gets the items list from storage
+func (b *Backend) GetItems(gameVersion, language string) (riotclient.ItemList, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	itemList := make(riotclient.ItemList)
+	if items, ok := b.items[key(gameVersion, language)]; ok {
+		if err := clone(items, &itemList); err != nil {
+			return nil, fmt.Errorf("GetItems decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+		}
+	}
+
+	return itemList, nil
+}
+
+// StoreItems stores a new items list
+func (b *Backend) StoreItems(gameVersion, language string, itemList riotclient.ItemList) error {
+	storedList := make(riotclient.ItemList)
+	if err := clone(itemList, &storedList); err != nil {
+		return fmt.Errorf("Error saving Items for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	items, ok := b.items[key(gameVersion, language)]
+	if !ok {
+		items = make(riotclient.ItemList)
+		b.items[key(gameVersion, language)] = items
+	}
+	for _, item := range storedList {
+		items[item.Key] = item
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+package memorybackend
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetLeagueByQueue returns the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueue(region string, tier string, queue string) (*storage.League, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	snapshots := b.leagues[key(region, strings.ToUpper(tier), queue)]
+	if len(snapshots) == 0 {
+		return nil, fmt.Errorf("League %s for queue %s in region %s not found in storage backend", tier, queue, region)
+	}
+
+	league := storage.League{}
+	if err := clone(snapshots[len(snapshots)-1], &league); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &league, nil
+}
+
+// GetLeagueByQueueTimeStamp returns the timestamp of the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueueTimeStamp(region string, tier string, queue string) (time.Time, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	snapshots := b.leagues[key(region, strings.ToUpper(tier), queue)]
+	if len(snapshots) == 0 {
+		return time.Time{}, fmt.Errorf("League %s for queue %s in region %s not found in storage backend", tier, queue, region)
+	}
+
+	return snapshots[len(snapshots)-1].Timestamp, nil
+}
+
+// GetLeagueHistoryByQueue returns all stored snapshots of a league identified by region, tier and queue name
+// which were taken between from and to (inclusive), sorted from oldest to newest
+func (b *Backend) GetLeagueHistoryByQueue(region string, tier string, queue string, from time.Time, to time.Time) ([]storage.League, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	leagues := []storage.League{}
+	for _, snapshot := range b.leagues[key(region, strings.ToUpper(tier), queue)] {
+		if snapshot.Timestamp.Before(from) || snapshot.Timestamp.After(to) {
+			continue
+		}
+		league := storage.League{}
+		if err := clone(snapshot, &league); err != nil {
+			return nil, fmt.Errorf("Decode error: %s", err)
+		}
+		leagues = append(leagues, league)
+	}
+
+	return leagues, nil
+}
+
+// StoreLeague stores a new snapshot of a league. Older snapshots are kept to be able to look at the league history,
+// only a snapshot with the same timestamp is replaced.
+func (b *Backend) StoreLeague(league *storage.League) error {
+	b.log.Debugf("Storing League %s for queue %s in region %s in storage", league.Tier, league.Queue, league.Region)
+
+	stored := storage.League{}
+	if err := clone(league, &stored); err != nil {
+		return err
+	}
+	stored.Tier = strings.ToUpper(stored.Tier)
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	k := key(stored.Region, stored.Tier, stored.Queue)
+	snapshots := b.leagues[k]
+
+	// Keep the snapshots sorted by timestamp
+	i := sort.Search(len(snapshots), func(i int) bool { return !snapshots[i].Timestamp.Before(stored.Timestamp) })
+	if i < len(snapshots) && snapshots[i].Timestamp.Equal(stored.Timestamp) {
+		snapshots[i] = stored
+		return nil
+	}
+	snapshots = append(snapshots, storage.League{})
+	copy(snapshots[i+1:], snapshots[i:])
+	snapshots[i] = stored
+	b.leagues[k] = snapshots
+
+	return nil
+}
+
+func (b *Backend) leaguesForSummonerQuery(summonerName string) (*storage.SummonerLeagues, error) {
+	stored, ok := b.summonerLeagues[summonerName]
+	if !ok {
+		return nil, fmt.Errorf("Summoner League information not found in storage backend")
+	}
+
+	leagues := storage.SummonerLeagues{}
+	if err := clone(stored, &leagues); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &leagues, nil
+}
+
+// oldestLeagueTimeStamp returns the oldest timestamp of the stored Leagues of a Summoner
+func oldestLeagueTimeStamp(leagues *storage.SummonerLeagues) time.Time {
+	if len(leagues.LeaguePositionDTOList.LeaguePosition) == 0 {
+		return time.Time{}
+	}
+
+	oldest := time.Now()
+	for _, league := range leagues.LeaguePositionDTOList.LeaguePosition {
+		if oldest.Sub(league.Timestamp) > 0 {
+			oldest = league.Timestamp
+		}
+	}
+
+	return oldest
+}
+
+// GetLeaguesForSummoner returns the stores League information for a certain Summoner identified by its name
+func (b *Backend) GetLeaguesForSummoner(summonerName string) (*storage.SummonerLeagues, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.leaguesForSummonerQuery(strings.ToLower(summonerName))
+}
+
+// GetLeaguesForSummonerTimeStamp gets the timestamp of the stored Leagues for Summoner idenfitied by name
+func (b *Backend) GetLeaguesForSummonerTimeStamp(summonerName string) (time.Time, error) {
+	leagues, err := b.GetLeaguesForSummoner(summonerName)
+	if err != nil {
+		b.log.Errorf("Error getting Leagues for Summoner %s for TimeStamp: %s", summonerName, err)
+		return time.Time{}, fmt.Errorf("Error getting Leagues for Summoner %s for TimeStamp: %s", summonerName, err)
+	}
+
+	return oldestLeagueTimeStamp(leagues), nil
+}
+
+// GetLeaguesForSummonerBySummonerID returns the stores League information for a certain Summoner identified by its Summoner ID
+func (b *Backend) GetLeaguesForSummonerBySummonerID(summonerID string) (*storage.SummonerLeagues, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	summonerName, ok := b.summonerLeaguesByID[summonerID]
+	if !ok {
+		return nil, fmt.Errorf("Summoner League information not found in storage backend")
+	}
+
+	return b.leaguesForSummonerQuery(summonerName)
+}
+
+// GetLeaguesForSummonerBySummonerIDTimeStamp gets the timestamp of the stored Leagues for Summoner idenfitied by ID
+func (b *Backend) GetLeaguesForSummonerBySummonerIDTimeStamp(summonerID string) (time.Time, error) {
+	leagues, err := b.GetLeaguesForSummonerBySummonerID(summonerID)
+	if err != nil {
+		b.log.Errorf("Error getting Leagues for Summoner ID %s for TimeStamp: %s", summonerID, err)
+		return time.Time{}, fmt.Errorf("Error getting Leagues for Summoner ID %s for TimeStamp: %s", summonerID, err)
+	}
+
+	return oldestLeagueTimeStamp(leagues), nil
+}
+
+// StoreLeaguesForSummoner stores new Summoner League information data
+func (b *Backend) StoreLeaguesForSummoner(leagues *storage.SummonerLeagues) error {
+	b.log.Debugf("Storing Summoner %s in storage", leagues.SummonerName)
+
+	stored := storage.SummonerLeagues{}
+	if err := clone(leagues, &stored); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	if len(stored.SummonerID) > 0 {
+		// Make sure we clean possible old entries for the same Summoner
+		if summonerName, ok := b.summonerLeaguesByID[stored.SummonerID]; ok {
+			delete(b.summonerLeagues, summonerName)
+		}
+		b.summonerLeaguesByID[stored.SummonerID] = stored.SummonerName
+	}
+	b.summonerLeagues[stored.SummonerName] = stored
+
+	return nil
+}
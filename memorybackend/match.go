@@ -0,0 +1,172 @@
+package memorybackend
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// matchKey returns the key of a match which is unique for game id and platform id
+func matchKey(gameID int64, platformID string) string {
+	return key(fmt.Sprintf("%020d", gameID), strings.ToUpper(platformID))
+}
+
+// gameVersionMatcher returns a matcher for game versions starting with gameVersion.
+// As with the MongoDB Backend, gameVersion is interpreted as a regular expression.
+func gameVersionMatcher(gameVersion string) (*regexp.Regexp, error) {
+	return regexp.Compile("^" + gameVersion)
+}
+
+func hasChampion(match *riotclient.MatchDTO, championID uint64) bool {
+	for _, participant := range match.Participants {
+		if uint64(participant.ChampionID) == championID {
+			return true
+		}
+	}
+	return false
+}
+
+// isOnPlatform returns true if the match was played on platformID, an empty platformID matches all platforms
+func isOnPlatform(match *riotclient.MatchDTO, platformID string) bool {
+	return len(platformID) == 0 || strings.EqualFold(match.PlatformID, platformID)
+}
+
+// GetMatch retreives match data for given platform and id
+func (b *Backend) GetMatch(platformID string, id uint64) (*riotclient.MatchDTO, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.matches[matchKey(int64(id), platformID)]
+	if !ok {
+		return nil, fmt.Errorf("Match with id=%d on platform %s not found in storage backend", id, platformID)
+	}
+
+	match := riotclient.MatchDTO{}
+	if err := clone(stored, &match); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &match, nil
+}
+
+// GetMatchesCount returns the number of stored Matches in the Backend
+func (b *Backend) GetMatchesCount() (uint64, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return uint64(len(b.matches)), nil
+}
+
+// StoreMatch stores new match data
+func (b *Backend) StoreMatch(data *riotclient.MatchDTO) error {
+	b.log.Debugf("Storing Match id=%d in storage", data.GameID)
+
+	match := riotclient.MatchDTO{}
+	if err := clone(data, &match); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	k := matchKey(match.GameID, match.PlatformID)
+	if _, ok := b.matches[k]; ok {
+		return fmt.Errorf("Match with id=%d and platform id %s already stored", match.GameID, match.PlatformID)
+	}
+	b.matches[k] = match
+
+	return nil
+}
+
+// findMatches returns a snapshot of all stored matches for which filter returns true, in ascending game id order.
+// The returned matches must not be modified.
+func (b *Backend) findMatches(filter func(match *riotclient.MatchDTO) bool) []riotclient.MatchDTO {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	keys := make([]string, 0)
+	for k, match := range b.matches {
+		if filter(&match) {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	matches := make([]riotclient.MatchDTO, 0, len(keys))
+	for _, k := range keys {
+		matches = append(matches, b.matches[k])
+	}
+
+	return matches
+}
+
+// collectMatches returns copies of all stored matches for which filter returns true
+func (b *Backend) collectMatches(filter func(match *riotclient.MatchDTO) bool) (*riotclient.Matches, error) {
+	matches := riotclient.Matches{}
+	if err := clone(b.findMatches(filter), &matches.Matches); err != nil {
+		return nil, err
+	}
+
+	return &matches, nil
+}
+
+// GetMatchesByGameVersionAndChampionID returns all matches specific to a certain game version and champion id
+func (b *Backend) GetMatchesByGameVersionAndChampionID(gameVersion string, championID uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.collectMatches(func(match *riotclient.MatchDTO) bool {
+		return versionMatcher.MatchString(match.GameVersion) &&
+			hasChampion(match, championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s and Champion ID %d: %s", gameVersion, championID, err)
+	}
+
+	return matches, nil
+}
+
+// GetMatchesByGameVersionChampionIDMapQueue returns all matches specific to a certain game version, champion id, map id and queue id
+func (b *Backend) GetMatchesByGameVersionChampionIDMapQueue(gameVersion string, championID uint64, mapID uint64, queueID uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.collectMatches(func(match *riotclient.MatchDTO) bool {
+		return versionMatcher.MatchString(match.GameVersion) &&
+			uint64(match.MapID) == mapID &&
+			uint64(match.QueueID) == queueID &&
+			hasChampion(match, championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID %d: %s", gameVersion, championID, mapID, queueID, err)
+	}
+
+	return matches, nil
+}
+
+// GetMatchesByGameVersionChampionIDMapBetweenQueueIDs returns all matches specific to a certain game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (*riotclient.Matches, error) {
+	versionMatcher, err := gameVersionMatcher(gameVersion)
+	if err != nil {
+		return nil, fmt.Errorf("Invalid GameVersion %s: %s", gameVersion, err)
+	}
+
+	matches, err := b.collectMatches(func(match *riotclient.MatchDTO) bool {
+		return versionMatcher.MatchString(match.GameVersion) &&
+			uint64(match.MapID) == mapID &&
+			uint64(match.QueueID) <= ltequeue && uint64(match.QueueID) >= gtequeue &&
+			hasChampion(match, championID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, championID, mapID, gtequeue, ltequeue, err)
+	}
+
+	return matches, nil
+}
@@ -0,0 +1,49 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// MatchCursor is the cursor implementation for Match queries.
+// It iterates over a snapshot of the matches taken when the query was executed.
+type MatchCursor struct {
+	matches []riotclient.MatchDTO
+	current *riotclient.MatchDTO
+}
+
+func newMatchCursor(matches []riotclient.MatchDTO) *MatchCursor {
+	return &MatchCursor{
+		matches: matches,
+	}
+}
+
+// Next gets the next result from the cursor.
+// Returns true if there were no errors and there is a next result.
+func (c *MatchCursor) Next() bool {
+	if len(c.matches) == 0 {
+		c.current = nil
+		return false
+	}
+
+	c.current = &c.matches[0]
+	c.matches = c.matches[1:]
+
+	return true
+}
+
+// Decode decodes the current result
+func (c *MatchCursor) Decode(i interface{}) error {
+	if c.current == nil {
+		return fmt.Errorf("No current result to decode")
+	}
+	return clone(c.current, i)
+}
+
+// Close the cursor
+func (c *MatchCursor) Close() error {
+	c.matches = nil
+	c.current = nil
+	return nil
+}
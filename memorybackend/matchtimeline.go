@@ -0,0 +1,46 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetMatchTimeLine retreives the match timeline for given platform and id
+func (b *Backend) GetMatchTimeLine(platformID string, id uint64) (*riotclient.MatchTimelineDTO, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.timelines[matchKey(int64(id), platformID)]
+	if !ok {
+		return nil, fmt.Errorf("Match TimeLine with id=%d on platform %s not found in storage backend", id, platformID)
+	}
+
+	timeline := riotclient.MatchTimelineDTO{}
+	if err := clone(stored, &timeline); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &timeline, nil
+}
+
+// StoreMatchTimeLine stores the timeline of a match
+func (b *Backend) StoreMatchTimeLine(match *riotclient.MatchDTO, data *riotclient.MatchTimelineDTO) error {
+	b.log.Debugf("Storing Match TimeLine id=%d in storage", match.GameID)
+
+	timeline := riotclient.MatchTimelineDTO{}
+	if err := clone(data, &timeline); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	k := matchKey(match.GameID, match.PlatformID)
+	if _, ok := b.timelines[k]; ok {
+		return fmt.Errorf("Match TimeLine with id=%d and platform id %s already stored", match.GameID, match.PlatformID)
+	}
+	b.timelines[k] = timeline
+
+	return nil
+}
@@ -0,0 +1,128 @@
+// Package memorybackend provides an in-memory storage backend for use with the storage package.
+// Nothing is persisted, which makes it useful for tests and ephemeral deployments.
+package memorybackend
+
+import (
+	"encoding/json"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// Backend represents the Memory Backend. It is safe for concurrent use.
+type Backend struct {
+	log   *logrus.Entry
+	mutex sync.RWMutex
+
+	champions       map[string]riotclient.Champion
+	championDetails map[string]riotclient.ChampionDetails
+	freeRotation    riotclient.FreeRotation
+	gameVersions    storage.GameVersions
+
+	summonerSpells map[string]riotclient.SummonerSpellsList
+	runesReforged  map[string]riotclient.RunesReforgedList
+	items          map[string]riotclient.ItemList
+
+	leagues             map[string][]storage.League
+	summonerLeagues     map[string]storage.SummonerLeagues
+	summonerLeaguesByID map[string]string
+
+	matches   map[string]riotclient.MatchDTO
+	timelines map[string]riotclient.MatchTimelineDTO
+
+	summoners            map[string]storage.Summoner
+	summonersByID        map[string]string
+	summonersByAccountID map[string]string
+	summonersByPUUID     map[string]string
+
+	championMasteries map[string]storage.ChampionMasteries
+
+	championStats        map[string]storage.ChampionStatsStorage
+	championStatsSummary map[string]storage.ChampionStatsSummaryStorage
+	itemStats            map[string]storage.ItemStatsStorage
+	summonerSpellsStats  map[string]storage.SummonerSpellsStatsStorage
+	runesReforgedStats   map[string]storage.RunesReforgedStatsStorage
+
+	crawlFrontier map[string]storage.CrawlEntry
+}
+
+// NewBackend creates a new Memory Backend
+func NewBackend() (*Backend, error) {
+	b := &Backend{
+		log: logging.Get("Memory Storage Backend"),
+
+		champions:       make(map[string]riotclient.Champion),
+		championDetails: make(map[string]riotclient.ChampionDetails),
+
+		summonerSpells: make(map[string]riotclient.SummonerSpellsList),
+		runesReforged:  make(map[string]riotclient.RunesReforgedList),
+		items:          make(map[string]riotclient.ItemList),
+
+		leagues:             make(map[string][]storage.League),
+		summonerLeagues:     make(map[string]storage.SummonerLeagues),
+		summonerLeaguesByID: make(map[string]string),
+
+		matches:   make(map[string]riotclient.MatchDTO),
+		timelines: make(map[string]riotclient.MatchTimelineDTO),
+
+		summoners:            make(map[string]storage.Summoner),
+		summonersByID:        make(map[string]string),
+		summonersByAccountID: make(map[string]string),
+		summonersByPUUID:     make(map[string]string),
+
+		championMasteries: make(map[string]storage.ChampionMasteries),
+
+		championStats:        make(map[string]storage.ChampionStatsStorage),
+		championStatsSummary: make(map[string]storage.ChampionStatsSummaryStorage),
+		itemStats:            make(map[string]storage.ItemStatsStorage),
+		summonerSpellsStats:  make(map[string]storage.SummonerSpellsStatsStorage),
+		runesReforgedStats:   make(map[string]storage.RunesReforgedStatsStorage),
+
+		crawlFrontier: make(map[string]storage.CrawlEntry),
+	}
+
+	return b, nil
+}
+
+// Connect does nothing for the Memory Backend, it is ready to use after creation
+func (b *Backend) Connect() error {
+	return nil
+}
+
+// Close does nothing for the Memory Backend
+func (b *Backend) Close() error {
+	return nil
+}
+
+// key builds a map key out of its parts
+func key(parts ...string) string {
+	return strings.Join(parts, "/")
+}
+
+// clone deep copies src into dst, so that stored data never shares memory with data handed out to callers
+func clone(src interface{}, dst interface{}) error {
+	data, err := json.Marshal(src)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dst)
+}
+
+// GetStorageSummary returns stats about the stored elements in the Backend
+func (b *Backend) GetStorageSummary() (storage.Summary, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	summary := storage.Summary{
+		NumberOfMatches:   uint64(len(b.matches)),
+		NumberOfChampions: uint64(len(b.champions)),
+		NumberOfSummoners: uint64(len(b.summoners)),
+	}
+
+	return summary, nil
+}
@@ -0,0 +1,93 @@
+package memorybackend
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func TestStoreAndRetrieveMatch(t *testing.T) {
+	backend, err := NewBackend()
+	if err != nil {
+		t.Fatalf("Could not get a new Memory Backend: %s", err)
+	}
+
+	match := riotclient.MatchDTO{
+		GameID:       1234,
+		PlatformID:   "EUW1",
+		GameVersion:  "9.5.263.5124",
+		MapID:        11,
+		QueueID:      420,
+		Participants: []riotclient.ParticipantDTO{{ChampionID: 12}, {ChampionID: 42}},
+	}
+
+	if err := backend.StoreMatch(&match); err != nil {
+		t.Fatalf("Could not store match: %s", err)
+	}
+	if err := backend.StoreMatch(&match); err == nil {
+		t.Errorf("Expected error when storing the same match twice")
+	}
+
+	// Changes to the stored value must not alter the stored match
+	match.Participants[0].ChampionID = 13
+
+	stored, err := backend.GetMatch("EUW1", 1234)
+	if err != nil {
+		t.Fatalf("Could not get match: %s", err)
+	}
+	if stored.Participants[0].ChampionID != 12 {
+		t.Errorf("Stored match was modified, got champion id %d", stored.Participants[0].ChampionID)
+	}
+
+	cur, err := backend.GetMatchesCursorByGameVersionMapQueueID("", `9\.5\.`, 11, 420)
+	if err != nil {
+		t.Fatalf("Could not get cursor: %s", err)
+	}
+	defer cur.Close()
+
+	count := 0
+	for cur.Next() {
+		decoded := riotclient.MatchDTO{}
+		if err := cur.Decode(&decoded); err != nil {
+			t.Errorf("Could not decode match: %s", err)
+		}
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected one match from cursor, got %d", count)
+	}
+}
+
+func TestConcurrentAccess(t *testing.T) {
+	backend, _ := NewBackend()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				backend.StoreMatch(&riotclient.MatchDTO{GameID: int64(i*1000 + j), PlatformID: "EUW1", GameVersion: "9.5.1"})
+				backend.StoreSummoner(&storage.Summoner{SummonerName: fmt.Sprintf("summoner%d", i), AccountID: fmt.Sprintf("%d", i)})
+
+				cur, err := backend.GetMatchesCursorByGameVersion("", `9\.5`)
+				if err != nil {
+					t.Errorf("Could not get cursor: %s", err)
+					return
+				}
+				for cur.Next() {
+				}
+				cur.Close()
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	summary, _ := backend.GetStorageSummary()
+	if summary.NumberOfMatches != 500 || summary.NumberOfSummoners != 10 {
+		t.Errorf("Unexpected storage summary %v", summary)
+	}
+}
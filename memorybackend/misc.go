@@ -0,0 +1,37 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetKnownGameVersions gets the stored known game versions
+func (b *Backend) GetKnownGameVersions() (*storage.GameVersions, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	gameVersions := storage.GameVersions{}
+	if err := clone(b.gameVersions, &gameVersions); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &gameVersions, nil
+}
+
+// StoreKnownGameVersions stores a new list of known game versions
+func (b *Backend) StoreKnownGameVersions(data *storage.GameVersions) error {
+	b.log.Debugf("Storing known Game Versions in storage")
+
+	gameVersions := storage.GameVersions{}
+	if err := clone(data, &gameVersions); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.gameVersions = gameVersions
+
+	return nil
+}
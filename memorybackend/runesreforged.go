@@ -0,0 +1,44 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetRunesReforged gets the Runes Reforged list from storage
+func (b *Backend) GetRunesReforged(gameVersion, language string) (riotclient.RunesReforgedList, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	runesReforgedList := make(riotclient.RunesReforgedList)
+	if runesReforged, ok := b.runesReforged[key(gameVersion, language)]; ok {
+		if err := clone(runesReforged, &runesReforgedList); err != nil {
+			return nil, fmt.Errorf("GetRunesReforged decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+		}
+	}
+
+	return runesReforgedList, nil
+}
+
+// StoreRunesReforged stores a new Runes Reforged list
+func (b *Backend) StoreRunesReforged(gameVersion, language string, runesReforgedList riotclient.RunesReforgedList) error {
+	storedList := make(riotclient.RunesReforgedList)
+	if err := clone(runesReforgedList, &storedList); err != nil {
+		return fmt.Errorf("Error saving Runes Reforged for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	runesReforged, ok := b.runesReforged[key(gameVersion, language)]
+	if !ok {
+		runesReforged = make(riotclient.RunesReforgedList)
+		b.runesReforged[key(gameVersion, language)] = runesReforged
+	}
+	for _, runesReforgedSet := range storedList {
+		runesReforged[runesReforgedSet.ID] = runesReforgedSet
+	}
+
+	return nil
+}
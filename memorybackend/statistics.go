@@ -0,0 +1,172 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetChampionStatsByChampionIDGameVersionTierQueue returns all stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetChampionStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.ChampionStatsStorage, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.championStats[key(championID, gameVersion, tier, queue)]
+	if !ok {
+		return nil, fmt.Errorf("No Champion Stats found for Champion ID %s, GameVersion %s and Tier %s", championID, gameVersion, tier)
+	}
+
+	stat := storage.ChampionStatsStorage{}
+	if err := clone(stored, &stat); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+
+	return &stat, nil
+}
+
+// StoreChampionStats stores new champion stats in storage
+func (b *Backend) StoreChampionStats(data *storage.ChampionStatsStorage) error {
+	stat := storage.ChampionStatsStorage{}
+	if err := clone(data, &stat); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.championStats[key(stat.ChampionID, stat.GameVersion, stat.Tier, stat.Queue)] = stat
+
+	return nil
+}
+
+// GetChampionStatsSummaryByGameVersionTierQueue returns the stats summary for a specific game version, queue and tier
+func (b *Backend) GetChampionStatsSummaryByGameVersionTierQueue(gameVersion, tier, queue string) (*storage.ChampionStatsSummaryStorage, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.championStatsSummary[key(gameVersion, tier, queue)]
+	if !ok {
+		return nil, fmt.Errorf("No Champion Stats Summary found for GameVersion %s, Queue %s and Tier %s", gameVersion, queue, tier)
+	}
+
+	stat := storage.ChampionStatsSummaryStorage{}
+	if err := clone(stored, &stat); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Stats Summary for GameVersion %s, Queue %s and Tier %s: %s", gameVersion, queue, tier, err)
+	}
+
+	return &stat, nil
+}
+
+// StoreChampionStatsSummary stores a Champion Statistics Summary
+func (b *Backend) StoreChampionStatsSummary(data *storage.ChampionStatsSummaryStorage) error {
+	stat := storage.ChampionStatsSummaryStorage{}
+	if err := clone(data, &stat); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.championStatsSummary[key(stat.GameVersion, stat.Tier, stat.Queue)] = stat
+
+	return nil
+}
+
+// GetItemStatsByChampionIDGameVersionTierQueue returns all item stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetItemStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.ItemStatsStorage, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.itemStats[key(championID, gameVersion, tier, queue)]
+	if !ok {
+		return nil, fmt.Errorf("No Champion Items Stats found for Champion ID %s, GameVersion %s, Tier %s and Queue %s", championID, gameVersion, tier, queue)
+	}
+
+	stat := storage.ItemStatsStorage{}
+	if err := clone(stored, &stat); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Items Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+
+	return &stat, nil
+}
+
+// StoreItemStats stores new item stats in storage
+func (b *Backend) StoreItemStats(data *storage.ItemStatsStorage) error {
+	stat := storage.ItemStatsStorage{}
+	if err := clone(data, &stat); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.itemStats[key(stat.ChampionID, stat.GameVersion, stat.Tier, stat.Queue)] = stat
+
+	return nil
+}
+
+// GetSummonerSpellsStatsByChampionIDGameVersionTierQueue returns all summoner spells stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetSummonerSpellsStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.SummonerSpellsStatsStorage, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.summonerSpellsStats[key(championID, gameVersion, tier, queue)]
+	if !ok {
+		return nil, fmt.Errorf("No Summoner Spells Stats found for Champion ID %s, GameVersion %s, Tier %s and Queue %s", championID, gameVersion, tier, queue)
+	}
+
+	stat := storage.SummonerSpellsStatsStorage{}
+	if err := clone(stored, &stat); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Summoner Spells Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+
+	return &stat, nil
+}
+
+// StoreSummonerSpellsStats stores new summoner spells stats in storage
+func (b *Backend) StoreSummonerSpellsStats(data *storage.SummonerSpellsStatsStorage) error {
+	stat := storage.SummonerSpellsStatsStorage{}
+	if err := clone(data, &stat); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.summonerSpellsStats[key(stat.ChampionID, stat.GameVersion, stat.Tier, stat.Queue)] = stat
+
+	return nil
+}
+
+// GetRunesReforgedStatsByChampionIDGameVersionTierQueue returns all runes reforged stats specific to a certain game version, champion id and tier and queue
+func (b *Backend) GetRunesReforgedStatsByChampionIDGameVersionTierQueue(championID, gameVersion, tier, queue string) (*storage.RunesReforgedStatsStorage, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	stored, ok := b.runesReforgedStats[key(championID, gameVersion, tier, queue)]
+	if !ok {
+		return nil, fmt.Errorf("No Runes Reforged Stats found for Champion ID %s, GameVersion %s and Tier %s", championID, gameVersion, tier)
+	}
+
+	stat := storage.RunesReforgedStatsStorage{}
+	if err := clone(stored, &stat); err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Runes Reforged Stats for Champion ID %s, GameVersion %s, Tier %s and Queue %s: %s", championID, gameVersion, tier, queue, err)
+	}
+
+	return &stat, nil
+}
+
+// StoreRunesReforgedStats stores new runes reforged stats in storage
+func (b *Backend) StoreRunesReforgedStats(data *storage.RunesReforgedStatsStorage) error {
+	stat := storage.RunesReforgedStatsStorage{}
+	if err := clone(data, &stat); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	b.runesReforgedStats[key(stat.ChampionID, stat.GameVersion, stat.Tier, stat.Queue)] = stat
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package memorybackend
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func (b *Backend) summonerQuery(index map[string]string, id string) (*storage.Summoner, error) {
+	summonerName := id
+	if index != nil {
+		summonerName = index[id]
+	}
+
+	stored, ok := b.summoners[summonerName]
+	if !ok {
+		return nil, fmt.Errorf("Summoner not found in storage backend")
+	}
+
+	summoner := storage.Summoner{}
+	if err := clone(stored, &summoner); err != nil {
+		return nil, fmt.Errorf("Decode error: %s", err)
+	}
+
+	return &summoner, nil
+}
+
+// GetSummonerByNameTimeStamp gets the Timestamp when the data was stored for the Summoner specified by name
+func (b *Backend) GetSummonerByNameTimeStamp(name string) time.Time {
+	summoner, err := b.GetSummonerByName(name)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByName retrieves a summoner by name
+func (b *Backend) GetSummonerByName(name string) (*storage.Summoner, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.summonerQuery(nil, strings.ToLower(name))
+}
+
+// GetSummonerBySummonerID retrieves a summoner identified by its Summoner ID
+func (b *Backend) GetSummonerBySummonerID(summonerID string) (*storage.Summoner, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.summonerQuery(b.summonersByID, summonerID)
+}
+
+// GetSummonerBySummonerIDTimeStamp retrieves a summoners time stamp by its Summoner ID
+func (b *Backend) GetSummonerBySummonerIDTimeStamp(summonerID string) time.Time {
+	summoner, err := b.GetSummonerBySummonerID(summonerID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByAccountID retrieves a summoner identified by its Account ID
+func (b *Backend) GetSummonerByAccountID(accountID string) (*storage.Summoner, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.summonerQuery(b.summonersByAccountID, accountID)
+}
+
+// GetSummonerByAccountIDTimeStamp retrieves a summoners time stamp by its Account ID
+func (b *Backend) GetSummonerByAccountIDTimeStamp(accountID string) time.Time {
+	summoner, err := b.GetSummonerByAccountID(accountID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// GetSummonerByPUUID retrieves a summoner identified by its PUUID
+func (b *Backend) GetSummonerByPUUID(PUUID string) (*storage.Summoner, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	return b.summonerQuery(b.summonersByPUUID, PUUID)
+}
+
+// GetSummonerByPUUIDTimeStamp retrieves a summoners time stamp by its PUUID
+func (b *Backend) GetSummonerByPUUIDTimeStamp(PUUID string) time.Time {
+	summoner, err := b.GetSummonerByPUUID(PUUID)
+	if err != nil {
+		return time.Time{}
+	}
+	return summoner.SummonerDTO.Timestamp
+}
+
+// deleteSummoner removes the Summoner stored under summonerName together with its index entries.
+// The caller must hold the write lock.
+func (b *Backend) deleteSummoner(summonerName string) {
+	old, ok := b.summoners[summonerName]
+	if !ok {
+		return
+	}
+
+	delete(b.summonersByID, old.SummonerID)
+	delete(b.summonersByAccountID, old.AccountID)
+	delete(b.summonersByPUUID, old.PUUID)
+	delete(b.summoners, summonerName)
+}
+
+// StoreSummoner stores new Summoner data
+func (b *Backend) StoreSummoner(data *storage.Summoner) error {
+	b.log.Debugf("Storing Summoner %s in storage", data.SummonerName)
+
+	stored := storage.Summoner{}
+	if err := clone(data, &stored); err != nil {
+		return err
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	// Make sure we clean possible old entries with same ids first
+	if summonerName, ok := b.summonersByID[stored.SummonerID]; ok && len(stored.SummonerID) > 0 {
+		b.deleteSummoner(summonerName)
+	}
+	if summonerName, ok := b.summonersByAccountID[stored.AccountID]; ok && len(stored.AccountID) > 0 {
+		b.deleteSummoner(summonerName)
+	}
+	if summonerName, ok := b.summonersByPUUID[stored.PUUID]; ok && len(stored.PUUID) > 0 {
+		b.deleteSummoner(summonerName)
+	}
+	b.deleteSummoner(stored.SummonerName)
+
+	b.summoners[stored.SummonerName] = stored
+	if len(stored.SummonerID) > 0 {
+		b.summonersByID[stored.SummonerID] = stored.SummonerName
+	}
+	if len(stored.AccountID) > 0 {
+		b.summonersByAccountID[stored.AccountID] = stored.SummonerName
+	}
+	if len(stored.PUUID) > 0 {
+		b.summonersByPUUID[stored.PUUID] = stored.SummonerName
+	}
+
+	return nil
+}
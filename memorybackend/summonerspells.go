@@ -0,0 +1,44 @@
+package memorybackend
+
+import (
+	"fmt"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// GetSummonerSpells gets the summoner spells list from storage
+func (b *Backend) GetSummonerSpells(gameVersion, language string) (riotclient.SummonerSpellsList, error) {
+	b.mutex.RLock()
+	defer b.mutex.RUnlock()
+
+	summonerSpellsList := make(riotclient.SummonerSpellsList)
+	if summonerSpells, ok := b.summonerSpells[key(gameVersion, language)]; ok {
+		if err := clone(summonerSpells, &summonerSpellsList); err != nil {
+			return nil, fmt.Errorf("GetSummonerSpells decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+		}
+	}
+
+	return summonerSpellsList, nil
+}
+
+// StoreSummonerSpells stores a new summoner spells list
+func (b *Backend) StoreSummonerSpells(gameVersion, language string, summonerSpellsList riotclient.SummonerSpellsList) error {
+	storedList := make(riotclient.SummonerSpellsList)
+	if err := clone(summonerSpellsList, &storedList); err != nil {
+		return fmt.Errorf("Error saving Summoner Spells for gameversion %s and language %s: %s", gameVersion, language, err)
+	}
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	summonerSpells, ok := b.summonerSpells[key(gameVersion, language)]
+	if !ok {
+		summonerSpells = make(riotclient.SummonerSpellsList)
+		b.summonerSpells[key(gameVersion, language)] = summonerSpells
+	}
+	for _, summonerSpell := range storedList {
+		summonerSpells[summonerSpell.ID] = summonerSpell
+	}
+
+	return nil
+}
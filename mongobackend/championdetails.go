@@ -0,0 +1,72 @@
+package mongobackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+type storedChampionDetails struct {
+	GameVersion string
+	Language    string
+
+	ChampionID      string
+	ChampionDetails riotclient.ChampionDetails
+}
+
+// GetChampionDetails gets the full data of a champion for a game version and language from storage
+func (b *Backend) GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	c := b.client.Database(b.config.Database).Collection("championdetails")
+
+	query := bson.D{
+		{Key: "championid", Value: championID},
+		{Key: "gameversion", Value: gameVersion},
+		{Key: "language", Value: language},
+	}
+
+	doc := c.FindOne(context.Background(), query)
+	if doc == nil {
+		return nil, fmt.Errorf("No Champion Details found for Champion ID %s, GameVersion %s and Language %s", championID, gameVersion, language)
+	}
+
+	stored := storedChampionDetails{}
+	err := doc.Decode(&stored)
+	if err != nil {
+		return nil, fmt.Errorf("Decode error when trying to Decode Champion Details for Champion ID %s, GameVersion %s and Language %s: %s", championID, gameVersion, language, err)
+	}
+
+	return &stored.ChampionDetails, nil
+}
+
+// StoreChampionDetails stores the full data of a champion for a game version and language
+func (b *Backend) StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error {
+	c := b.client.Database(b.config.Database).Collection("championdetails")
+
+	upsert := true
+	updateOptions := options.UpdateOptions{Upsert: &upsert}
+
+	stored := storedChampionDetails{
+		GameVersion:     gameVersion,
+		Language:        language,
+		ChampionID:      championDetails.ID,
+		ChampionDetails: *championDetails,
+	}
+
+	query := bson.D{
+		{Key: "championid", Value: stored.ChampionID},
+		{Key: "gameversion", Value: gameVersion},
+		{Key: "language", Value: language},
+	}
+	update := bson.D{{Key: "$set", Value: stored}}
+
+	_, err := c.UpdateOne(context.Background(), query, update, &updateOptions)
+	if err != nil {
+		return fmt.Errorf("Error saving Champion Details %s for gameversion %s and language %s in DB: %s", championDetails.ID, gameVersion, language, err)
+	}
+
+	return nil
+}
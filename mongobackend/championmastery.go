@@ -0,0 +1,58 @@
+package mongobackend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetChampionMasteries returns the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteries(summonerID string) (*storage.ChampionMasteries, error) {
+	c := b.client.Database(b.config.Database).Collection("championmasteries")
+
+	masteries := storage.ChampionMasteries{}
+	err := c.FindOne(context.Background(), bson.D{{Key: "summonerid", Value: summonerID}}).Decode(&masteries)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("Champion Masteries for Summoner ID %s not found in storage backend", summonerID)
+	} else if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	return &masteries, nil
+}
+
+// GetChampionMasteriesTimeStamp gets the timestamp of the stored Champion Masteries of a Summoner identified by its Summoner ID
+func (b *Backend) GetChampionMasteriesTimeStamp(summonerID string) time.Time {
+	masteries, err := b.GetChampionMasteries(summonerID)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return masteries.ChampionMasteryDTOList.Timestamp
+}
+
+// StoreChampionMasteries stores the Champion Masteries of a Summoner, replacing previously stored ones
+func (b *Backend) StoreChampionMasteries(masteries *storage.ChampionMasteries) error {
+	b.log.Debugf("Storing Champion Masteries for Summoner ID %s in storage", masteries.SummonerID)
+
+	c := b.client.Database(b.config.Database).Collection("championmasteries")
+
+	upsert := true
+	updateOptions := options.UpdateOptions{Upsert: &upsert}
+
+	query := bson.D{{Key: "summonerid", Value: masteries.SummonerID}}
+	update := bson.D{{Key: "$set", Value: masteries}}
+
+	_, err := c.UpdateOne(context.Background(), query, update, &updateOptions)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
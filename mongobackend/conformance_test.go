@@ -0,0 +1,37 @@
+package mongobackend
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/storage"
+	"git.abyle.org/hps/alolstats/storage/backendtest"
+)
+
+// TestConformance needs a running MongoDB, e.g., ALOLSTATS_TEST_MONGO_URL=mongodb://localhost go test ./mongobackend
+func TestConformance(t *testing.T) {
+	url := os.Getenv("ALOLSTATS_TEST_MONGO_URL")
+	if len(url) == 0 {
+		t.Skip("ALOLSTATS_TEST_MONGO_URL not set, skipping MongoDB conformance tests")
+	}
+
+	backendtest.Run(t, func(t *testing.T) (storage.Backend, func()) {
+		cfg := config.MongoBackend{URL: url, Database: fmt.Sprintf("alolstats_conformance_%d", time.Now().UnixNano())}
+		backend, err := NewBackend(cfg)
+		if err != nil {
+			t.Fatalf("Could not get a new Mongo Backend: %s", err)
+		}
+		if err := backend.Connect(); err != nil {
+			t.Fatalf("Could not connect to MongoDB: %s", err)
+		}
+
+		return backend, func() {
+			backend.client.Database(cfg.Database).Drop(context.Background())
+			backend.client.Disconnect(context.Background())
+		}
+	})
+}
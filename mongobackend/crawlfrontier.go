@@ -0,0 +1,98 @@
+package mongobackend
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/mongodb/mongo-go-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// GetCrawlEntry returns the crawl entry of an account in a region
+func (b *Backend) GetCrawlEntry(region string, accountID string) (*storage.CrawlEntry, error) {
+	c := b.client.Database(b.config.Database).Collection("crawlfrontier")
+
+	query := bson.D{
+		{Key: "region", Value: region},
+		{Key: "accountid", Value: accountID},
+	}
+
+	entry := storage.CrawlEntry{}
+	err := c.FindOne(context.Background(), query).Decode(&entry)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("No crawl entry found for Account ID %s in region %s", accountID, region)
+	} else if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	return &entry, nil
+}
+
+// GetCrawlEntries returns up to limit crawl entries of a region with at least minPriority which were not crawled since crawledBefore
+func (b *Backend) GetCrawlEntries(region string, minPriority int, crawledBefore time.Time, limit int) ([]storage.CrawlEntry, error) {
+	c := b.client.Database(b.config.Database).Collection("crawlfrontier")
+
+	query := bson.D{
+		{Key: "region", Value: region},
+		{Key: "priority", Value: bson.D{{Key: "$gte", Value: minPriority}}},
+		{Key: "lastcrawled", Value: bson.D{{Key: "$lt", Value: crawledBefore}}},
+	}
+	findOptions := options.Find().SetSort(bson.D{
+		{Key: "priority", Value: -1},
+		{Key: "lastcrawled", Value: 1},
+		{Key: "accountid", Value: 1},
+	})
+	if limit > 0 {
+		findOptions.SetLimit(int64(limit))
+	}
+
+	cur, err := c.Find(context.Background(), query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	defer cur.Close(context.Background())
+
+	entries := []storage.CrawlEntry{}
+
+	for cur.Next(nil) {
+		entry := storage.CrawlEntry{}
+		err := cur.Decode(&entry)
+		if err != nil {
+			b.log.Warnln("Decode error ", err)
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	if err := cur.Err(); err != nil {
+		b.log.Warnln("Cursor error ", err)
+	}
+
+	return entries, nil
+}
+
+// StoreCrawlEntry stores a crawl entry, replacing the stored one of the same account and region
+func (b *Backend) StoreCrawlEntry(entry *storage.CrawlEntry) error {
+	c := b.client.Database(b.config.Database).Collection("crawlfrontier")
+
+	upsert := true
+	updateOptions := options.UpdateOptions{Upsert: &upsert}
+
+	query := bson.D{
+		{Key: "region", Value: entry.Region},
+		{Key: "accountid", Value: entry.AccountID},
+	}
+	update := bson.D{{Key: "$set", Value: entry}}
+
+	_, err := c.UpdateOne(context.Background(), query, update, &updateOptions)
+	if err != nil {
+		return fmt.Errorf("Error saving crawl entry for Account ID %s in region %s in DB: %s", entry.AccountID, entry.Region, err)
+	}
+
+	return nil
+}
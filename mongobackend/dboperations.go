@@ -37,6 +37,52 @@ func (b *Backend) checkChampions() error {
 	return nil
 }
 
+// checkChampionDetails checks the championdetails collection and sets the correct indices
+func (b *Backend) checkChampionDetails() error {
+
+	err := b.createIndex("championdetails", mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "championid", Value: bsonx.Int32(1)},
+			{Key: "gameversion", Value: bsonx.Int32(1)},
+			{Key: "language", Value: bsonx.Int32(1)},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB indices: %s", err)
+	}
+
+	return nil
+}
+
+// checkCrawlFrontier checks the crawlfrontier collection and sets the correct indices
+func (b *Backend) checkCrawlFrontier() error {
+
+	err := b.createIndex("crawlfrontier", mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "region", Value: bsonx.Int32(1)},
+			{Key: "accountid", Value: bsonx.Int32(1)},
+		},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB indices: %s", err)
+	}
+
+	err = b.createIndex("crawlfrontier", mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "region", Value: bsonx.Int32(1)},
+			{Key: "priority", Value: bsonx.Int32(-1)},
+			{Key: "lastcrawled", Value: bsonx.Int32(1)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB indices: %s", err)
+	}
+
+	return nil
+}
+
 // checkSummonerSpells checks the summonerspells collection and sets the correct indices
 func (b *Backend) checkSummonerSpells() error {
 
@@ -172,6 +218,24 @@ func (b *Backend) checkSummoners() error {
 	return nil
 }
 
+// checkLeagues checks the leagues collection and sets the correct indices
+func (b *Backend) checkLeagues() error {
+	collection := "leagues"
+	err := b.createIndex(collection, mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "region", Value: bsonx.Int32(1)},
+			{Key: "tier", Value: bsonx.Int32(1)},
+			{Key: "queue", Value: bsonx.Int32(1)},
+			{Key: "timestamp", Value: bsonx.Int32(-1)}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB indices: %s", err)
+	}
+
+	return nil
+}
+
 // checkSummonerLeagues checks the summonerleagues collection and sets the correct indices
 func (b *Backend) checkSummonerLeagues() error {
 	collection := "summonerleagues"
@@ -196,6 +260,21 @@ func (b *Backend) checkSummonerLeagues() error {
 	return nil
 }
 
+// checkChampionMasteries checks the championmasteries collection and sets the correct indices
+func (b *Backend) checkChampionMasteries() error {
+	collection := "championmasteries"
+	err := b.createIndex(collection, mongo.IndexModel{
+		Keys: bsonx.Doc{
+			{Key: "summonerid", Value: bsonx.Int32(1)}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("Error creating MongoDB indices: %s", err)
+	}
+
+	return nil
+}
+
 // checkChampionStats checks the championstats collection and sets the correct indices
 func (b *Backend) checkChampionStats() error {
 	collection := "championstats"
@@ -349,6 +428,11 @@ func (b *Backend) checkCollections() error {
 		return err
 	}
 
+	err = b.checkChampionDetails()
+	if err != nil {
+		return err
+	}
+
 	err = b.checkMatches()
 	if err != nil {
 		return err
@@ -364,11 +448,21 @@ func (b *Backend) checkCollections() error {
 		return err
 	}
 
+	err = b.checkLeagues()
+	if err != nil {
+		return err
+	}
+
 	err = b.checkSummonerLeagues()
 	if err != nil {
 		return err
 	}
 
+	err = b.checkChampionMasteries()
+	if err != nil {
+		return err
+	}
+
 	err = b.checkChampionStats()
 	if err != nil {
 		return err
@@ -399,5 +493,10 @@ func (b *Backend) checkCollections() error {
 		return err
 	}
 
+	err = b.checkCrawlFrontier()
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
@@ -3,13 +3,31 @@ package mongobackend
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/storage"
 	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
-// GetMatchesCursorByGameVersion returns cursor to matches specific to a certain game version
-func (b *Backend) GetMatchesCursorByGameVersion(gameVersion string) (storage.QueryCursor, error) {
+// withPlatform restricts a match query to a platform, an empty platformID matches all platforms
+func withPlatform(query bson.D, platformID string) bson.D {
+	if len(platformID) == 0 {
+		return query
+	}
+	return append(query, bson.E{Key: "platformid", Value: strings.ToUpper(platformID)})
+}
+
+// byGameID sorts the matches of a cursor by ascending game id like the other backends, using the unique matches index
+func byGameID() *options.FindOptions {
+	return options.Find().SetSort(bson.D{
+		{Key: "gameid", Value: 1},
+		{Key: "platformid", Value: 1},
+	})
+}
+
+// GetMatchesCursorByGameVersion returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (b *Backend) GetMatchesCursorByGameVersion(platformID string, gameVersion string) (storage.QueryCursor, error) {
 	c := b.client.Database(b.config.Database).Collection("matches")
 
 	query := bson.D{{Key: "gameversion",
@@ -19,7 +37,7 @@ func (b *Backend) GetMatchesCursorByGameVersion(gameVersion string) (storage.Que
 	}}
 
 	cur, err := c.Find(
-		context.Background(), query)
+		context.Background(), withPlatform(query, platformID), byGameID())
 	if err != nil {
 		return nil, fmt.Errorf("Error finding matches for GameVersion %s: %s", gameVersion, err)
 	}
@@ -32,8 +50,8 @@ func (b *Backend) GetMatchesCursorByGameVersion(gameVersion string) (storage.Que
 	return &matchCursor, nil
 }
 
-// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
-func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, champion id, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
 	c := b.client.Database(b.config.Database).Collection("matches")
 
 	query := bson.D{
@@ -61,7 +79,7 @@ func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(game
 	}
 
 	cur, err := c.Find(
-		context.Background(), query)
+		context.Background(), withPlatform(query, platformID), byGameID())
 	if err != nil {
 		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Champion ID %d, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, championID, mapID, gtequeue, ltequeue, err)
 	}
@@ -74,8 +92,8 @@ func (b *Backend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(game
 	return &matchCursor, nil
 }
 
-// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain game version, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
-func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
+// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue ids between and equal to ltequeue <= queueid <= gtequeue
+func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (storage.QueryCursor, error) {
 	c := b.client.Database(b.config.Database).Collection("matches")
 
 	query := bson.D{
@@ -99,7 +117,7 @@ func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion st
 	}
 
 	cur, err := c.Find(
-		context.Background(), query)
+		context.Background(), withPlatform(query, platformID), byGameID())
 	if err != nil {
 		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Map ID %d, Queue ID  %d <= id <= %d: %s", gameVersion, mapID, gtequeue, ltequeue, err)
 	}
@@ -112,8 +130,8 @@ func (b *Backend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion st
 	return &matchCursor, nil
 }
 
-// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain game version, map id and queue id
-func (b *Backend) GetMatchesCursorByGameVersionMapQueueID(gameVersion string, mapID uint64, queueID uint64) (storage.QueryCursor, error) {
+// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain platform (all platforms if empty), game version, map id and queue id
+func (b *Backend) GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueID uint64) (storage.QueryCursor, error) {
 	c := b.client.Database(b.config.Database).Collection("matches")
 
 	query := bson.D{
@@ -134,7 +152,7 @@ func (b *Backend) GetMatchesCursorByGameVersionMapQueueID(gameVersion string, ma
 	}
 
 	cur, err := c.Find(
-		context.Background(), query)
+		context.Background(), withPlatform(query, platformID), byGameID())
 	if err != nil {
 		return nil, fmt.Errorf("Error finding matches for GameVersion %s, Map ID %d, Queue ID  %d: %s", gameVersion, mapID, queueID, err)
 	}
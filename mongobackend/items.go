@@ -24,7 +24,7 @@ func (b *Backend) GetItems(gameVersion, language string) (riotclient.ItemList, e
 	c := b.client.Database(b.config.Database).Collection("items")
 
 	query := bson.D{
-		{Key: "gameVersion", Value: gameVersion},
+		{Key: "gameversion", Value: gameVersion},
 		{Key: "language", Value: language},
 	}
 
@@ -44,14 +44,14 @@ func (b *Backend) GetItems(gameVersion, language string) (riotclient.ItemList, e
 		item := storedItem{}
 		err := cur.Decode(&item)
 		if err != nil {
-			b.log.Warnln("GetItems decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			b.log.Warnf("GetItems decode error for gameversion %s and language %s: %s", gameVersion, language, err)
 			continue
 		}
 		itemList[item.Key] = item.Item
 	}
 
 	if err := cur.Err(); err != nil {
-		b.log.Warnln("GetItems cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
+		b.log.Warnf("GetItems cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
 	}
 
 	return itemList, nil
@@ -71,9 +71,9 @@ func (b *Backend) StoreItems(gameVersion, language string, itemList riotclient.I
 		}
 
 		query := bson.D{
-			{Key: "gameVersion", Value: gameVersion},
+			{Key: "gameversion", Value: gameVersion},
 			{Key: "language", Value: language},
-			{Key: "id", Value: itemForStorage.Key},
+			{Key: "key", Value: itemForStorage.Key},
 		}
 		update := bson.D{{Key: "$set", Value: itemForStorage}}
 
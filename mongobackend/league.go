@@ -7,25 +7,106 @@ import (
 	"time"
 
 	"github.com/mongodb/mongo-go-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 
-	"git.abyle.org/hps/alolstats/riotclient"
 	"git.abyle.org/hps/alolstats/storage"
 )
 
-// GetLeagueByQueue is not implemented, yet
-func (b *Backend) GetLeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error) {
-	return nil, fmt.Errorf("Not implemented")
+func leagueQuery(region string, tier string, queue string) bson.D {
+	return bson.D{
+		{Key: "region", Value: region},
+		{Key: "tier", Value: strings.ToUpper(tier)},
+		{Key: "queue", Value: queue},
+	}
+}
+
+// GetLeagueByQueue returns the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueue(region string, tier string, queue string) (*storage.League, error) {
+	c := b.client.Database(b.config.Database).Collection("leagues")
+
+	findOptions := options.FindOne().SetSort(bson.D{{Key: "timestamp", Value: -1}})
+
+	league := storage.League{}
+	err := c.FindOne(context.Background(), leagueQuery(region, tier, queue), findOptions).Decode(&league)
+	if err == mongo.ErrNoDocuments {
+		return nil, fmt.Errorf("League %s for queue %s in region %s not found in storage backend", tier, queue, region)
+	} else if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	return &league, nil
 }
 
-// GetLeagueByQueueTimeStamp is not implemented, yet
-func (b *Backend) GetLeagueByQueueTimeStamp(league string, queue string) (time.Time, error) {
-	return time.Time{}, fmt.Errorf("Not implemented")
+// GetLeagueByQueueTimeStamp returns the timestamp of the newest stored snapshot of a league identified by region, tier and queue name
+func (b *Backend) GetLeagueByQueueTimeStamp(region string, tier string, queue string) (time.Time, error) {
+	league, err := b.GetLeagueByQueue(region, tier, queue)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return league.Timestamp, nil
+}
+
+// GetLeagueHistoryByQueue returns all stored snapshots of a league identified by region, tier and queue name
+// which were taken between from and to (inclusive), sorted from oldest to newest
+func (b *Backend) GetLeagueHistoryByQueue(region string, tier string, queue string, from time.Time, to time.Time) ([]storage.League, error) {
+	c := b.client.Database(b.config.Database).Collection("leagues")
+
+	query := append(leagueQuery(region, tier, queue), bson.E{Key: "timestamp", Value: bson.D{
+		{Key: "$gte", Value: from},
+		{Key: "$lte", Value: to},
+	}})
+	findOptions := options.Find().SetSort(bson.D{{Key: "timestamp", Value: 1}})
+
+	cur, err := c.Find(context.Background(), query, findOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Find error: %s", err)
+	}
+
+	defer cur.Close(context.Background())
+
+	leagues := []storage.League{}
+
+	for cur.Next(nil) {
+		league := storage.League{}
+		err := cur.Decode(&league)
+		if err != nil {
+			b.log.Warnln("Decode error ", err)
+			continue
+		}
+		leagues = append(leagues, league)
+	}
+
+	if err := cur.Err(); err != nil {
+		b.log.Warnln("Cursor error ", err)
+	}
+
+	return leagues, nil
 }
 
-// StoreLeague is not implemented, yet
-func (b *Backend) StoreLeague(*riotclient.LeagueListDTO) error {
-	return fmt.Errorf("Not implemented")
+// StoreLeague stores a new snapshot of a league. Older snapshots are kept to be able to look at the league history,
+// only a snapshot with the same timestamp is replaced.
+func (b *Backend) StoreLeague(league *storage.League) error {
+	b.log.Debugf("Storing League %s for queue %s in region %s in storage", league.Tier, league.Queue, league.Region)
+
+	c := b.client.Database(b.config.Database).Collection("leagues")
+
+	stored := *league
+	stored.Tier = strings.ToUpper(stored.Tier)
+
+	upsert := true
+	updateOptions := options.UpdateOptions{Upsert: &upsert}
+
+	query := append(leagueQuery(stored.Region, stored.Tier, stored.Queue), bson.E{Key: "timestamp", Value: stored.Timestamp})
+	update := bson.D{{Key: "$set", Value: stored}}
+
+	_, err := c.UpdateOne(context.Background(), query, update, &updateOptions)
+	if err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (b *Backend) leaguesForSummonerQuery(query *bson.D) (*storage.SummonerLeagues, error) {
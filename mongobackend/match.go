@@ -3,18 +3,22 @@ package mongobackend
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 	"go.mongodb.org/mongo-driver/bson"
 )
 
-// GetMatch retreives match data for given id
-func (b *Backend) GetMatch(id uint64) (*riotclient.MatchDTO, error) {
+// GetMatch retreives match data for given platform and id
+func (b *Backend) GetMatch(platformID string, id uint64) (*riotclient.MatchDTO, error) {
 	c := b.client.Database(b.config.Database).Collection("matches")
 
 	cur, err := c.Find(
 		context.Background(),
-		bson.D{{Key: "gameid", Value: id}},
+		bson.D{
+			{Key: "gameid", Value: id},
+			{Key: "platformid", Value: strings.ToUpper(platformID)},
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("Find error: %s", err)
@@ -40,10 +44,10 @@ func (b *Backend) GetMatch(id uint64) (*riotclient.MatchDTO, error) {
 	if len(matches) == 1 {
 		return &matches[0], nil
 	} else if len(matches) > 1 {
-		return nil, fmt.Errorf("Found one than more Match (namely %d) with id=%d in storage backend", len(matches), id)
+		return nil, fmt.Errorf("Found one than more Match (namely %d) with id=%d on platform %s in storage backend", len(matches), id, platformID)
 	}
 
-	return nil, fmt.Errorf("Match with id=%d not found in storage backend", id)
+	return nil, fmt.Errorf("Match with id=%d on platform %s not found in storage backend", id, platformID)
 }
 
 // GetMatchesCount returns the number of stored Matches in the Backend
@@ -3,6 +3,7 @@ package mongobackend
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 	"github.com/mongodb/mongo-go-driver/bson"
@@ -21,13 +22,16 @@ type MatchTimeLineStoreData struct {
 	TimeLine    *riotclient.MatchTimelineDTO
 }
 
-// GetMatchTimeLine is not implemented, yet
-func (b *Backend) GetMatchTimeLine(id uint64) (*riotclient.MatchTimelineDTO, error) {
+// GetMatchTimeLine retreives the match timeline for given platform and id
+func (b *Backend) GetMatchTimeLine(platformID string, id uint64) (*riotclient.MatchTimelineDTO, error) {
 	c := b.client.Database(b.config.Database).Collection("timelines")
 
 	cur, err := c.Find(
 		context.Background(),
-		bson.D{{Key: "gameid", Value: id}},
+		bson.D{
+			{Key: "gameid", Value: id},
+			{Key: "platformid", Value: strings.ToUpper(platformID)},
+		},
 	)
 	if err != nil {
 		return nil, fmt.Errorf("Find error: %s", err)
@@ -53,10 +57,10 @@ func (b *Backend) GetMatchTimeLine(id uint64) (*riotclient.MatchTimelineDTO, err
 	if len(timelines) == 1 {
 		return timelines[0].TimeLine, nil
 	} else if len(timelines) > 1 {
-		return nil, fmt.Errorf("Found one than more Match TimeLine (namely %d) with id=%d in storage backend", len(timelines), id)
+		return nil, fmt.Errorf("Found one than more Match TimeLine (namely %d) with id=%d on platform %s in storage backend", len(timelines), id, platformID)
 	}
 
-	return nil, fmt.Errorf("Match TimeLine with id=%d not found in storage backend", id)
+	return nil, fmt.Errorf("Match TimeLine with id=%d on platform %s not found in storage backend", id, platformID)
 }
 
 // StoreMatchTimeLine is not implemented, yet
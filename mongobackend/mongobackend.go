@@ -57,6 +57,11 @@ func (b *Backend) Connect() error {
 	return nil
 }
 
+// Close disconnects the MongoDBBackend Client
+func (b *Backend) Close() error {
+	return b.client.Disconnect(context.Background())
+}
+
 // GetStorageSummary returns stats about the stored elements in the Backend
 func (b *Backend) GetStorageSummary() (storage.Summary, error) {
 	summary := storage.Summary{}
@@ -24,7 +24,7 @@ func (b *Backend) GetRunesReforged(gameVersion, language string) (riotclient.Run
 	c := b.client.Database(b.config.Database).Collection("runesreforged")
 
 	query := bson.D{
-		{Key: "gameVersion", Value: gameVersion},
+		{Key: "gameversion", Value: gameVersion},
 		{Key: "language", Value: language},
 	}
 
@@ -44,14 +44,14 @@ func (b *Backend) GetRunesReforged(gameVersion, language string) (riotclient.Run
 		runerf := storedRunesReforged{}
 		err := cur.Decode(&runerf)
 		if err != nil {
-			b.log.Warnln("GetRunesReforged decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			b.log.Warnf("GetRunesReforged decode error for gameversion %s and language %s: %s", gameVersion, language, err)
 			continue
 		}
 		rfList[runerf.ID] = runerf.RunesReforged
 	}
 
 	if err := cur.Err(); err != nil {
-		b.log.Warnln("GetRunesReforged cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
+		b.log.Warnf("GetRunesReforged cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
 	}
 
 	return rfList, nil
@@ -71,7 +71,7 @@ func (b *Backend) StoreRunesReforged(gameVersion, language string, rfList riotcl
 		}
 
 		query := bson.D{
-			{Key: "gameVersion", Value: gameVersion},
+			{Key: "gameversion", Value: gameVersion},
 			{Key: "language", Value: language},
 			{Key: "id", Value: runesForStorage.ID},
 		}
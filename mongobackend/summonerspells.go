@@ -24,7 +24,7 @@ func (b *Backend) GetSummonerSpells(gameVersion, language string) (riotclient.Su
 	c := b.client.Database(b.config.Database).Collection("summonerspells")
 
 	query := bson.D{
-		{Key: "gameVersion", Value: gameVersion},
+		{Key: "gameversion", Value: gameVersion},
 		{Key: "language", Value: language},
 	}
 
@@ -44,14 +44,14 @@ func (b *Backend) GetSummonerSpells(gameVersion, language string) (riotclient.Su
 		summonerSpell := storedSummonerSpell{}
 		err := cur.Decode(&summonerSpell)
 		if err != nil {
-			b.log.Warnln("GetSummonerSpells decode error for gameversion %s and language %s: %s", gameVersion, language, err)
+			b.log.Warnf("GetSummonerSpells decode error for gameversion %s and language %s: %s", gameVersion, language, err)
 			continue
 		}
 		summonerSpellsList[summonerSpell.ID] = summonerSpell.SummonerSpell
 	}
 
 	if err := cur.Err(); err != nil {
-		b.log.Warnln("GetSummonerSpells cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
+		b.log.Warnf("GetSummonerSpells cursor error for gameversion %s and language %s: %s", gameVersion, language, err)
 	}
 
 	return summonerSpellsList, nil
@@ -0,0 +1,295 @@
+// Package riotclientcassette provides an HTTP transport which records the exchanges of the Riot API and Data Dragon
+// clients to a cassette file and replays them deterministically, e.g., to reproduce problems from captured traffic.
+//
+// A cassette file holds one JSON encoded Interaction per line, so that recording only appends to it. Cassette files
+// holding a single Cassette object, as written by former versions, are replayed as well.
+package riotclientcassette
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+)
+
+const (
+	// ModeRecord records all exchanges to the cassette file
+	ModeRecord = "record"
+	// ModeReplay serves the exchanges from the cassette file without network access
+	ModeReplay = "replay"
+)
+
+// scrubbed replaces the API key in recorded requests
+const scrubbed = "SCRUBBED"
+
+// Request is a recorded HTTP request
+type Request struct {
+	Method string      `json:"method"`
+	URL    string      `json:"url"`
+	Header http.Header `json:"header,omitempty"`
+}
+
+// Response is a recorded HTTP response
+type Response struct {
+	StatusCode int         `json:"statusCode"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body"`
+}
+
+// Interaction is a recorded exchange. Error is set if no response was received.
+type Interaction struct {
+	Request  Request   `json:"request"`
+	Response *Response `json:"response,omitempty"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Cassette is the content of a cassette file of former versions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Transport is an http.RoundTripper which records or replays exchanges
+type Transport struct {
+	mode string
+	file string
+	next http.RoundTripper
+	log  *logrus.Entry
+
+	mutex sync.Mutex
+	// out is the cassette file the interactions are appended to while recording
+	out *os.File
+	// cassette holds the interactions to replay
+	cassette Cassette
+	// replayed holds the number of interactions already replayed per request, so that repeated requests are
+	// answered in the recorded order
+	replayed map[string]int
+}
+
+// New creates a Transport for the given settings. In record mode the requests are sent with next,
+// http.DefaultTransport if it is nil.
+func New(cfg config.Cassette, next http.RoundTripper) (*Transport, error) {
+	switch strings.ToLower(cfg.Mode) {
+	case ModeRecord:
+		return NewRecorder(cfg.File, next)
+	case ModeReplay:
+		return NewReplayer(cfg.File)
+	default:
+		return nil, fmt.Errorf("Unknown cassette mode %s, must be %s or %s", cfg.Mode, ModeRecord, ModeReplay)
+	}
+}
+
+// NewRecorder creates a Transport which sends requests with next and records them to file, overwriting it.
+// Every interaction is written to the file when it is recorded, Close releases the file.
+func NewRecorder(file string, next http.RoundTripper) (*Transport, error) {
+	if len(file) == 0 {
+		return nil, fmt.Errorf("Cassette file is empty, check config file")
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+
+	out, err := os.OpenFile(file, os.O_WRONLY|os.O_CREATE|os.O_TRUNC|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("Error creating cassette file: %s", err)
+	}
+
+	return &Transport{
+		mode: ModeRecord,
+		file: file,
+		next: next,
+		log:  logging.Get("RiotClientCassette"),
+		out:  out,
+	}, nil
+}
+
+// NewReplayer creates a Transport which answers requests with the exchanges recorded in file
+func NewReplayer(file string) (*Transport, error) {
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("Error reading cassette file: %s", err)
+	}
+
+	t := &Transport{
+		mode:     ModeReplay,
+		file:     file,
+		log:      logging.Get("RiotClientCassette"),
+		replayed: make(map[string]int),
+	}
+	if t.cassette.Interactions, err = parseInteractions(data); err != nil {
+		return nil, fmt.Errorf("Error parsing cassette file %s: %s", file, err)
+	}
+
+	return t, nil
+}
+
+// parseInteractions returns the interactions of a cassette file, either one per line or in a single Cassette object
+func parseInteractions(data []byte) ([]Interaction, error) {
+	// An entry is an Interaction or, in cassette files of former versions, a Cassette
+	type entry struct {
+		Interaction
+		Interactions []Interaction `json:"interactions"`
+	}
+
+	interactions := []Interaction{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	for {
+		var e entry
+		if err := decoder.Decode(&e); err == io.EOF {
+			return interactions, nil
+		} else if err != nil {
+			return nil, err
+		}
+		if e.Interactions != nil {
+			interactions = append(interactions, e.Interactions...)
+		} else {
+			interactions = append(interactions, e.Interaction)
+		}
+	}
+}
+
+// Close closes the cassette file of a recording Transport. Requests must not be recorded anymore afterwards.
+func (t *Transport) Close() error {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.out == nil {
+		return nil
+	}
+	err := t.out.Close()
+	t.out = nil
+	return err
+}
+
+// Client returns an http.Client using the Transport
+func (t *Transport) Client() *http.Client {
+	return &http.Client{Transport: t}
+}
+
+// RoundTrip records or replays a single exchange
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.mode == ModeReplay {
+		return t.replay(req)
+	}
+	return t.record(req)
+}
+
+func (t *Transport) record(req *http.Request) (*http.Response, error) {
+	interaction := Interaction{Request: scrubRequest(req)}
+
+	response, err := t.next.RoundTrip(req)
+	if err != nil {
+		interaction.Error = err.Error()
+	} else {
+		body, readErr := ioutil.ReadAll(response.Body)
+		response.Body.Close()
+		if readErr != nil {
+			return nil, readErr
+		}
+		response.Body = ioutil.NopCloser(bytes.NewReader(body))
+		interaction.Response = &Response{StatusCode: response.StatusCode, Header: response.Header, Body: string(body)}
+	}
+
+	if saveErr := t.save(interaction); saveErr != nil {
+		t.log.Errorf("Error saving cassette file %s: %s", t.file, saveErr)
+	}
+
+	return response, err
+}
+
+func (t *Transport) replay(req *http.Request) (*http.Response, error) {
+	recorded := scrubRequest(req)
+	key := recorded.Method + " " + recorded.URL
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	// The n-th request is answered with the n-th matching interaction, the last one is repeated when they are used up
+	var match *Interaction
+	n := 0
+	for i := range t.cassette.Interactions {
+		interaction := &t.cassette.Interactions[i]
+		if interaction.Request.Method != recorded.Method || interaction.Request.URL != recorded.URL {
+			continue
+		}
+		match = interaction
+		if n == t.replayed[key] {
+			break
+		}
+		n++
+	}
+	if match == nil {
+		return nil, fmt.Errorf("No recorded interaction for %s", key)
+	}
+	t.replayed[key]++
+
+	if match.Response == nil {
+		return nil, fmt.Errorf("%s", match.Error)
+	}
+
+	header := make(http.Header)
+	for name, values := range match.Response.Header {
+		header[name] = append([]string(nil), values...)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", match.Response.StatusCode, http.StatusText(match.Response.StatusCode)),
+		StatusCode:    match.Response.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(strings.NewReader(match.Response.Body)),
+		ContentLength: int64(len(match.Response.Body)),
+		Request:       req,
+	}, nil
+}
+
+// save appends the interaction as a single line to the cassette file
+func (t *Transport) save(interaction Interaction) error {
+	data, err := json.Marshal(interaction)
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.out == nil {
+		return fmt.Errorf("Cassette file is closed")
+	}
+	if _, err := t.out.Write(data); err != nil {
+		return fmt.Errorf("Error writing cassette file: %s", err)
+	}
+	return nil
+}
+
+// scrubRequest returns the request to record, with the API key removed from the header and the query
+func scrubRequest(req *http.Request) Request {
+	header := make(http.Header)
+	for name, values := range req.Header {
+		if http.CanonicalHeaderKey(name) == "X-Riot-Token" {
+			header[name] = []string{scrubbed}
+			continue
+		}
+		header[name] = append([]string(nil), values...)
+	}
+
+	u := *req.URL
+	query := u.Query()
+	if _, ok := query["api_key"]; ok {
+		query.Set("api_key", scrubbed)
+		u.RawQuery = query.Encode()
+	}
+
+	return Request{Method: req.Method, URL: u.String(), Header: header}
+}
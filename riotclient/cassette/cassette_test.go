@@ -0,0 +1,136 @@
+package riotclientcassette
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclientv4"
+
+	riotclientdd "git.abyle.org/hps/alolstats/riotclient/datadragon"
+	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+func newV4Client(t *testing.T, httpClient *http.Client, baseURL string) *riotclientv4.RiotClientV4 {
+	rateLimit, _ := riotclientrl.New()
+	client, err := riotclientv4.NewClient(httpClient,
+		config.RiotClient{APIVersion: "v4", Region: "euw1", Key: "RGAPI-secret-key", BaseURL: baseURL}, nil, rateLimit)
+	if err != nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	client.Start()
+	return client
+}
+
+func TestNew(t *testing.T) {
+	if _, err := New(config.Cassette{Mode: "rewind", File: "cassette.json"}, nil); err == nil {
+		t.Errorf("Could create a cassette with unknown mode")
+	}
+	if _, err := New(config.Cassette{Mode: "record"}, nil); err == nil {
+		t.Errorf("Could create a recording cassette without file")
+	}
+	if _, err := New(config.Cassette{Mode: "replay", File: "does-not-exist.json"}, nil); err == nil {
+		t.Errorf("Could create a replaying cassette without file")
+	}
+}
+
+func TestTransport_recordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassette")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "cassette.json")
+
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.Header.Get("X-Riot-Token") != "RGAPI-secret-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case "/lol/summoner/v4/summoners/by-name/Player":
+			w.Header().Set("X-App-Rate-Limit", "20:1")
+			w.Header().Set("X-App-Rate-Limit-Count", fmt.Sprintf("%d:1", calls))
+			fmt.Fprintf(w, `{"id":"summoner-1","accountId":"account-1","name":"Player","summonerLevel":%d}`, calls)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+
+	recorder, err := NewRecorder(file, nil)
+	if err != nil {
+		t.Fatalf("Could not create recorder: %s", err)
+	}
+	client := newV4Client(t, recorder.Client(), server.URL)
+	for level := int64(1); level <= 2; level++ {
+		if summoner, err := client.SummonerByName("Player"); err != nil || summoner.SummonerLevel != level {
+			t.Fatalf("SummonerByName() while recording = %+v, %v", summoner, err)
+		}
+	}
+	if _, err := client.SummonerByAccountID("unknown"); riotclient.KindOf(err) != riotclient.ErrorNotFound {
+		t.Fatalf("SummonerByAccountID() while recording returned %v, want Not Found", err)
+	}
+	client.Stop()
+	server.Close()
+	if err := recorder.Close(); err != nil {
+		t.Fatalf("Could not close recorder: %s", err)
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("Could not read cassette: %s", err)
+	}
+	if lines := strings.Split(strings.TrimSpace(string(data)), "\n"); len(lines) != 3 {
+		t.Errorf("Cassette has %d lines, want one per interaction: %s", len(lines), data)
+	}
+	if strings.Contains(string(data), "RGAPI-secret-key") || !strings.Contains(string(data), scrubbed) {
+		t.Errorf("API key was not scrubbed from cassette: %s", data)
+	}
+
+	replayer, err := NewReplayer(file)
+	if err != nil {
+		t.Fatalf("Could not create replayer: %s", err)
+	}
+	client = newV4Client(t, replayer.Client(), server.URL)
+	defer client.Stop()
+
+	// Repeated requests are answered in the recorded order, the last response is repeated
+	for _, level := range []int64{1, 2, 2} {
+		if summoner, err := client.SummonerByName("Player"); err != nil || summoner.SummonerLevel != level {
+			t.Errorf("SummonerByName() while replaying = %+v, %v, want level %d", summoner, err, level)
+		}
+	}
+	if _, err := client.SummonerByAccountID("unknown"); riotclient.KindOf(err) != riotclient.ErrorNotFound {
+		t.Errorf("SummonerByAccountID() while replaying returned %v, want Not Found", err)
+	}
+	req, _ := http.NewRequest("GET", server.URL+"/lol/summoner/v4/summoners/not-recorded", nil)
+	if _, err := replayer.RoundTrip(req); err == nil {
+		t.Errorf("RoundTrip() returned no error for a request which was not recorded")
+	}
+	if calls != 3 {
+		t.Errorf("Server received %d requests, want 3 from recording only", calls)
+	}
+}
+
+// The Data Dragon cassette is in the format of former versions, a single object holding all interactions
+func TestTransport_replayDataDragon(t *testing.T) {
+	replayer, err := NewReplayer("./testdata/datadragon.json")
+	if err != nil {
+		t.Fatalf("Could not create replayer: %s", err)
+	}
+
+	ddragon, _ := riotclientdd.New(replayer.Client(), config.RiotClient{Region: "euw1"})
+	versions, err := ddragon.GetLoLVersions()
+	if err != nil || string(versions) != `["8.24.1","8.23.1"]` {
+		t.Errorf("GetLoLVersions() = %s, %v", versions, err)
+	}
+}
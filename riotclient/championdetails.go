@@ -0,0 +1,60 @@
+package riotclient
+
+// ChampionDetails stores the full champion data in the format coming from the champion/<ID>.json files of DataDragon.
+// It contains everything of Champion plus the spells, the passive, skins and tips.
+type ChampionDetails struct {
+	Champion
+
+	Lore      string          `json:"lore"`
+	AllyTips  []string        `json:"allytips"`
+	EnemyTips []string        `json:"enemytips"`
+	Skins     []ChampionSkin  `json:"skins"`
+	Spells    []ChampionSpell `json:"spells"`
+	Passive   ChampionPassive `json:"passive"`
+}
+
+//////////////////////////////////////////////
+// Subelementtype definitions follow bellow //
+//////////////////////////////////////////////
+
+// ChampionSkin contains information about a skin of the champion
+type ChampionSkin struct {
+	ID      string `json:"id"`
+	Num     int    `json:"num"`
+	Name    string `json:"name"`
+	Chromas bool   `json:"chromas"`
+}
+
+// ChampionSpell contains information about a spell of the champion. Spells are given in the order Q, W, E, R.
+// The slices contain one value per rank.
+type ChampionSpell struct {
+	ID           string             `json:"id"`
+	Name         string             `json:"name"`
+	Description  string             `json:"description"`
+	Tooltip      string             `json:"tooltip"`
+	Leveltip     ChampionSpellLevel `json:"leveltip"`
+	Maxrank      int                `json:"maxrank"`
+	Cooldown     []float64          `json:"cooldown"`
+	CooldownBurn string             `json:"cooldownBurn"`
+	Cost         []float64          `json:"cost"`
+	CostBurn     string             `json:"costBurn"`
+	CostType     string             `json:"costType"`
+	Maxammo      string             `json:"maxammo"`
+	Range        []float64          `json:"range"`
+	RangeBurn    string             `json:"rangeBurn"`
+	Image        ChampionImage      `json:"image"`
+	Resource     string             `json:"resource"`
+}
+
+// ChampionSpellLevel describes what changes with the ranks of a spell
+type ChampionSpellLevel struct {
+	Label  []string `json:"label"`
+	Effect []string `json:"effect"`
+}
+
+// ChampionPassive contains information about the passive of the champion
+type ChampionPassive struct {
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Image       ChampionImage `json:"image"`
+}
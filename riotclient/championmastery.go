@@ -0,0 +1,23 @@
+package riotclient
+
+import "time"
+
+// ChampionMasteryDTO contains the mastery information of a Summoner for one Champion
+type ChampionMasteryDTO struct {
+	ChampionID                   int64  `json:"championId"`
+	ChampionLevel                int    `json:"championLevel"`
+	ChampionPoints               int    `json:"championPoints"`
+	ChampionPointsSinceLastLevel int64  `json:"championPointsSinceLastLevel"`
+	ChampionPointsUntilNextLevel int64  `json:"championPointsUntilNextLevel"`
+	ChestGranted                 bool   `json:"chestGranted"`
+	LastPlayTime                 int64  `json:"lastPlayTime"` // Unix milliseconds
+	TokensEarned                 int    `json:"tokensEarned"`
+	SummonerID                   string `json:"summonerId"`
+}
+
+// ChampionMasteryDTOList contains the mastery information of a Summoner for all Champions
+type ChampionMasteryDTOList struct {
+	ChampionMasteries []ChampionMasteryDTO `json:"masteries"`
+
+	Timestamp time.Time `json:"timestamp"`
+}
@@ -0,0 +1,12 @@
+package riotclient
+
+import (
+	"context"
+)
+
+// ClientContext is implemented by Clients which support canceling API calls
+type ClientContext interface {
+	// WithContext returns a Client which does all its API calls with the given context.
+	// When ctx is done, queued calls are dropped, calls in flight are aborted and return an error.
+	WithContext(ctx context.Context) Client
+}
@@ -0,0 +1,241 @@
+package riotclientdd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+)
+
+// defaultRefreshInterval is how often the realms and the version list are downloaded again if nothing is configured
+const defaultRefreshInterval = 60 * time.Minute
+
+// cachedAssets are the Data Dragon files per game version and language which are kept in the cache
+var cachedAssets = []string{"champion", "item", "runesReforged", "summoner"}
+
+// A cache keeps Data Dragon files on disk. Files of a game version and language never change, they are stored as
+// <dir>/<version>/<language>/<asset>.json and used forever. The realms and the version list point to the current
+// versions, they are stored in <dir>/pointers and downloaded again when they are older than the refresh interval.
+type cache struct {
+	dir     string
+	refresh time.Duration
+	log     *logrus.Entry
+}
+
+func newCache(cfg config.DataDragonCache) (*cache, error) {
+	refresh := time.Duration(cfg.RefreshInterval) * time.Minute
+	if refresh == 0 {
+		refresh = defaultRefreshInterval
+	}
+
+	if err := os.MkdirAll(filepath.Join(cfg.Dir, "pointers"), 0755); err != nil {
+		return nil, fmt.Errorf("Error creating Data Dragon cache directory: %s", err)
+	}
+
+	return &cache{dir: cfg.Dir, refresh: refresh, log: logging.Get("RiotClientDD Cache")}, nil
+}
+
+// validName returns false for names which must not be used as part of a path, e.g., ".." or names containing separators
+func validName(name string) bool {
+	return len(name) > 0 && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// assetPath returns the file of an asset of a game version and language, false if they cannot be cached.
+// Assets in sub directories of Data Dragon, e.g., champion/Annie, are kept in the same sub directories.
+func (c *cache) assetPath(version, language, asset string) (string, bool) {
+	if !validName(version) || !validName(language) {
+		return "", false
+	}
+	parts := strings.Split(asset, "/")
+	for _, part := range parts {
+		if !validName(part) {
+			return "", false
+		}
+	}
+	return filepath.Join(append([]string{c.dir, version, language}, parts...)...) + ".json", true
+}
+
+func (c *cache) pointerPath(name string) string {
+	return filepath.Join(c.dir, "pointers", name+".json")
+}
+
+// read returns the content of a cached file and how old it is
+func (c *cache) read(path string) ([]byte, time.Duration, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	return data, time.Since(info.ModTime()), nil
+}
+
+// write stores a file in the cache. It is written to a temporary file first, so that readers never see a partial file.
+func (c *cache) write(path string, data []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// writeOutdated stores a pointer which has to be refreshed on the next access, but can be used when Data Dragon is unreachable
+func (c *cache) writeOutdated(path string, data []byte) error {
+	if err := c.write(path, data); err != nil {
+		return err
+	}
+	return os.Chtimes(path, time.Unix(0, 0), time.Unix(0, 0))
+}
+
+// seed copies the assets from an extracted dragontail archive, laid out as <version>/data/<language>/<asset>.json,
+// and the champion details from <version>/data/<language>/champion/<ID>.json into the cache. Files which are already cached are kept. If the cache has no realm for realm or no version list,
+// outdated ones pointing to the newest seeded version are created, so that the cache can be used offline right away.
+func (c *cache) seed(dragontailDir string, realm string) (int, error) {
+	versionDirs, err := ioutil.ReadDir(dragontailDir)
+	if err != nil {
+		return 0, fmt.Errorf("Error reading dragontail directory: %s", err)
+	}
+
+	seeded := 0
+	var versions []string
+	languages := make(map[string]bool)
+	for _, versionDir := range versionDirs {
+		version := versionDir.Name()
+		if !versionDir.IsDir() || !validName(version) {
+			continue
+		}
+		languageDirs, err := ioutil.ReadDir(filepath.Join(dragontailDir, version, "data"))
+		if err != nil {
+			continue
+		}
+
+		found := false
+		for _, languageDir := range languageDirs {
+			language := languageDir.Name()
+			if !languageDir.IsDir() || !validName(language) {
+				continue
+			}
+			assets := append([]string(nil), cachedAssets...)
+			championFiles, _ := ioutil.ReadDir(filepath.Join(dragontailDir, version, "data", language, "champion"))
+			for _, championFile := range championFiles {
+				if !championFile.IsDir() && strings.HasSuffix(championFile.Name(), ".json") {
+					assets = append(assets, "champion/"+strings.TrimSuffix(championFile.Name(), ".json"))
+				}
+			}
+
+			for _, asset := range assets {
+				path, ok := c.assetPath(version, language, asset)
+				if !ok {
+					continue
+				}
+				data, err := ioutil.ReadFile(filepath.Join(dragontailDir, version, "data", language, filepath.FromSlash(asset)+".json"))
+				if err != nil {
+					continue
+				}
+				found = true
+				languages[language] = true
+
+				if _, err := os.Stat(path); err == nil {
+					continue
+				}
+				if err := c.write(path, data); err != nil {
+					return seeded, fmt.Errorf("Error writing %s to Data Dragon cache: %s", path, err)
+				}
+				seeded++
+			}
+		}
+		if found {
+			versions = append(versions, version)
+		}
+	}
+
+	if len(versions) == 0 {
+		return seeded, nil
+	}
+	sort.Slice(versions, func(i, j int) bool { return NewerVersion(versions[i], versions[j]) })
+
+	if err := c.seedPointers(versions, languages, realm); err != nil {
+		return seeded, err
+	}
+
+	return seeded, nil
+}
+
+// seedPointers creates outdated pointers for seeded versions if there are none
+func (c *cache) seedPointers(versions []string, languages map[string]bool, realm string) error {
+	versionsPath := c.pointerPath("versions")
+	if _, err := os.Stat(versionsPath); os.IsNotExist(err) {
+		data, _ := json.Marshal(versions)
+		if err := c.writeOutdated(versionsPath, data); err != nil {
+			return fmt.Errorf("Error writing version list to Data Dragon cache: %s", err)
+		}
+	}
+
+	realmPath := c.pointerPath("realm_" + realm)
+	if _, err := os.Stat(realmPath); os.IsNotExist(err) {
+		language := "en_US"
+		if !languages[language] {
+			for l := range languages {
+				language = l
+				break
+			}
+		}
+		newest := versions[0]
+		data, _ := json.Marshal(currentVersions{
+			N:   N{Item: newest, Rune: newest, Summoner: newest, Champion: newest, Language: newest},
+			V:   newest,
+			L:   language,
+			Cdn: "https://ddragon.leagueoflegends.com/cdn",
+			Dd:  newest,
+		})
+		if err := c.writeOutdated(realmPath, data); err != nil {
+			return fmt.Errorf("Error writing realm to Data Dragon cache: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// NewerVersion returns true if game version a is newer than b, e.g., 9.10.1 is newer than 9.9.1
+func NewerVersion(a, b string) bool {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(partsA) && i < len(partsB); i++ {
+		numberA, errA := strconv.Atoi(partsA[i])
+		numberB, errB := strconv.Atoi(partsB[i])
+		if errA != nil || errB != nil {
+			if partsA[i] != partsB[i] {
+				return partsA[i] > partsB[i]
+			}
+			continue
+		}
+		if numberA != numberB {
+			return numberA > numberB
+		}
+	}
+	return len(partsA) > len(partsB)
+}
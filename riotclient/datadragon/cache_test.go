@@ -0,0 +1,186 @@
+package riotclientdd
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+)
+
+// urlHTTPClient answers with the body given for the URL, fails for unknown URLs or when offline, and counts the requests
+type urlHTTPClient struct {
+	bodies   map[string]string
+	offline  bool
+	requests map[string]int
+}
+
+func (c *urlHTTPClient) Get(url string) (*http.Response, error) {
+	if c.requests == nil {
+		c.requests = make(map[string]int)
+	}
+	c.requests[url]++
+	body, ok := c.bodies[url]
+	if c.offline || !ok {
+		return nil, fmt.Errorf("Could not connect to %s", url)
+	}
+	return &http.Response{StatusCode: 200, Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}, nil
+}
+
+const (
+	realmURL    = "https://ddragon.leagueoflegends.com/realms/euw.json"
+	versionsURL = "https://ddragon.leagueoflegends.com/api/versions.json"
+	championURL = "https://ddragon.leagueoflegends.com/cdn/9.7.1/data/en_GB/champion.json"
+)
+
+func newCacheTestClient(t *testing.T, cfg config.DataDragonCache) (*RiotClientDD, *urlHTTPClient) {
+	httpClient := &urlHTTPClient{bodies: map[string]string{
+		realmURL:    `{"n":{"champion":"9.7.1"},"l":"en_GB","cdn":"https://ddragon.leagueoflegends.com/cdn"}`,
+		versionsURL: `["9.7.1","9.6.1"]`,
+		championURL: `{"data":{}}`,
+	}}
+	c, err := New(httpClient, config.RiotClient{Region: "euw1", DataDragonCache: cfg})
+	if err != nil {
+		t.Fatalf("Could not create Data Dragon client: %s", err)
+	}
+	return c, httpClient
+}
+
+func tempDir(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "datadragon")
+	if err != nil {
+		t.Fatalf("Could not create temporary directory: %s", err)
+	}
+	return dir
+}
+
+func TestRiotClientDD_cacheAssets(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	c, httpClient := newCacheTestClient(t, config.DataDragonCache{Dir: dir})
+	for i := 0; i < 2; i++ {
+		if body, err := c.GetDataDragonChampions(); err != nil || string(body) != `{"data":{}}` {
+			t.Fatalf("GetDataDragonChampions() = %s, %v", body, err)
+		}
+	}
+	if httpClient.requests[championURL] != 1 || httpClient.requests[realmURL] != 1 {
+		t.Errorf("Downloaded %v, want realm and champions only once", httpClient.requests)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "9.7.1", "en_GB", "champion.json")); err != nil {
+		t.Errorf("Champions were not cached: %s", err)
+	}
+
+	// Cached assets of a specific version do not need Data Dragon at all
+	httpClient.offline = true
+	if body, err := c.GetDataDragonChampionsSpecificVersionLanguage("9.7.1", "en_GB"); err != nil || string(body) != `{"data":{}}` {
+		t.Errorf("GetDataDragonChampionsSpecificVersionLanguage() offline = %s, %v", body, err)
+	}
+
+	// Versions and languages which are no valid names are never cached
+	if _, err := c.GetDataDragonChampionsSpecificVersionLanguage("..", "en_GB"); err == nil {
+		t.Errorf("GetDataDragonChampionsSpecificVersionLanguage() with invalid version did not fail")
+	}
+}
+
+func TestRiotClientDD_cachePointers(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+
+	c, httpClient := newCacheTestClient(t, config.DataDragonCache{Dir: dir, RefreshInterval: 10})
+	for i := 0; i < 2; i++ {
+		if body, err := c.GetLoLVersions(); err != nil || string(body) != `["9.7.1","9.6.1"]` {
+			t.Fatalf("GetLoLVersions() = %s, %v", body, err)
+		}
+	}
+	if httpClient.requests[versionsURL] != 1 {
+		t.Errorf("Downloaded versions %d times within the refresh interval, want 1", httpClient.requests[versionsURL])
+	}
+
+	// Outdated pointers are refreshed
+	path := filepath.Join(dir, "pointers", "versions.json")
+	old := time.Now().Add(-11 * time.Minute)
+	os.Chtimes(path, old, old)
+	httpClient.bodies[versionsURL] = `["9.8.1","9.7.1","9.6.1"]`
+	if body, err := c.GetLoLVersions(); err != nil || string(body) != `["9.8.1","9.7.1","9.6.1"]` {
+		t.Errorf("GetLoLVersions() after refresh interval = %s, %v", body, err)
+	}
+
+	// and served stale when Data Dragon is unreachable
+	os.Chtimes(path, old, old)
+	httpClient.offline = true
+	if body, err := c.GetLoLVersions(); err != nil || string(body) != `["9.8.1","9.7.1","9.6.1"]` {
+		t.Errorf("GetLoLVersions() offline = %s, %v", body, err)
+	}
+	if httpClient.requests[versionsURL] != 3 {
+		t.Errorf("Downloaded versions %d times, want 3", httpClient.requests[versionsURL])
+	}
+}
+
+func TestRiotClientDD_cacheSeed(t *testing.T) {
+	dir := tempDir(t)
+	defer os.RemoveAll(dir)
+	dragontail := tempDir(t)
+	defer os.RemoveAll(dragontail)
+
+	for _, version := range []string{"9.9.1", "9.10.1"} {
+		for _, language := range []string{"en_US", "de_DE"} {
+			languageDir := filepath.Join(dragontail, version, "data", language)
+			os.MkdirAll(languageDir, 0755)
+			for _, asset := range cachedAssets {
+				ioutil.WriteFile(filepath.Join(languageDir, asset+".json"), []byte(`"`+version+` `+language+` `+asset+`"`), 0644)
+			}
+			os.MkdirAll(filepath.Join(languageDir, "champion"), 0755)
+			ioutil.WriteFile(filepath.Join(languageDir, "champion", "Annie.json"), []byte(`"`+version+` `+language+` Annie"`), 0644)
+		}
+	}
+	os.MkdirAll(filepath.Join(dragontail, "img"), 0755)
+
+	c, httpClient := newCacheTestClient(t, config.DataDragonCache{Dir: dir, SeedDir: dragontail})
+	httpClient.offline = true
+
+	if body, err := c.GetLoLVersions(); err != nil || string(body) != `["9.10.1","9.9.1"]` {
+		t.Errorf("GetLoLVersions() from seeded cache = %s, %v", body, err)
+	}
+	if body, err := c.GetDataDragonItems(); err != nil || string(body) != `"9.10.1 en_US item"` {
+		t.Errorf("GetDataDragonItems() from seeded cache = %s, %v", body, err)
+	}
+	if body, err := c.GetDataDragonRunesReforgedSpecificVersionLanguage("9.9.1", "de_DE"); err != nil || string(body) != `"9.9.1 de_DE runesReforged"` {
+		t.Errorf("GetDataDragonRunesReforgedSpecificVersionLanguage() from seeded cache = %s, %v", body, err)
+	}
+	if body, err := c.GetDataDragonChampionDetailsSpecificVersionLanguage("Annie", "9.10.1", "de_DE"); err != nil || string(body) != `"9.10.1 de_DE Annie"` {
+		t.Errorf("GetDataDragonChampionDetailsSpecificVersionLanguage() from seeded cache = %s, %v", body, err)
+	}
+	if _, err := c.GetDataDragonChampionDetailsSpecificVersionLanguage("../Annie", "9.10.1", "de_DE"); err == nil {
+		t.Errorf("GetDataDragonChampionDetailsSpecificVersionLanguage() with invalid champion ID did not fail")
+	}
+
+	// Seeded pointers are outdated, so that they are refreshed when Data Dragon is reachable
+	httpClient.offline = false
+	if body, err := c.GetLoLVersions(); err != nil || string(body) != `["9.7.1","9.6.1"]` {
+		t.Errorf("GetLoLVersions() online = %s, %v", body, err)
+	}
+}
+
+func Test_newerVersion(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want bool
+	}{
+		{"9.10.1", "9.9.1", true},
+		{"9.9.1", "9.10.1", false},
+		{"10.1.1", "9.24.1", true},
+		{"9.9.1", "9.9.1", false},
+		{"9.9.1", "9.9", true},
+	}
+	for _, tt := range tests {
+		if got := NewerVersion(tt.a, tt.b); got != tt.want {
+			t.Errorf("NewerVersion(%s, %s) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
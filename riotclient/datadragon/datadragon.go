@@ -6,6 +6,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"strings"
+	"time"
 
 	"git.abyle.org/hps/alolstats/config"
 	"git.abyle.org/hps/alolstats/logging"
@@ -42,6 +43,7 @@ type RiotClientDD struct {
 	config     config.RiotClient
 	httpClient httpClient
 	log        *logrus.Entry
+	cache      *cache
 }
 
 type httpClient interface {
@@ -70,6 +72,20 @@ func New(httpClient httpClient, cfg config.RiotClient) (*RiotClientDD, error) {
 		log:        logging.Get("RiotClientDD"),
 	}
 
+	if len(cfg.DataDragonCache.Dir) > 0 {
+		c.cache, err = newCache(cfg.DataDragonCache)
+		if err != nil {
+			return nil, err
+		}
+		if len(cfg.DataDragonCache.SeedDir) > 0 {
+			seeded, err := c.cache.seed(cfg.DataDragonCache.SeedDir, c.getRegion())
+			if err != nil {
+				return nil, fmt.Errorf("Error seeding Data Dragon cache: %s", err)
+			}
+			c.log.Infof("Seeded Data Dragon cache with %d files from %s", seeded, cfg.DataDragonCache.SeedDir)
+		}
+	}
+
 	return c, nil
 }
 
@@ -87,6 +103,69 @@ func (c *RiotClientDD) downloadFile(url string) ([]byte, error) {
 	return ioutil.ReadAll(response.Body)
 }
 
+// getPointer returns a file which changes over time, e.g., the realm. With a cache it is only downloaded again when
+// the cached copy is older than the refresh interval, and the cached copy is used when the download fails.
+func (c *RiotClientDD) getPointer(name string, url string) ([]byte, error) {
+	if c.cache == nil {
+		return c.downloadFile(url)
+	}
+
+	path := c.cache.pointerPath(name)
+	cached, age, cacheErr := c.cache.read(path)
+	if cacheErr == nil && age < c.cache.refresh {
+		return cached, nil
+	}
+
+	body, err := c.downloadFile(url)
+	if err != nil {
+		if cacheErr == nil {
+			c.log.Warnf("Data Dragon is unreachable (%s), using cached %s which was not refreshed for %s", err, name, age.Round(time.Minute))
+			return cached, nil
+		}
+		return nil, err
+	}
+
+	if err := c.cache.write(path, body); err != nil {
+		c.log.Warnf("Error writing %s to Data Dragon cache: %s", name, err)
+	}
+	return body, nil
+}
+
+// getAsset returns a file of a game version and language, e.g., champion for champion.json. Cached files are used
+// without asking Data Dragon. versions are only needed to download the file, they are fetched if nil.
+func (c *RiotClientDD) getAsset(versions *currentVersions, version, language, asset string) ([]byte, error) {
+	var path string
+	cacheable := false
+	if c.cache != nil {
+		path, cacheable = c.cache.assetPath(version, language, asset)
+		if cacheable {
+			if cached, _, err := c.cache.read(path); err == nil {
+				return cached, nil
+			}
+		}
+	}
+
+	if versions == nil {
+		var err error
+		versions, err = c.getVersions()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	body, err := c.downloadFile(versions.Cdn + "/" + version + "/data/" + language + "/" + asset + ".json")
+	if err != nil {
+		return nil, err
+	}
+
+	if cacheable {
+		if err := c.cache.write(path, body); err != nil {
+			c.log.Warnf("Error writing %s to Data Dragon cache: %s", path, err)
+		}
+	}
+	return body, nil
+}
+
 func (c *RiotClientDD) getRegion() string {
 	region := strings.ToLower(c.config.Region)
 
@@ -97,7 +176,7 @@ func (c *RiotClientDD) getVersions() (*currentVersions, error) {
 
 	versionURL := "https://ddragon.leagueoflegends.com/realms/" + c.getRegion() + ".json"
 
-	versionData, err := c.downloadFile(versionURL)
+	versionData, err := c.getPointer("realm_"+c.getRegion(), versionURL)
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading versions data from Data Dragon: %s", err)
 	}
@@ -115,7 +194,7 @@ func (c *RiotClientDD) getVersions() (*currentVersions, error) {
 func (c *RiotClientDD) GetLoLVersions() ([]byte, error) {
 	versionsURL := "https://ddragon.leagueoflegends.com/api/versions.json"
 
-	body, err := c.downloadFile(versionsURL)
+	body, err := c.getPointer("versions", versionsURL)
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Verions data from Data Dragon: %s", err)
 	}
@@ -130,9 +209,7 @@ func (c *RiotClientDD) GetDataDragonChampions() ([]byte, error) {
 		return nil, err
 	}
 
-	championsURL := versions.Cdn + "/" + versions.N.Champion + "/data/" + versions.L + "/champion.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(versions, versions.N.Champion, versions.L, "champion")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Champions data from Data Dragon: %s", err)
 	}
@@ -142,16 +219,24 @@ func (c *RiotClientDD) GetDataDragonChampions() ([]byte, error) {
 
 // GetDataDragonChampionsSpecificVersionLanguage returns the champions for a given game version and language
 func (c *RiotClientDD) GetDataDragonChampionsSpecificVersionLanguage(gameVersion, language string) ([]byte, error) {
-	versions, err := c.getVersions()
+	body, err := c.getAsset(nil, gameVersion, language, "champion")
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("Error downloading Champions data for game version %s and language %s from Data Dragon: %s", gameVersion, language, err)
 	}
 
-	championsURL := versions.Cdn + "/" + gameVersion + "/data/" + language + "/champion.json"
+	return body, nil
+}
 
-	body, err := c.downloadFile(championsURL)
+// GetDataDragonChampionDetailsSpecificVersionLanguage returns the full data of the champion with the given ID, e.g., Annie,
+// for a given game version and language
+func (c *RiotClientDD) GetDataDragonChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) ([]byte, error) {
+	if !validName(championID) {
+		return nil, fmt.Errorf("Invalid Champion ID %s", championID)
+	}
+
+	body, err := c.getAsset(nil, gameVersion, language, "champion/"+championID)
 	if err != nil {
-		return nil, fmt.Errorf("Error downloading Champions data for game version %s and language %s from Data Dragon: %s", gameVersion, language, err)
+		return nil, fmt.Errorf("Error downloading Champion %s data for game version %s and language %s from Data Dragon: %s", championID, gameVersion, language, err)
 	}
 
 	return body, nil
@@ -164,9 +249,7 @@ func (c *RiotClientDD) GetDataDragonSummonerSpells() ([]byte, error) {
 		return nil, err
 	}
 
-	championsURL := versions.Cdn + "/" + versions.N.Summoner + "/data/" + versions.L + "/summoner.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(versions, versions.N.Summoner, versions.L, "summoner")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Summoner Spells data from Data Dragon: %s", err)
 	}
@@ -176,14 +259,7 @@ func (c *RiotClientDD) GetDataDragonSummonerSpells() ([]byte, error) {
 
 // GetDataDragonSummonerSpellsSpecificVersionLanguage returns the Summoner Spells for a given game version and language
 func (c *RiotClientDD) GetDataDragonSummonerSpellsSpecificVersionLanguage(gameVersion, language string) ([]byte, error) {
-	versions, err := c.getVersions()
-	if err != nil {
-		return nil, fmt.Errorf("Unable to get versions from data dragon: %s", err)
-	}
-
-	championsURL := versions.Cdn + "/" + gameVersion + "/data/" + language + "/summoner.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(nil, gameVersion, language, "summoner")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Summoner Spells data for game version %s and language %s from Data Dragon: %s", gameVersion, language, err)
 	}
@@ -198,9 +274,7 @@ func (c *RiotClientDD) GetDataDragonItems() ([]byte, error) {
 		return nil, err
 	}
 
-	championsURL := versions.Cdn + "/" + versions.N.Item + "/data/" + versions.L + "/item.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(versions, versions.N.Item, versions.L, "item")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Items data from Data Dragon: %s", err)
 	}
@@ -210,14 +284,7 @@ func (c *RiotClientDD) GetDataDragonItems() ([]byte, error) {
 
 // GetDataDragonItemsSpecificVersionLanguage returns the Items  for a given game version and language
 func (c *RiotClientDD) GetDataDragonItemsSpecificVersionLanguage(gameVersion, language string) ([]byte, error) {
-	versions, err := c.getVersions()
-	if err != nil {
-		return nil, err
-	}
-
-	championsURL := versions.Cdn + "/" + gameVersion + "/data/" + language + "/item.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(nil, gameVersion, language, "item")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Items data for game version %s and language %s from Data Dragon: %s", gameVersion, language, err)
 	}
@@ -234,9 +301,7 @@ func (c *RiotClientDD) GetDataDragonRunesReforged() ([]byte, error) {
 
 	// We use the Item version as there seems to be no special version for Runes Reforged
 
-	championsURL := versions.Cdn + "/" + versions.N.Item + "/data/" + versions.L + "/runesReforged.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(versions, versions.N.Item, versions.L, "runesReforged")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Runes Reforged data from Data Dragon: %s", err)
 	}
@@ -246,14 +311,7 @@ func (c *RiotClientDD) GetDataDragonRunesReforged() ([]byte, error) {
 
 // GetDataDragonRunesReforgedSpecificVersionLanguage returns the Runes Reforged for a given game version and language
 func (c *RiotClientDD) GetDataDragonRunesReforgedSpecificVersionLanguage(gameVersion, language string) ([]byte, error) {
-	versions, err := c.getVersions()
-	if err != nil {
-		return nil, err
-	}
-
-	championsURL := versions.Cdn + "/" + gameVersion + "/data/" + language + "/runesReforged.json"
-
-	body, err := c.downloadFile(championsURL)
+	body, err := c.getAsset(nil, gameVersion, language, "runesReforged")
 	if err != nil {
 		return nil, fmt.Errorf("Error downloading Runes Reforged data for game version %s and language %s from Data Dragon: %s", gameVersion, language, err)
 	}
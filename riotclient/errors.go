@@ -0,0 +1,117 @@
+package riotclient
+
+import (
+	"fmt"
+	"net/http"
+)
+
+// ErrorKind classifies why a Riot API call failed
+type ErrorKind int
+
+const (
+	// ErrorUnknown is used for errors which could not be classified
+	ErrorUnknown ErrorKind = iota
+	// ErrorBadRequest means the request was invalid (400, 405, 415)
+	ErrorBadRequest
+	// ErrorForbidden means the API key is missing, invalid or has no access to the endpoint (401, 403)
+	ErrorForbidden
+	// ErrorNotFound means the requested data does not exist (404)
+	ErrorNotFound
+	// ErrorRateLimited means a Rate Limit was exceeded (429)
+	ErrorRateLimited
+	// ErrorServerError means the Riot API failed or is unavailable (5xx)
+	ErrorServerError
+	// ErrorTimeout means no response was received in time
+	ErrorTimeout
+	// ErrorCanceled means the call was canceled, e.g., because its context is done or the Client was stopped
+	ErrorCanceled
+	// ErrorTransport means the request could not be sent or the response could not be received, e.g., because the connection failed
+	ErrorTransport
+)
+
+var errorKindNames = map[ErrorKind]string{
+	ErrorUnknown:     "Unknown Error",
+	ErrorBadRequest:  "Bad Request",
+	ErrorForbidden:   "Forbidden",
+	ErrorNotFound:    "Not Found",
+	ErrorRateLimited: "Rate Limited",
+	ErrorServerError: "Server Error",
+	ErrorTimeout:     "Timeout",
+	ErrorCanceled:    "Canceled",
+	ErrorTransport:   "Transport Error",
+}
+
+func (k ErrorKind) String() string {
+	if name, ok := errorKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("ErrorKind(%d)", int(k))
+}
+
+// Retryable returns true if a call failing with this kind of error may succeed when it is repeated
+func (k ErrorKind) Retryable() bool {
+	switch k {
+	case ErrorRateLimited, ErrorServerError, ErrorTimeout, ErrorTransport:
+		return true
+	default:
+		return false
+	}
+}
+
+// Error is returned by Clients when a Riot API call failed. Use Kind to decide how to handle it instead of the message.
+type Error struct {
+	Kind ErrorKind
+	// StatusCode is the HTTP status code of the Riot API response, 0 if there was none
+	StatusCode int
+	Message    string
+}
+
+// NewError returns an Error of the given kind without status code
+func NewError(kind ErrorKind, format string, a ...interface{}) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, a...)}
+}
+
+// NewStatusCodeError returns an Error for an unsuccessful HTTP status code of the Riot API
+func NewStatusCodeError(statusCode int) *Error {
+	var kind ErrorKind
+	switch {
+	case statusCode == http.StatusUnauthorized || statusCode == http.StatusForbidden:
+		kind = ErrorForbidden
+	case statusCode == http.StatusNotFound:
+		kind = ErrorNotFound
+	case statusCode == http.StatusTooManyRequests:
+		kind = ErrorRateLimited
+	case statusCode >= 500:
+		kind = ErrorServerError
+	case statusCode >= 400:
+		kind = ErrorBadRequest
+	}
+
+	text := http.StatusText(statusCode)
+	if len(text) == 0 {
+		text = errorKindNames[kind]
+	}
+
+	return &Error{Kind: kind, StatusCode: statusCode, Message: fmt.Sprintf("Status code %d (%s)", statusCode, text)}
+}
+
+// WrapError returns an Error with the given message which keeps Kind and StatusCode of err
+func WrapError(err error, format string, a ...interface{}) *Error {
+	wrapped := NewError(KindOf(err), format, a...)
+	if e, ok := err.(*Error); ok {
+		wrapped.StatusCode = e.StatusCode
+	}
+	return wrapped
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// KindOf returns the ErrorKind of err, ErrorUnknown if err is not an *Error
+func KindOf(err error) ErrorKind {
+	if e, ok := err.(*Error); ok {
+		return e.Kind
+	}
+	return ErrorUnknown
+}
@@ -0,0 +1,61 @@
+package riotclient
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestNewStatusCodeError(t *testing.T) {
+	tests := []struct {
+		statusCode  int
+		wantKind    ErrorKind
+		wantMessage string
+		retryable   bool
+	}{
+		{400, ErrorBadRequest, "Status code 400 (Bad Request)", false},
+		{401, ErrorForbidden, "Status code 401 (Unauthorized)", false},
+		{403, ErrorForbidden, "Status code 403 (Forbidden)", false},
+		{404, ErrorNotFound, "Status code 404 (Not Found)", false},
+		{415, ErrorBadRequest, "Status code 415 (Unsupported Media Type)", false},
+		{429, ErrorRateLimited, "Status code 429 (Too Many Requests)", true},
+		{500, ErrorServerError, "Status code 500 (Internal Server Error)", true},
+		{503, ErrorServerError, "Status code 503 (Service Unavailable)", true},
+		{599, ErrorServerError, "Status code 599 (Server Error)", true},
+		{302, ErrorUnknown, "Status code 302 (Found)", false},
+	}
+	for _, tt := range tests {
+		err := NewStatusCodeError(tt.statusCode)
+		if err.Kind != tt.wantKind {
+			t.Errorf("NewStatusCodeError(%d) kind = %s, want %s", tt.statusCode, err.Kind, tt.wantKind)
+		}
+		if err.StatusCode != tt.statusCode {
+			t.Errorf("NewStatusCodeError(%d) status code = %d", tt.statusCode, err.StatusCode)
+		}
+		if err.Error() != tt.wantMessage {
+			t.Errorf("NewStatusCodeError(%d) message = %s, want %s", tt.statusCode, err.Error(), tt.wantMessage)
+		}
+		if err.Kind.Retryable() != tt.retryable {
+			t.Errorf("NewStatusCodeError(%d) retryable = %t, want %t", tt.statusCode, err.Kind.Retryable(), tt.retryable)
+		}
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	if got := KindOf(nil); got != ErrorUnknown {
+		t.Errorf("KindOf(nil) = %s, want %s", got, ErrorUnknown)
+	}
+	if got := KindOf(fmt.Errorf("Some error")); got != ErrorUnknown {
+		t.Errorf("KindOf() of a plain error = %s, want %s", got, ErrorUnknown)
+	}
+	if got := KindOf(NewError(ErrorTimeout, "API call timed out")); got != ErrorTimeout {
+		t.Errorf("KindOf() = %s, want %s", got, ErrorTimeout)
+	}
+
+	wrapped := WrapError(NewStatusCodeError(404), "Error getting %s", "Summoner")
+	if wrapped.Kind != ErrorNotFound || wrapped.StatusCode != 404 || wrapped.Error() != "Error getting Summoner" {
+		t.Errorf("WrapError() = %+v, want kind, status code and new message", wrapped)
+	}
+	if wrapped := WrapError(fmt.Errorf("Some error"), "Error"); wrapped.Kind != ErrorUnknown {
+		t.Errorf("WrapError() of a plain error has kind %s, want %s", wrapped.Kind, ErrorUnknown)
+	}
+}
@@ -74,3 +74,21 @@ type LeaguePositionDTO struct {
 type LeaguePositionDTOList struct {
 	LeaguePosition []LeaguePositionDTO
 }
+
+// LeagueEntryDTO contains one entry of a tier and division in a queue, as returned by the League-EXP API
+type LeagueEntryDTO struct {
+	LeagueID     string        `json:"leagueId"`
+	SummonerID   string        `json:"summonerId"`
+	SummonerName string        `json:"summonerName"`
+	QueueType    string        `json:"queueType"`
+	Tier         string        `json:"tier"`
+	Rank         string        `json:"rank"`
+	LeaguePoints int           `json:"leaguePoints"`
+	Wins         int           `json:"wins"`
+	Losses       int           `json:"losses"`
+	HotStreak    bool          `json:"hotStreak"`
+	Veteran      bool          `json:"veteran"`
+	FreshBlood   bool          `json:"freshBlood"`
+	Inactive     bool          `json:"inactive"`
+	MiniSeries   MiniSeriesDTO `json:"miniSeries"`
+}
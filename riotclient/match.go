@@ -184,6 +184,7 @@ type PlayerDTO struct {
 	CurrentAccountID  string `json:"currentAccountId"`
 	MatchHistoryURI   string `json:"matchHistoryUri"`
 	ProfileIcon       int    `json:"profileIcon"`
+	PUUID             string `json:"puuid"` // Only provided by API v5
 }
 
 // ParticipantIdentityDTO contains Participant identity information
@@ -0,0 +1,28 @@
+package riotclient
+
+import "strings"
+
+// MatchTier returns the tier of a match, i.e., the most common highest achieved season tier of its participants
+// (e.g., "GOLD"), or "UNRANKED" if it is not known
+func MatchTier(participants []ParticipantDTO) string {
+	tierCounts := make(map[string]uint64)
+	for _, participant := range participants {
+		currentTier := strings.ToUpper(strings.TrimSpace(participant.HighestAchievedSeasonTier))
+		tierCounts[currentTier]++
+	}
+
+	var maxTier string
+	maxCounts := uint64(0)
+	for tier, cnt := range tierCounts {
+		if cnt > maxCounts {
+			maxTier = tier
+			maxCounts = cnt
+		}
+	}
+
+	if maxTier == "" {
+		return "UNRANKED"
+	}
+
+	return maxTier
+}
@@ -0,0 +1,22 @@
+package riotclient
+
+// Priority defines how urgent an API call is. Clients supporting priorities serve calls with a higher priority first.
+type Priority int
+
+const (
+	// PriorityInteractive is used for calls somebody is waiting for, e.g., REST API requests. It is the default.
+	PriorityInteractive Priority = iota
+	// PriorityBackground is used for regular background crawls
+	PriorityBackground
+	// PriorityBulk is used for bulk backfills which can wait the longest
+	PriorityBulk
+
+	// NumPriorities is the number of known priorities
+	NumPriorities = int(PriorityBulk) + 1
+)
+
+// ClientPriority is implemented by Clients which support scheduling API calls by priority
+type ClientPriority interface {
+	// WithPriority returns a Client which does all its API calls with the given priority
+	WithPriority(priority Priority) Client
+}
@@ -1,57 +1,71 @@
-// Package riotclientrl is a utility package to check the Rate Limit responses of the Riot API
-// and gives suggestions on how long to wait to avoid beeing rate limit
+// Package riotclientrl is a utility package to check the Rate Limit responses of the Riot API.
+// The limits announced by the Riot API are enforced client-side with sliding windows, see Wait.
 package riotclientrl
 
 import (
+	"context"
 	"net/http"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
-	"github.com/sirupsen/logrus"
 	"git.abyle.org/hps/alolstats/logging"
+	"github.com/sirupsen/logrus"
 )
 
 var now = time.Now
-
-// A limit represents a Riot API rate limit set of limits and counts
-type limit struct {
-	// appRateLimits key = time period in seconds, value = number of calls allowed in that time period
-	rateLimits map[uint32]uint32
-	// appRateLimitsCount key = time period in seconds, value = number of used calls in that time period
-	rateLimitsCount map[uint32]uint32
-}
-
-func newLimit() limit {
-	l := limit{
-		rateLimits:      make(map[uint32]uint32),
-		rateLimitsCount: make(map[uint32]uint32),
+var sleep = sleepContext
+
+// sleepContext pauses for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
 	}
-	return l
 }
 
 // RiotClientRL Riot LoL API Rate Limit checker
 type RiotClientRL struct {
 	log *logrus.Entry
 
-	// Stores the Application Rate Limit informations
-	appRateLimit limit
-	// Stores the Method Rate Limit informations key = method name, value limit
-	methodRateLimits map[string]limit
 	// Time after which it is allowed to send a new request to the API
 	retryAfter time.Time
 
+	// Sliding windows tracking the requests for the Application Rate Limits
+	appWindows windows
+	// Sliding windows tracking the requests per method, key = method name
+	methodWindows map[string]windows
+
+	// Requests currently waiting in WaitPriority
+	waiters map[*waiter]bool
+	// waitersChanged is closed and replaced when a waiter stops waiting
+	waitersChanged chan struct{}
+
 	rateLimitMutex sync.Mutex
 }
 
+// A waiter is a request waiting for a slot in the rate limits
+type waiter struct {
+	method   string
+	priority int
+}
+
+// yieldRecheck is how long a request which yields to a higher priority one waits at most before checking again
+const yieldRecheck = 100 * time.Millisecond
+
 // New creates a new Riot LoL API Rate Limit checker
 func New() (*RiotClientRL, error) {
 
 	rl := &RiotClientRL{
-		log:              logging.Get("RiotClientRL"),
-		appRateLimit:     newLimit(),
-		methodRateLimits: make(map[string]limit),
+		log:           logging.Get("RiotClientRL"),
+		appWindows:    make(windows),
+		methodWindows: make(map[string]windows),
 	}
 
 	return rl, nil
@@ -70,201 +84,226 @@ func New() (*RiotClientRL, error) {
 // header: http header containing rate limit information
 // method: method name of the api endpoint used
 func (c *RiotClientRL) UpdateRateLimits(header http.Header, method string) {
-	if val, ok := header["X-App-Rate-Limit"]; ok {
-		if len(val) > 0 {
-			c.updateAppRateLimits(val[0])
-		}
+	appLimits := c.parseRates(header.Get("X-App-Rate-Limit"))
+	appCounts := c.parseRates(header.Get("X-App-Rate-Limit-Count"))
+	methodLimits := c.parseRates(header.Get("X-Method-Rate-Limit"))
+	methodCounts := c.parseRates(header.Get("X-Method-Rate-Limit-Count"))
+	retryAfter, hasRetryAfter := header["Retry-After"]
+	if len(appLimits) == 0 && len(appCounts) == 0 && len(methodLimits) == 0 && len(methodCounts) == 0 && !hasRetryAfter {
+		return
 	}
-	if val, ok := header["X-App-Rate-Limit-Count"]; ok {
-		if len(val) > 0 {
-			c.updateAppRateLimitsCount(val[0])
+
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
+
+	t := now()
+	for period, calls := range appLimits {
+		if w, ok := c.appWindows[period]; !ok {
+			c.log.Infof("Added rate limit: Period: %ds Allowed Requests: %d", period, calls)
+		} else if w.limit != calls {
+			c.log.Infof("Updated rate limit: Period: %ds Allowed Requests: %d", period, calls)
 		}
+		c.appWindows.set(period, calls)
 	}
+	c.appWindows.sync(appCounts, t)
 
-	if val, ok := header["X-Method-Rate-Limit"]; ok {
-		if len(val) > 0 {
-			c.updateMethodRateLimits(val[0], method)
+	if len(methodLimits) > 0 {
+		if _, ok := c.methodWindows[method]; !ok {
+			c.methodWindows[method] = make(windows)
 		}
-	}
-	if val, ok := header["X-Method-Rate-Limit-Count"]; ok {
-		if len(val) > 0 {
-			c.updateMethodRateLimitsCount(val[0], method)
+		for period, calls := range methodLimits {
+			c.methodWindows[method].set(period, calls)
 		}
 	}
+	c.methodWindows[method].sync(methodCounts, t)
 
-	if val, ok := header["Retry-After"]; ok {
-		if len(val) > 0 {
-			seconds, err := strconv.ParseUint(val[0], 10, 32)
-			if err != nil {
-				c.log.Warnf("Could not convert value %s to rate limit retry at seconds", val[0])
-				c.updateRateLimitRetryAt(10)
-			} else {
-				c.updateRateLimitRetryAt(uint32(seconds))
-			}
+	if len(retryAfter) > 0 {
+		seconds, err := strconv.ParseUint(retryAfter[0], 10, 32)
+		if err != nil {
+			c.log.Warnf("Could not convert value %s to rate limit retry at seconds", retryAfter[0])
+			seconds = 10
 		}
+		c.retryAfter = t.Add(time.Second * time.Duration(seconds))
 	}
 }
 
-func (c *RiotClientRL) updateAppRateLimits(limits string) {
-	c.rateLimitMutex.Lock()
-	defer c.rateLimitMutex.Unlock()
-
-	if len(limits) > 0 {
-		values := strings.Split(limits, ",")
-		if c.appRateLimit.rateLimits == nil {
-			c.appRateLimit.rateLimits = make(map[uint32]uint32)
+// parseRates parses a rate limit header value, e.g., 100:1,1000:10, into a map of time period in seconds to number of calls.
+// Invalid entries are skipped.
+func (c *RiotClientRL) parseRates(value string) map[uint32]uint32 {
+	rates := make(map[uint32]uint32)
+	if len(value) == 0 {
+		return rates
+	}
+	for _, entry := range strings.Split(value, ",") {
+		rate := strings.Split(entry, ":")
+		if len(rate) != 2 {
+			continue
 		}
-		for _, entry := range values {
-			rate := strings.Split(entry, ":")
-			if len(rate) == 2 {
-				period, err := strconv.ParseUint(rate[1], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit period", rate[1])
-					continue
-				}
-				calls, err := strconv.ParseUint(rate[0], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit count", rate[0])
-					continue
-				}
-				if val, ok := c.appRateLimit.rateLimits[uint32(period)]; ok {
-					if val != uint32(calls) {
-						c.log.Infof("Updated rate limit: Period: %ds Allowed Requests: %d", uint32(period), uint32(calls))
-					}
-				} else {
-					c.log.Infof("Added rate limit: Period: %ds Allowed Requests: %d", uint32(period), uint32(calls))
-				}
-				c.appRateLimit.rateLimits[uint32(period)] = uint32(calls)
-			}
+		period, err := strconv.ParseUint(rate[1], 10, 32)
+		if err != nil {
+			c.log.Warnf("Could not convert value %s to rate limit period", rate[1])
+			continue
 		}
+		calls, err := strconv.ParseUint(rate[0], 10, 32)
+		if err != nil {
+			c.log.Warnf("Could not convert value %s to rate limit count", rate[0])
+			continue
+		}
+		rates[uint32(period)] = uint32(calls)
 	}
+	return rates
 }
 
-// updateRateLimitsCount update the current rate limit counts
-func (c *RiotClientRL) updateAppRateLimitsCount(counts string) {
-	c.rateLimitMutex.Lock()
-	defer c.rateLimitMutex.Unlock()
-
-	if len(counts) > 0 {
-		values := strings.Split(counts, ",")
-		c.appRateLimit.rateLimitsCount = make(map[uint32]uint32)
-		for _, entry := range values {
-			rate := strings.Split(entry, ":")
-			if len(rate) == 2 {
-				period, err := strconv.ParseUint(rate[1], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit period", rate[1])
-					continue
-				}
-				calls, err := strconv.ParseUint(rate[0], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit count", rate[0])
-					continue
-				}
-				c.appRateLimit.rateLimitsCount[uint32(period)] = uint32(calls)
-			}
-		}
+// nextAllowed returns the earliest time not before t at which a request for method is allowed
+// by the Retry-After time and all known Application and Method Rate Limits
+func (c *RiotClientRL) nextAllowed(method string, t time.Time) time.Time {
+	next := t
+	if c.retryAfter.After(next) {
+		next = c.retryAfter
 	}
+	if slot := c.appWindows.nextSlot(t); slot.After(next) {
+		next = slot
+	}
+	if slot := c.methodWindows[method].nextSlot(t); slot.After(next) {
+		next = slot
+	}
+	return next
+}
+
+// Wait blocks until a request for method is allowed by the Retry-After time and all known Application and Method Rate Limits
+// and records the request in the sliding windows of those limits. It has to be called once before every request.
+// Limits are known after the first response carrying X-App-Rate-Limit or X-Method-Rate-Limit headers was passed to UpdateRateLimits.
+func (c *RiotClientRL) Wait(method string) {
+	c.WaitContext(context.Background(), method)
 }
 
-func (c *RiotClientRL) updateMethodRateLimits(limits string, method string) {
+// WaitContext is like Wait, but stops waiting when ctx is done. In that case the request is not recorded and ctx.Err() is returned.
+// The request has the highest priority, see WaitPriority.
+func (c *RiotClientRL) WaitContext(ctx context.Context, method string) error {
+	return c.WaitPriority(ctx, method, 0)
+}
+
+// WaitPriority is like WaitContext for a request with the given priority, lower values are served first.
+// The Application Rate Limits are shared by all methods, so a request does not take a free slot in them
+// while a request with a higher priority waits which is not held back by its own Method Rate Limits.
+func (c *RiotClientRL) WaitPriority(ctx context.Context, method string, priority int) error {
+	w := &waiter{method: method, priority: priority}
 	c.rateLimitMutex.Lock()
-	defer c.rateLimitMutex.Unlock()
+	c.addWaiter(w)
+	c.rateLimitMutex.Unlock()
+	defer func() {
+		c.rateLimitMutex.Lock()
+		c.removeWaiter(w)
+		c.rateLimitMutex.Unlock()
+	}()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
 
-	if len(limits) > 0 {
-		values := strings.Split(limits, ",")
-		if _, ok := c.methodRateLimits[method]; !ok {
-			c.methodRateLimits[method] = newLimit()
+		c.rateLimitMutex.Lock()
+		t := now()
+		next := c.nextAllowed(method, t)
+		if next.After(t) {
+			c.rateLimitMutex.Unlock()
+
+			c.log.Debugln("Sleeping for", next.Sub(t).String(), "to adhere to rate limit")
+			if err := sleep(ctx, next.Sub(t)); err != nil {
+				return err
+			}
+			continue
 		}
-		for _, entry := range values {
-			rate := strings.Split(entry, ":")
-			if len(rate) == 2 {
-				period, err := strconv.ParseUint(rate[1], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit period", rate[1])
-					continue
-				}
-				calls, err := strconv.ParseUint(rate[0], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit count", rate[0])
-					continue
-				}
-				c.methodRateLimits[method].rateLimits[uint32(period)] = uint32(calls)
+		if c.yields(w, t) {
+			changed := c.waitersChanged
+			c.rateLimitMutex.Unlock()
+
+			if err := waitChanged(ctx, changed); err != nil {
+				return err
 			}
+			continue
 		}
+
+		c.appWindows.add(t)
+		c.methodWindows[method].add(t)
+		c.rateLimitMutex.Unlock()
+		return nil
 	}
 }
 
-func (c *RiotClientRL) updateMethodRateLimitsCount(counts string, method string) {
-	c.rateLimitMutex.Lock()
-	defer c.rateLimitMutex.Unlock()
+func (c *RiotClientRL) addWaiter(w *waiter) {
+	if c.waiters == nil {
+		c.waiters = make(map[*waiter]bool)
+		c.waitersChanged = make(chan struct{})
+	}
+	c.waiters[w] = true
+}
 
-	if len(counts) > 0 {
-		values := strings.Split(counts, ",")
-		if _, ok := c.methodRateLimits[method]; !ok {
-			c.methodRateLimits[method] = newLimit()
-		}
-		for _, entry := range values {
-			rate := strings.Split(entry, ":")
-			if len(rate) == 2 {
-				period, err := strconv.ParseUint(rate[1], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit period", rate[1])
-					continue
-				}
-				calls, err := strconv.ParseUint(rate[0], 10, 32)
-				if err != nil {
-					c.log.Warnf("Could not convert value %s to rate limit count", rate[0])
-					continue
-				}
-				c.methodRateLimits[method].rateLimitsCount[uint32(period)] = uint32(calls)
-			}
+// removeWaiter removes a waiter and wakes up the requests which yielded to it
+func (c *RiotClientRL) removeWaiter(w *waiter) {
+	delete(c.waiters, w)
+	close(c.waitersChanged)
+	c.waitersChanged = make(chan struct{})
+}
+
+// yields returns true if a request with a higher priority than w waits at time t which is only held back by the Application Rate Limits
+func (c *RiotClientRL) yields(w *waiter, t time.Time) bool {
+	for other := range c.waiters {
+		if other.priority < w.priority && !c.methodWindows[other.method].nextSlot(t).After(t) {
+			return true
 		}
 	}
+	return false
 }
 
-// updateRateLimitRetryAt sets the retryAt time based on current time + seconds specified
-func (c *RiotClientRL) updateRateLimitRetryAt(seconds uint32) {
-	c.rateLimitMutex.Lock()
-	defer c.rateLimitMutex.Unlock()
-
-	c.retryAfter = now().Add(time.Second * time.Duration(seconds))
+// waitChanged waits until changed is closed, at most for yieldRecheck, or ctx is done
+func waitChanged(ctx context.Context, changed chan struct{}) error {
+	timer := time.NewTimer(yieldRecheck)
+	defer timer.Stop()
+
+	select {
+	case <-changed:
+		return nil
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
 
-// GetRateLimitRetryAt gets a time back at which it is allowed to do a new API call
-// method: method name used for that API call
-func (c *RiotClientRL) GetRateLimitRetryAt(method string) time.Time {
+// Headroom returns how long a request for method has to wait for the Retry-After time and the known Application and Method Rate Limits,
+// and how many requests fit into those limits at that time. Without known limits the number of requests is math.MaxUint32.
+func (c *RiotClientRL) Headroom(method string) (wait time.Duration, free uint32) {
 	c.rateLimitMutex.Lock()
 	defer c.rateLimitMutex.Unlock()
 
-	if c.retryAfter.Sub(now()) <= time.Duration(0) {
-		c.retryAfter = now()
+	t := now()
+	next := c.nextAllowed(method, t)
+	free = c.appWindows.free(next)
+	if f := c.methodWindows[method].free(next); f < free {
+		free = f
 	}
 
-	return c.retryAfter.Add(c.getAdditionalWaitTime(method))
+	return next.Sub(t), free
 }
 
-func (c *RiotClientRL) getAdditionalWaitTime(method string) time.Duration {
-	var addWaitTime time.Duration
-	addWaitTime = 0
+// AppWindows returns the current state of the Application Rate Limit windows, sorted by period
+func (c *RiotClientRL) AppWindows() []WindowStatus {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
 
-	for key, val := range c.appRateLimit.rateLimitsCount {
-		maxSlots := c.appRateLimit.rateLimits[key]
-		emptySlots := int32(maxSlots) - int32(val)
-		if emptySlots <= 5 {
-			addWaitTime += time.Second * time.Duration(key) / 5
-		}
-	}
+	return c.appWindows.status(now())
+}
 
-	if limit, ok := c.methodRateLimits[method]; ok {
-		for key, val := range limit.rateLimitsCount {
-			maxSlots := limit.rateLimits[key]
-			emptySlots := int32(maxSlots) - int32(val)
-			if emptySlots <= 5 {
-				addWaitTime += time.Second * time.Duration(key) / 5
-			}
-		}
-	}
+// MethodWindows returns the current state of the Method Rate Limit windows per method key, sorted by period
+func (c *RiotClientRL) MethodWindows() map[string][]WindowStatus {
+	c.rateLimitMutex.Lock()
+	defer c.rateLimitMutex.Unlock()
 
-	return addWaitTime
+	t := now()
+	status := make(map[string][]WindowStatus, len(c.methodWindows))
+	for method, ws := range c.methodWindows {
+		status[method] = ws.status(t)
+	}
+	return status
 }
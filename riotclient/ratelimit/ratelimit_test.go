@@ -3,30 +3,17 @@
 package riotclientrl
 
 import (
+	"context"
+	"math"
 	"net/http"
 	"reflect"
+	"sync"
 	"testing"
 	"time"
 
 	"git.abyle.org/hps/alolstats/logging"
 )
 
-func Test_newLimit(t *testing.T) {
-	tests := []struct {
-		name string
-		want limit
-	}{
-		{"Succesful creation", limit{rateLimits: make(map[uint32]uint32), rateLimitsCount: make(map[uint32]uint32)}},
-	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			if got := newLimit(); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("newLimit() = %v, want %v", got, tt.want)
-			}
-		})
-	}
-}
-
 func TestNew(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -34,9 +21,9 @@ func TestNew(t *testing.T) {
 		wantErr bool
 	}{
 		{"Succesful creation", &RiotClientRL{
-			log:              logging.Get("RiotClientRL"),
-			appRateLimit:     newLimit(),
-			methodRateLimits: make(map[string]limit),
+			log:           logging.Get("RiotClientRL"),
+			appWindows:    make(windows),
+			methodWindows: make(map[string]windows),
 		}, false},
 	}
 	for _, tt := range tests {
@@ -62,10 +49,17 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 		return t
 	}
 
+	limits := []WindowStatus{
+		{Period: time.Second, Limit: 100, Count: 1},
+		{Period: 10 * time.Second, Limit: 1000, Count: 2},
+		{Period: 600 * time.Second, Limit: 60000, Count: 2},
+		{Period: 3600 * time.Second, Limit: 360000, Count: 2},
+	}
+
 	type fields struct {
-		appRateLimit     limit
-		methodRateLimits map[string]limit
-		retryAfter       time.Time
+		appWindows    []WindowStatus
+		methodWindows map[string][]WindowStatus
+		retryAfter    time.Time
 	}
 	type args struct {
 		header http.Header
@@ -78,22 +72,9 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 	}{
 		{name: "Test 1 - Update AppRateLimit",
 			fields: fields{
-				appRateLimit: limit{
-					rateLimits: map[uint32]uint32{
-						1:    100,
-						10:   1000,
-						600:  60000,
-						3600: 360000,
-					},
-					rateLimitsCount: map[uint32]uint32{
-						1:    1,
-						10:   2,
-						600:  2,
-						3600: 2,
-					},
-				},
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       time.Time{},
+				appWindows:    limits,
+				methodWindows: map[string][]WindowStatus{},
+				retryAfter:    time.Time{},
 			},
 			args: args{
 				header: http.Header{
@@ -104,24 +85,9 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 		},
 		{name: "Test 2 - Update MethodRateLimit",
 			fields: fields{
-				appRateLimit: newLimit(),
-				methodRateLimits: map[string]limit{
-					"champions": {
-						rateLimits: map[uint32]uint32{
-							1:    100,
-							10:   1000,
-							600:  60000,
-							3600: 360000,
-						},
-						rateLimitsCount: map[uint32]uint32{
-							1:    1,
-							10:   2,
-							600:  2,
-							3600: 2,
-						},
-					},
-				},
-				retryAfter: time.Time{},
+				appWindows:    []WindowStatus{},
+				methodWindows: map[string][]WindowStatus{"champions": limits},
+				retryAfter:    time.Time{},
 			},
 			args: args{
 				header: http.Header{
@@ -133,9 +99,9 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 		},
 		{name: "Test 3 - Update RetryAfter",
 			fields: fields{
-				appRateLimit:     newLimit(),
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       now().Add(time.Second * time.Duration(7)),
+				appWindows:    []WindowStatus{},
+				methodWindows: map[string][]WindowStatus{},
+				retryAfter:    now().Add(time.Second * time.Duration(7)),
 			},
 			args: args{
 				header: http.Header{
@@ -145,9 +111,9 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 		},
 		{name: "Test 4 - Update RetryAfter - Header corrupt",
 			fields: fields{
-				appRateLimit:     newLimit(),
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       now().Add(time.Second * time.Duration(10)),
+				appWindows:    []WindowStatus{},
+				methodWindows: map[string][]WindowStatus{},
+				retryAfter:    now().Add(time.Second * time.Duration(10)),
 			},
 			args: args{
 				header: http.Header{
@@ -157,9 +123,9 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 		},
 		{name: "Test 5 - Update AppRateLimit - Header corrupt",
 			fields: fields{
-				appRateLimit:     newLimit(),
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       time.Time{},
+				appWindows:    []WindowStatus{},
+				methodWindows: map[string][]WindowStatus{},
+				retryAfter:    time.Time{},
 			},
 			args: args{
 				header: http.Header{
@@ -168,160 +134,268 @@ func TestRiotClientRL_UpdateRateLimits(t *testing.T) {
 				},
 			},
 		},
+		{name: "Test 6 - Counts of unknown limits are ignored",
+			fields: fields{
+				appWindows:    []WindowStatus{},
+				methodWindows: map[string][]WindowStatus{},
+				retryAfter:    time.Time{},
+			},
+			args: args{
+				header: http.Header{
+					"X-App-Rate-Limit-Count":    []string{"1:1,2:10"},
+					"X-Method-Rate-Limit-Count": []string{"1:1"},
+				},
+				method: "champions",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c, _ := New()
 			c.UpdateRateLimits(tt.args.header, tt.args.method)
-			if !reflect.DeepEqual(c.appRateLimit, tt.fields.appRateLimit) {
-				t.Errorf("New() = %v, want %v", c.appRateLimit, tt.fields.appRateLimit)
+			if got := c.AppWindows(); !reflect.DeepEqual(got, tt.fields.appWindows) {
+				t.Errorf("AppWindows() = %v, want %v", got, tt.fields.appWindows)
 			}
-			if !reflect.DeepEqual(c.methodRateLimits, tt.fields.methodRateLimits) {
-				t.Errorf("New() = %v, want %v", c.methodRateLimits, tt.fields.methodRateLimits)
+			if got := c.MethodWindows(); !reflect.DeepEqual(got, tt.fields.methodWindows) {
+				t.Errorf("MethodWindows() = %v, want %v", got, tt.fields.methodWindows)
 			}
 			if !reflect.DeepEqual(c.retryAfter, tt.fields.retryAfter) {
-				t.Errorf("New() = %v, want %v", c.retryAfter, tt.fields.retryAfter)
+				t.Errorf("retryAfter = %v, want %v", c.retryAfter, tt.fields.retryAfter)
 			}
 		})
 	}
 }
 
-func TestRiotClientRL_GetRateLimitRetryAt(t *testing.T) {
-	type fields struct {
-		appRateLimit     limit
-		methodRateLimits map[string]limit
-		retryAfter       time.Time
+func TestRiotClientRL_Wait(t *testing.T) {
+	// Inject a fake clock which is advanced by sleeping
+	clock := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		return clock
 	}
-	type args struct {
-		method string
+	var slept time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		slept += d
+		clock = clock.Add(d)
+		return nil
 	}
-	tests := []struct {
-		name   string
-		fields fields
-		args   args
-		want   time.Time
-	}{
-		{name: "Test 1 - Plain RetryAfter",
-			fields: fields{
-				appRateLimit:     newLimit(),
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       now().Add(time.Second * time.Duration(43)),
-			},
-			args: args{
-				method: "summoners",
-			},
-			want: now().Add(time.Second * time.Duration(43)),
-		},
-		{name: "Test 2 - Close to getting rate limited due to AppRateLimit",
-			fields: fields{
-				appRateLimit: limit{
-					rateLimits: map[uint32]uint32{
-						1:    100,
-						10:   1000,
-						600:  60000,
-						3600: 360000,
-					},
-					rateLimitsCount: map[uint32]uint32{
-						1:    1,
-						10:   2,
-						600:  60000 - 5,
-						3600: 2,
-					},
-				},
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       now().Add(time.Second * time.Duration(0)),
-			},
-			args: args{
-				method: "summoners",
-			},
-			want: now().Add(time.Second * time.Duration(600/5)),
-		},
-		{name: "Test 3 - Close to getting rate limited due to AppRateLimit - still close",
-			fields: fields{
-				appRateLimit: limit{
-					rateLimits: map[uint32]uint32{
-						1:    100,
-						10:   1000,
-						600:  60000,
-						3600: 360000,
-					},
-					rateLimitsCount: map[uint32]uint32{
-						1:    1,
-						10:   2,
-						600:  60000 - 1,
-						3600: 2,
-					},
-				},
-				methodRateLimits: make(map[string]limit),
-				retryAfter:       now().Add(time.Second * time.Duration(0)),
-			},
-			args: args{
-				method: "summoners",
-			},
-			want: now().Add(time.Second * time.Duration(600/5)),
-		},
-		{name: "Test 4 - Close to getting rate limited due to MethodRateLimit",
-			fields: fields{
-				appRateLimit: newLimit(),
-				methodRateLimits: map[string]limit{
-					"champions": {
-						rateLimits: map[uint32]uint32{
-							1:    100,
-							10:   1000,
-							600:  60000,
-							3600: 360000,
-						},
-						rateLimitsCount: map[uint32]uint32{
-							1:    1,
-							10:   1000 - 2,
-							600:  2,
-							3600: 2,
-						},
-					},
-				},
-				retryAfter: now().Add(time.Second * time.Duration(13)),
-			},
-			args: args{
-				method: "champions",
-			},
-			want: now().Add(time.Second * time.Duration(13+10/5)),
-		},
-		{name: "Test 4 - Close to getting rate limited for a certain Method, but not the one we requested",
-			fields: fields{
-				appRateLimit: newLimit(),
-				methodRateLimits: map[string]limit{
-					"champions": {
-						rateLimits: map[uint32]uint32{
-							1:    100,
-							10:   1000,
-							600:  60000,
-							3600: 360000,
-						},
-						rateLimitsCount: map[uint32]uint32{
-							1:    1,
-							10:   1000 - 2,
-							600:  2,
-							3600: 2,
-						},
-					},
-				},
-				retryAfter: now().Add(time.Second * time.Duration(23)),
-			},
-			args: args{
-				method: "summoners",
-			},
-			want: now().Add(time.Second * time.Duration(23)),
-		},
+	defer func() {
+		sleep = sleepContext
+	}()
+
+	c, _ := New()
+
+	// No limits known yet
+	c.Wait("summoners")
+	if slept != 0 {
+		t.Errorf("Wait() slept %s without known limits", slept)
 	}
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			c, _ := New()
-			c.appRateLimit = tt.fields.appRateLimit
-			c.methodRateLimits = tt.fields.methodRateLimits
-			c.retryAfter = tt.fields.retryAfter
-			if got := c.GetRateLimitRetryAt(tt.args.method); !reflect.DeepEqual(got, tt.want) {
-				t.Errorf("RiotClientRL.GetRateLimitRetryAt() = %v, want %v", got, tt.want)
+
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":          []string{"3:1,5:10"},
+		"X-App-Rate-Limit-Count":    []string{"1:1,1:10"},
+		"X-Method-Rate-Limit":       []string{"2:10"},
+		"X-Method-Rate-Limit-Count": []string{"0:10"},
+	}, "summoners")
+
+	c.Wait("summoners")
+	c.Wait("summoners")
+	if slept != 0 {
+		t.Errorf("Wait() slept %s even though slots were free", slept)
+	}
+
+	// Method limit of 2 per 10s is used up, other methods are only restricted by the App limit
+	c.Wait("champions")
+	if slept != time.Second {
+		t.Errorf("Wait() slept %s, want 1s for the App limit of 3 per second", slept)
+	}
+	c.Wait("summoners")
+	if slept != 10*time.Second {
+		t.Errorf("Wait() slept %s in total, want 10s for the Method limit", slept)
+	}
+
+	// Other clients sharing the key used up the App limit
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit-Count": []string{"1:1,5:10"},
+	}, "champions")
+	slept = 0
+	c.Wait("champions")
+	if slept != time.Second {
+		t.Errorf("Wait() slept %s, want 1s until the oldest own request leaves the window after syncing the App count", slept)
+	}
+
+	// Retry-After is respected
+	c.UpdateRateLimits(http.Header{"Retry-After": []string{"30"}}, "champions")
+	slept = 0
+	c.Wait("champions")
+	if slept != 30*time.Second {
+		t.Errorf("Wait() slept %s, want 30s for Retry-After", slept)
+	}
+}
+
+func TestRiotClientRL_WaitContext(t *testing.T) {
+	c, _ := New()
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":       []string{"1:10"},
+		"X-App-Rate-Limit-Count": []string{"1:10"},
+	}, "summoners")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := c.WaitContext(ctx, "summoners"); err != context.DeadlineExceeded {
+		t.Errorf("WaitContext() error = %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	cancel()
+	if err := c.WaitContext(ctx, "champions"); err == nil {
+		t.Errorf("WaitContext() expected an error for a context which is done")
+	}
+	if got := c.AppWindows()[0].Count; got != 1 {
+		t.Errorf("WaitContext() recorded requests when canceled, got count %d, want 1", got)
+	}
+}
+
+func TestRiotClientRL_WaitPriority(t *testing.T) {
+	now = time.Now
+	sleep = sleepContext
+
+	c, _ := New()
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":       []string{"1:1"},
+		"X-App-Rate-Limit-Count": []string{"1:1"},
+	}, "match-v4.getMatch")
+
+	var wg sync.WaitGroup
+	ctx, cancel := context.WithCancel(context.Background())
+	defer func() {
+		cancel()
+		wg.Wait()
+	}()
+
+	// The bulk requests wait first for the exhausted App limit, the interactive one of another method has to get the next slot nevertheless
+	served := make(chan string, 3)
+	for _, method := range []string{"match-v4.getMatch", "match-v4.getMatchlist"} {
+		wg.Add(1)
+		go func(method string) {
+			defer wg.Done()
+			if c.WaitPriority(ctx, method, 2) == nil {
+				served <- method
 			}
-		})
+		}(method)
+	}
+	time.Sleep(50 * time.Millisecond)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if c.WaitPriority(ctx, "summoner-v4.getBySummonerName", 0) == nil {
+			served <- "summoner-v4.getBySummonerName"
+		}
+	}()
+
+	select {
+	case method := <-served:
+		if method != "summoner-v4.getBySummonerName" {
+			t.Errorf("WaitPriority() served %s first, want the interactive summoner-v4.getBySummonerName", method)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("WaitPriority() did not serve any request")
+	}
+
+	// A higher priority request held back by its own Method limit does not block the others
+	c.UpdateRateLimits(http.Header{
+		"X-Method-Rate-Limit":       []string{"1:10"},
+		"X-Method-Rate-Limit-Count": []string{"1:10"},
+	}, "summoner-v4.getBySummonerName")
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if c.WaitPriority(ctx, "summoner-v4.getBySummonerName", 0) == nil {
+			served <- "summoner-v4.getBySummonerName"
+		}
+	}()
+	select {
+	case method := <-served:
+		if method == "summoner-v4.getBySummonerName" {
+			t.Errorf("WaitPriority() ignored the Method limit of summoner-v4.getBySummonerName")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatalf("WaitPriority() did not serve the bulk requests while the interactive one is held back by its Method limit")
+	}
+}
+
+func TestRiotClientRL_Windows(t *testing.T) {
+	clock := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		return clock
+	}
+
+	c, _ := New()
+	if len(c.AppWindows()) != 0 || len(c.MethodWindows()) != 0 {
+		t.Fatalf("Expected no windows before limits are known")
+	}
+
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":    []string{"1000:10,100:1"},
+		"X-Method-Rate-Limit": []string{"500:10"},
+	}, "match-v4.getMatchlist")
+	c.UpdateRateLimits(http.Header{
+		"X-Method-Rate-Limit": []string{"2000:60"},
+	}, "summoner-v4.getBySummonerName")
+
+	for i := 0; i < 3; i++ {
+		c.Wait("match-v4.getMatchlist")
+	}
+	c.Wait("summoner-v4.getBySummonerName")
+
+	want := []WindowStatus{
+		{Period: time.Second, Limit: 100, Count: 4},
+		{Period: 10 * time.Second, Limit: 1000, Count: 4},
+	}
+	if got := c.AppWindows(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AppWindows() = %v, want %v", got, want)
+	}
+
+	wantMethods := map[string][]WindowStatus{
+		"match-v4.getMatchlist":         {{Period: 10 * time.Second, Limit: 500, Count: 3}},
+		"summoner-v4.getBySummonerName": {{Period: 60 * time.Second, Limit: 2000, Count: 1}},
+	}
+	if got := c.MethodWindows(); !reflect.DeepEqual(got, wantMethods) {
+		t.Errorf("MethodWindows() = %v, want %v", got, wantMethods)
+	}
+
+	clock = clock.Add(time.Second)
+	if got := c.AppWindows(); got[0].Count != 0 || got[1].Count != 4 {
+		t.Errorf("AppWindows() after 1s = %v, expected the 1s window to be empty", got)
+	}
+}
+
+func TestRiotClientRL_Headroom(t *testing.T) {
+	clock := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	now = func() time.Time {
+		return clock
+	}
+
+	c, _ := New()
+	if wait, free := c.Headroom("summoners"); wait != 0 || free != math.MaxUint32 {
+		t.Errorf("Headroom() = %s, %d without known limits, want 0s, %d", wait, free, uint32(math.MaxUint32))
+	}
+
+	c.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":          []string{"20:1"},
+		"X-App-Rate-Limit-Count":    []string{"5:1"},
+		"X-Method-Rate-Limit":       []string{"10:10"},
+		"X-Method-Rate-Limit-Count": []string{"7:10"},
+	}, "summoners")
+	if wait, free := c.Headroom("summoners"); wait != 0 || free != 3 {
+		t.Errorf("Headroom() = %s, %d, want 0s, 3 limited by the Method limit", wait, free)
+	}
+	if wait, free := c.Headroom("champions"); wait != 0 || free != 15 {
+		t.Errorf("Headroom() = %s, %d, want 0s, 15 limited by the App limit", wait, free)
+	}
+
+	c.UpdateRateLimits(http.Header{
+		"X-Method-Rate-Limit-Count": []string{"10:10"},
+	}, "summoners")
+	if wait, free := c.Headroom("summoners"); wait != 10*time.Second || free != 10 {
+		t.Errorf("Headroom() = %s, %d, want 10s, 10 when the Method limit is used up", wait, free)
 	}
 }
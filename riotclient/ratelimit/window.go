@@ -0,0 +1,133 @@
+package riotclientrl
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// A window is a sliding window rate limit. It keeps the times of the requests done within its period.
+type window struct {
+	period time.Duration
+	limit  uint32
+	// requests holds the times of the requests within the period, oldest first
+	requests []time.Time
+}
+
+// windows holds the sliding windows of a set of rate limits, key = time period in seconds
+type windows map[uint32]*window
+
+// prune removes all requests which are outside of the window at time t
+func (w *window) prune(t time.Time) {
+	i := 0
+	for i < len(w.requests) && t.Sub(w.requests[i]) >= w.period {
+		i++
+	}
+	w.requests = w.requests[i:]
+}
+
+// nextSlot returns the earliest time not before t at which a new request fits into the window.
+// A limit of 0 blocks all requests until the window is reset, i.e., until its requests left it or for a whole period if it has none.
+func (w *window) nextSlot(t time.Time) time.Time {
+	w.prune(t)
+	if uint32(len(w.requests)) < w.limit {
+		return t
+	}
+	if w.limit == 0 {
+		if len(w.requests) == 0 {
+			return t.Add(w.period)
+		}
+		return w.requests[len(w.requests)-1].Add(w.period)
+	}
+	return w.requests[len(w.requests)-int(w.limit)].Add(w.period)
+}
+
+// free returns how many requests fit into the window at time t
+func (w *window) free(t time.Time) uint32 {
+	w.prune(t)
+	if n := uint32(len(w.requests)); n < w.limit {
+		return w.limit - n
+	}
+	return 0
+}
+
+// add records a request at time t
+func (w *window) add(t time.Time) {
+	w.requests = append(w.requests, t)
+}
+
+// sync makes sure the window holds at least count requests at time t. Riot counts all requests done with the same key,
+// so requests done by other clients sharing the key are added as if they happened at time t.
+func (w *window) sync(count uint32, t time.Time) {
+	w.prune(t)
+	for uint32(len(w.requests)) < count {
+		w.add(t)
+	}
+}
+
+// set updates the limit for the window with the given period, creating it if necessary
+func (ws windows) set(period uint32, calls uint32) {
+	if w, ok := ws[period]; ok {
+		w.limit = calls
+		return
+	}
+	ws[period] = &window{period: time.Second * time.Duration(period), limit: calls}
+}
+
+// sync makes sure the windows hold at least the given counts of requests at time t, key = time period in seconds.
+// Counts of unknown windows are ignored.
+func (ws windows) sync(counts map[uint32]uint32, t time.Time) {
+	for period, count := range counts {
+		if w, ok := ws[period]; ok {
+			w.sync(count, t)
+		}
+	}
+}
+
+// nextSlot returns the earliest time not before t at which a new request fits into all windows
+func (ws windows) nextSlot(t time.Time) time.Time {
+	next := t
+	for _, w := range ws {
+		if slot := w.nextSlot(t); slot.After(next) {
+			next = slot
+		}
+	}
+	return next
+}
+
+// free returns how many requests fit into all windows at time t, math.MaxUint32 if there are no windows
+func (ws windows) free(t time.Time) uint32 {
+	free := uint32(math.MaxUint32)
+	for _, w := range ws {
+		if f := w.free(t); f < free {
+			free = f
+		}
+	}
+	return free
+}
+
+// add records a request at time t in all windows
+func (ws windows) add(t time.Time) {
+	for _, w := range ws {
+		w.add(t)
+	}
+}
+
+// WindowStatus describes the current state of one rate limit window
+type WindowStatus struct {
+	Period time.Duration
+	Limit  uint32
+	// Count is the number of requests done within the period
+	Count uint32
+}
+
+// status returns the state of the windows at time t, sorted by period
+func (ws windows) status(t time.Time) []WindowStatus {
+	status := make([]WindowStatus, 0, len(ws))
+	for _, w := range ws {
+		w.prune(t)
+		status = append(status, WindowStatus{Period: w.period, Limit: w.limit, Count: uint32(len(w.requests))})
+	}
+	sort.Slice(status, func(i, j int) bool { return status[i].Period < status[j].Period })
+	return status
+}
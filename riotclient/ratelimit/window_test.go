@@ -0,0 +1,88 @@
+package riotclientrl
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_window_nextSlot(t *testing.T) {
+	start := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	w := &window{period: 10 * time.Second, limit: 3}
+
+	if got := w.nextSlot(start); !got.Equal(start) {
+		t.Errorf("nextSlot() for empty window = %v, want %v", got, start)
+	}
+
+	w.add(start)
+	w.add(start.Add(2 * time.Second))
+	w.add(start.Add(4 * time.Second))
+
+	if got, want := w.nextSlot(start.Add(5*time.Second)), start.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() for full window = %v, want %v", got, want)
+	}
+	if got, want := w.nextSlot(start.Add(10*time.Second)), start.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() after oldest request left the window = %v, want %v", got, want)
+	}
+	if len(w.requests) != 2 {
+		t.Errorf("Expected oldest request to be pruned, still have %d requests", len(w.requests))
+	}
+
+	// Requests of other clients sharing the key
+	w.sync(5, start.Add(10*time.Second))
+	if len(w.requests) != 5 {
+		t.Fatalf("Expected 5 requests after sync, got %d", len(w.requests))
+	}
+	if got, want := w.nextSlot(start.Add(10*time.Second)), start.Add(20*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() for overfull window = %v, want %v", got, want)
+	}
+
+	w.sync(1, start.Add(10*time.Second))
+	if len(w.requests) != 5 {
+		t.Errorf("sync must not remove requests, got %d", len(w.requests))
+	}
+}
+
+func Test_window_nextSlot_zeroLimit(t *testing.T) {
+	start := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	w := &window{period: 10 * time.Second, limit: 0}
+
+	if got, want := w.nextSlot(start), start.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() for empty window with limit 0 = %v, want %v", got, want)
+	}
+
+	w.add(start)
+	w.add(start.Add(4 * time.Second))
+	if got, want := w.nextSlot(start.Add(5*time.Second)), start.Add(14*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() for window with limit 0 = %v, want %v when the newest request leaves it", got, want)
+	}
+	if got := w.free(start.Add(5 * time.Second)); got != 0 {
+		t.Errorf("free() for window with limit 0 = %d, want 0", got)
+	}
+}
+
+func Test_windows(t *testing.T) {
+	start := time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	ws := make(windows)
+	ws.set(1, 2)
+	ws.set(10, 3)
+	ws.set(1, 1)
+
+	if ws[1].limit != 1 || ws[1].period != time.Second || ws[10].limit != 3 {
+		t.Fatalf("Windows not set correctly: %v %v", ws[1], ws[10])
+	}
+
+	ws.add(start)
+	if got, want := ws.nextSlot(start), start.Add(time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() = %v, want %v", got, want)
+	}
+	ws.add(start.Add(time.Second))
+	ws.add(start.Add(2 * time.Second))
+	if got, want := ws.nextSlot(start.Add(3*time.Second)), start.Add(10*time.Second); !got.Equal(want) {
+		t.Errorf("nextSlot() = %v, want %v", got, want)
+	}
+
+	var empty windows
+	if got := empty.nextSlot(start); !got.Equal(start) {
+		t.Errorf("nextSlot() for no windows = %v, want %v", got, start)
+	}
+}
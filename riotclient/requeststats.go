@@ -0,0 +1,28 @@
+package riotclient
+
+// RequestStats holds counters of the requests a Client sent to the Riot API since it was created
+type RequestStats struct {
+	// Requests is the number of requests sent, including retries
+	Requests uint64 `json:"requests"`
+	// Retries is the number of retried requests per kind of error, e.g., "Server Error"
+	Retries map[string]uint64 `json:"retries"`
+	// Failures is the number of requests which failed after all tries per kind of error
+	Failures map[string]uint64 `json:"failures"`
+	// APIKeys is the state of the API keys the requests are distributed over
+	APIKeys []APIKeyStatus `json:"apiKeys,omitempty"`
+}
+
+// APIKeyStatus describes the state of one Riot API key of a Client
+type APIKeyStatus struct {
+	// Key is the API key with all but the last four characters masked
+	Key string `json:"key"`
+	// Enabled is false if the key was rejected by the Riot API and is not used anymore
+	Enabled bool `json:"enabled"`
+	// Requests is the number of requests sent with the key
+	Requests uint64 `json:"requests"`
+}
+
+// ClientRequestStats is implemented by Clients which count their requests to the Riot API
+type ClientRequestStats interface {
+	RequestStats() RequestStats
+}
@@ -10,9 +10,17 @@ type ClientBase interface {
 // ClientChampion defines an interface to Champion API calls
 type ClientChampion interface {
 	Champions() (s ChampionsList, err error)
+	ChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) (*ChampionDetails, error)
 	ChampionRotations() (s *FreeRotation, err error)
 }
 
+// ClientChampionMastery defines an interface to Champion Mastery API calls
+type ClientChampionMastery interface {
+	ChampionMasteriesBySummonerID(encSummonerID string) (*ChampionMasteryDTOList, error)
+	ChampionMasteryBySummonerIDChampionID(encSummonerID string, championID string) (*ChampionMasteryDTO, error)
+	ChampionMasteryScoreBySummonerID(encSummonerID string) (int, error)
+}
+
 // ClientItem defines an interface to Item API calls
 type ClientItem interface {
 	Items() (*ItemList, error)
@@ -46,10 +54,29 @@ type ClientMatch interface {
 	MatchTimeLineByID(matchID uint64) (t *MatchTimelineDTO, err error)
 }
 
+// ClientMatchlistQueues is implemented by Clients which can tell whether a match list can be filtered by several queues
+// in one request, i.e., whether MatchesByAccountID accepts several comma-separated queue ids
+type ClientMatchlistQueues interface {
+	MultipleMatchlistQueues() bool
+}
+
+// ClientRegion is implemented by Clients which can tell the region they are configured for, i.e., the platform id
+// of the matches they fetch, e.g., euw1
+type ClientRegion interface {
+	Region() string
+}
+
+// ClientMatchlistPUUID is implemented by Clients which request match lists by PUUID. MatchesByPUUID takes the same
+// arguments as MatchesByAccountID, so that the Account ID does not have to be resolved to a PUUID by the Client.
+type ClientMatchlistPUUID interface {
+	MatchesByPUUID(PUUID string, args map[string]string) (s *MatchlistDTO, err error)
+}
+
 // ClientLeague defines an interface to League API calls
 type ClientLeague interface {
 	LeagueByQueue(league string, queue string) (*LeagueListDTO, error)
 	LeaguesForSummoner(encSummonerID string) (*LeaguePositionDTOList, error)
+	LeagueEntries(queue string, tier string, division string, page int) ([]LeagueEntryDTO, error)
 }
 
 // ClientSpectator defines an interface to Spectator API calls
@@ -62,6 +89,7 @@ type ClientSpectator interface {
 type Client interface {
 	ClientBase
 	ClientChampion
+	ClientChampionMastery
 	ClientItem
 	ClientLeague
 	ClientMatch
@@ -0,0 +1,123 @@
+package riotclientv4
+
+import (
+	"sync"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+
+	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+// An apiKey is one of the Riot API keys of a client. Riot tracks the Application Rate Limits per key,
+// so every key has its own rate limit state.
+type apiKey struct {
+	key       string
+	rateLimit *riotclientrl.RiotClientRL
+	disabled  bool
+	requests  uint64
+}
+
+// keyPool holds the API keys of a client and distributes the requests over them
+type keyPool struct {
+	mutex sync.Mutex
+	keys  []*apiKey
+}
+
+// newKeyPool returns a pool of the given keys, ignoring empty and duplicate ones.
+// The first key uses rateLimit, all others get a new one.
+func newKeyPool(keys []string, rateLimit *riotclientrl.RiotClientRL) *keyPool {
+	pool := &keyPool{}
+	seen := make(map[string]bool)
+	for _, key := range keys {
+		if len(key) == 0 || seen[key] {
+			continue
+		}
+		seen[key] = true
+
+		if len(pool.keys) > 0 {
+			rateLimit, _ = riotclientrl.New()
+		}
+		pool.keys = append(pool.keys, &apiKey{key: key, rateLimit: rateLimit})
+	}
+	return pool
+}
+
+// maskKey hides all but the last four characters of a key, so that it can be logged and reported
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+// selectKey returns the enabled key which can send a request for method the soonest and counts the request for it.
+// If several keys can send it at the same time, the one with the most free requests wins and then the one which
+// sent the least requests.
+func (p *keyPool) selectKey(method string) (*apiKey, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	var best *apiKey
+	var bestWait time.Duration
+	var bestFree uint32
+	for _, key := range p.keys {
+		if key.disabled {
+			continue
+		}
+
+		wait, free := key.rateLimit.Headroom(method)
+		if best == nil || wait < bestWait ||
+			(wait == bestWait && (free > bestFree || (free == bestFree && key.requests < best.requests))) {
+			best, bestWait, bestFree = key, wait, free
+		}
+	}
+
+	if best == nil {
+		return nil, riotclient.NewError(riotclient.ErrorForbidden, "All API keys are disabled")
+	}
+	best.requests++
+	return best, nil
+}
+
+// disable disables a key which was rejected by the Riot API. The last enabled key is never disabled, so that the
+// error reaches the caller. It returns the number of keys remaining enabled if the key was disabled, 0 if not.
+func (p *keyPool) disable(key *apiKey) int {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key.disabled {
+		return 0
+	}
+	enabled := 0
+	for _, k := range p.keys {
+		if k != key && !k.disabled {
+			enabled++
+		}
+	}
+	if enabled > 0 {
+		key.disabled = true
+	}
+	return enabled
+}
+
+// status returns the state of all keys with masked key values
+func (p *keyPool) status() []riotclient.APIKeyStatus {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	status := make([]riotclient.APIKeyStatus, 0, len(p.keys))
+	for _, key := range p.keys {
+		status = append(status, riotclient.APIKeyStatus{Key: maskKey(key.key), Enabled: !key.disabled, Requests: key.requests})
+	}
+	return status
+}
+
+// keyPool returns the API keys of the client. Clients which were not created by NewClient use Key and the rate limit
+// they were created with.
+func (c *RiotClientV4) keyPool() *keyPool {
+	if c.keys != nil {
+		return c.keys
+	}
+	return &keyPool{keys: []*apiKey{{key: c.config.Key, rateLimit: c.rateLimit}}}
+}
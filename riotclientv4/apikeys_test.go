@@ -0,0 +1,154 @@
+package riotclientv4
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+// keyHTTPClient responds with the status code given for the API key of the request, 200 for unknown keys,
+// and records the keys used
+type keyHTTPClient struct {
+	statusCodes map[string]int
+	header      http.Header
+	keys        []string
+}
+
+func (c *keyHTTPClient) Get(url string) (resp *http.Response, err error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *keyHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	key := req.Header.Get("X-Riot-Token")
+	c.keys = append(c.keys, key)
+	statusCode, ok := c.statusCodes[key]
+	if !ok {
+		statusCode = http.StatusOK
+	}
+	return &http.Response{StatusCode: statusCode, Header: c.header, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func newKeyTestClient(t *testing.T, httpClient httpClient, key string, keys ...string) *RiotClientV4 {
+	rateLimit, _ := riotclientrl.New()
+	c, err := NewClient(httpClient, config.RiotClient{APIVersion: "v4", Region: "euw1", Key: key, Keys: keys}, nil, rateLimit)
+	if err != nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	c.requestStats = newRequestStats()
+	return c
+}
+
+func doRequests(c *RiotClientV4, n int) error {
+	for i := 0; i < n; i++ {
+		req, _ := http.NewRequest("GET", "https://euw1.api.riotgames.com/lol/summoner/v4/summoners/by-name/abc", nil)
+		work := workOrder{request: req, responseChan: make(workResponseChan, 1), method: "summoner/v4/summoners/by-name"}
+		c.process(work)
+		if res := <-work.responseChan; res.err != nil {
+			return res.err
+		}
+	}
+	return nil
+}
+
+func Test_newKeyPool(t *testing.T) {
+	rateLimit, _ := riotclientrl.New()
+	pool := newKeyPool([]string{"", "key-1", "key-2", "key-1"}, rateLimit)
+	if len(pool.keys) != 2 || pool.keys[0].key != "key-1" || pool.keys[1].key != "key-2" {
+		t.Fatalf("newKeyPool() created keys %+v, want key-1 and key-2", pool.keys)
+	}
+	if pool.keys[0].rateLimit != rateLimit || pool.keys[1].rateLimit == rateLimit || pool.keys[1].rateLimit == nil {
+		t.Errorf("newKeyPool() did not give every key its own rate limit")
+	}
+}
+
+func Test_maskKey(t *testing.T) {
+	if got := maskKey("RGAPI-12345678-abcd"); got != "****abcd" {
+		t.Errorf("maskKey() = %s, want ****abcd", got)
+	}
+	if got := maskKey("abc"); got != "****" {
+		t.Errorf("maskKey() = %s, want ****", got)
+	}
+}
+
+func TestRiotClientV4_distributesRequestsOverKeys(t *testing.T) {
+	httpClient := &keyHTTPClient{header: http.Header{
+		"X-App-Rate-Limit":       []string{"100:1"},
+		"X-App-Rate-Limit-Count": []string{"1:1"},
+	}}
+	c := newKeyTestClient(t, httpClient, "key-1", "key-2", "key-3")
+
+	if err := doRequests(c, 6); err != nil {
+		t.Fatalf("Request failed: %s", err)
+	}
+
+	want := []riotclient.APIKeyStatus{
+		{Key: "****ey-1", Enabled: true, Requests: 2},
+		{Key: "****ey-2", Enabled: true, Requests: 2},
+		{Key: "****ey-3", Enabled: true, Requests: 2},
+	}
+	if got := c.RequestStats().APIKeys; !reflect.DeepEqual(got, want) {
+		t.Errorf("RequestStats().APIKeys = %+v, want %+v", got, want)
+	}
+}
+
+func TestRiotClientV4_prefersKeyWithHeadroom(t *testing.T) {
+	httpClient := &keyHTTPClient{}
+	c := newKeyTestClient(t, httpClient, "key-1", "key-2")
+	c.keys.keys[0].rateLimit.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":       []string{"10:1"},
+		"X-App-Rate-Limit-Count": []string{"9:1"},
+	}, "")
+	c.keys.keys[1].rateLimit.UpdateRateLimits(http.Header{
+		"X-App-Rate-Limit":       []string{"10:1"},
+		"X-App-Rate-Limit-Count": []string{"2:1"},
+	}, "")
+
+	if err := doRequests(c, 3); err != nil {
+		t.Fatalf("Request failed: %s", err)
+	}
+	if want := []string{"key-2", "key-2", "key-2"}; !reflect.DeepEqual(httpClient.keys, want) {
+		t.Errorf("Requests were sent with keys %v, want %v", httpClient.keys, want)
+	}
+}
+
+func TestRiotClientV4_disablesRejectedKeys(t *testing.T) {
+	httpClient := &keyHTTPClient{statusCodes: map[string]int{"key-1": http.StatusForbidden, "key-2": http.StatusUnauthorized}}
+	c := newKeyTestClient(t, httpClient, "key-1", "key-2", "key-3")
+
+	if err := doRequests(c, 3); err != nil {
+		t.Fatalf("Request failed even though key-3 is valid: %s", err)
+	}
+	if want := []string{"key-1", "key-2", "key-3", "key-3", "key-3"}; !reflect.DeepEqual(httpClient.keys, want) {
+		t.Errorf("Requests were sent with keys %v, want %v", httpClient.keys, want)
+	}
+
+	stats := c.RequestStats()
+	wantKeys := []riotclient.APIKeyStatus{
+		{Key: "****ey-1", Enabled: false, Requests: 1},
+		{Key: "****ey-2", Enabled: false, Requests: 1},
+		{Key: "****ey-3", Enabled: true, Requests: 3},
+	}
+	if !reflect.DeepEqual(stats.APIKeys, wantKeys) {
+		t.Errorf("RequestStats().APIKeys = %+v, want %+v", stats.APIKeys, wantKeys)
+	}
+	if stats.Retries["Forbidden"] != 2 {
+		t.Errorf("RequestStats().Retries = %v, want 2 Forbidden retries", stats.Retries)
+	}
+
+	// The last enabled key is kept, the error is returned to the caller
+	httpClient.statusCodes["key-3"] = http.StatusForbidden
+	err := doRequests(c, 1)
+	if riotclient.KindOf(err) != riotclient.ErrorForbidden {
+		t.Errorf("Request returned error %v, want a Forbidden error", err)
+	}
+	if keys := c.RequestStats().APIKeys; !keys[2].Enabled {
+		t.Errorf("The last enabled key was disabled")
+	}
+}
@@ -1 +1,61 @@
 package riotclientv4
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// ChampionMasteriesBySummonerID returns the Champion Masteries of a Summoner for all Champions
+func (c *RiotClientV4) ChampionMasteriesBySummonerID(encSummonerID string) (*riotclient.ChampionMasteryDTOList, error) {
+	// /lol/champion-mastery/v4/champion-masteries/by-summoner/{encryptedSummonerId}
+	data, err := apiCall(c, c.platformURL()+"/lol/champion-mastery/"+c.config.APIVersion+"/champion-masteries/by-summoner/"+encSummonerID, "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	masteries := []riotclient.ChampionMasteryDTO{}
+	err = json.Unmarshal(data, &masteries)
+	if err != nil {
+		return nil, err
+	}
+
+	return &riotclient.ChampionMasteryDTOList{ChampionMasteries: masteries, Timestamp: now()}, nil
+}
+
+// ChampionMasteryBySummonerIDChampionID returns the Champion Mastery of a Summoner for a specific Champion
+func (c *RiotClientV4) ChampionMasteryBySummonerIDChampionID(encSummonerID string, championID string) (*riotclient.ChampionMasteryDTO, error) {
+	// /lol/champion-mastery/v4/champion-masteries/by-summoner/{encryptedSummonerId}/by-champion/{championId}
+	data, err := apiCall(c, c.platformURL()+"/lol/champion-mastery/"+c.config.APIVersion+"/champion-masteries/by-summoner/"+encSummonerID+"/by-champion/"+championID, "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	mastery := riotclient.ChampionMasteryDTO{}
+	err = json.Unmarshal(data, &mastery)
+	if err != nil {
+		return nil, err
+	} else if mastery.ChampionID == 0 {
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "Champion Mastery does not exist")
+	}
+
+	return &mastery, nil
+}
+
+// ChampionMasteryScoreBySummonerID returns the total Champion Mastery score of a Summoner, which is the sum of all Champion Mastery levels
+func (c *RiotClientV4) ChampionMasteryScoreBySummonerID(encSummonerID string) (int, error) {
+	// /lol/champion-mastery/v4/scores/by-summoner/{encryptedSummonerId}
+	data, err := apiCall(c, c.platformURL()+"/lol/champion-mastery/"+c.config.APIVersion+"/scores/by-summoner/"+encSummonerID, "GET", "")
+	if err != nil {
+		return 0, err
+	}
+
+	score, err := strconv.Atoi(string(data))
+	if err != nil {
+		return 0, fmt.Errorf("Error parsing Champion Mastery score: %s", err)
+	}
+
+	return score, nil
+}
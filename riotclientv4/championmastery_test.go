@@ -0,0 +1,167 @@
+package riotclientv4
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func TestRiotClientV4_ChampionMasteriesBySummonerID(t *testing.T) {
+	// Override real API call with our fake one
+	apiCall = (*RiotClientV4).mockAPICall
+
+	// Inject a new time.Now()
+	now = func() time.Time {
+		layout := "2006-01-02T15:04:05.000Z"
+		str := "2018-12-22T13:00:00.000Z"
+		t, _ := time.Parse(layout, str)
+		return t
+	}
+
+	c := &RiotClientV4{
+		config: config.RiotClient{
+			APIVersion: "v4",
+			Region:     "euw1",
+		},
+		log: logging.Get("RiotClientV4"),
+	}
+
+	tests := []struct {
+		name            string
+		want            *riotclient.ChampionMasteryDTOList
+		wantErr         bool
+		setJSON         []byte
+		setError        error
+		wantAPICallPath string
+	}{
+		{
+			name: "Test 1 - Receive valid Champion Masteries JSON",
+			want: &riotclient.ChampionMasteryDTOList{
+				ChampionMasteries: []riotclient.ChampionMasteryDTO{
+					{
+						ChampionID:                   12,
+						ChampionLevel:                7,
+						ChampionPoints:               254193,
+						ChampionPointsSinceLastLevel: 232593,
+						ChestGranted:                 true,
+						LastPlayTime:                 1545478800000,
+						SummonerID:                   "sid",
+					},
+					{
+						ChampionID:                   42,
+						ChampionLevel:                2,
+						ChampionPoints:               2312,
+						ChampionPointsSinceLastLevel: 512,
+						ChampionPointsUntilNextLevel: 3688,
+						LastPlayTime:                 1545378800000,
+						TokensEarned:                 0,
+						SummonerID:                   "sid",
+					},
+				},
+				Timestamp: now(),
+			},
+			setJSON:         []byte(`[{"championId":12,"championLevel":7,"championPoints":254193,"championPointsSinceLastLevel":232593,"championPointsUntilNextLevel":0,"chestGranted":true,"lastPlayTime":1545478800000,"tokensEarned":0,"summonerId":"sid"},{"championId":42,"championLevel":2,"championPoints":2312,"championPointsSinceLastLevel":512,"championPointsUntilNextLevel":3688,"chestGranted":false,"lastPlayTime":1545378800000,"tokensEarned":0,"summonerId":"sid"}]`),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-summoner/sid",
+		},
+		{
+			name:            "Test 2 - Receive invalid JSON",
+			wantErr:         true,
+			setJSON:         []byte(`{"championId":12`),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-summoner/sid",
+		},
+		{
+			name:            "Test 3 - API Call returns error",
+			wantErr:         true,
+			setError:        fmt.Errorf("Some error"),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-summoner/sid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiCallReturnJSON = tt.setJSON
+			apiCallReturnErr = tt.setError
+
+			got, err := c.ChampionMasteriesBySummonerID("sid")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RiotClientV4.ChampionMasteriesBySummonerID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RiotClientV4.ChampionMasteriesBySummonerID() = %v, want %v", got, tt.want)
+			}
+			if lastAPICallPath != tt.wantAPICallPath {
+				t.Errorf("lastAPICallPath = %v, want %v", lastAPICallPath, tt.wantAPICallPath)
+			}
+		})
+	}
+}
+
+func TestRiotClientV4_ChampionMasteryBySummonerIDChampionID(t *testing.T) {
+	// Override real API call with our fake one
+	apiCall = (*RiotClientV4).mockAPICall
+
+	c := &RiotClientV4{
+		config: config.RiotClient{
+			APIVersion: "v4",
+			Region:     "euw1",
+		},
+		log: logging.Get("RiotClientV4"),
+	}
+
+	apiCallReturnErr = nil
+	apiCallReturnJSON = []byte(`{"championId":12,"championLevel":7,"championPoints":254193,"summonerId":"sid"}`)
+	got, err := c.ChampionMasteryBySummonerIDChampionID("sid", "12")
+	if err != nil {
+		t.Fatalf("RiotClientV4.ChampionMasteryBySummonerIDChampionID() error = %v", err)
+	}
+	want := &riotclient.ChampionMasteryDTO{ChampionID: 12, ChampionLevel: 7, ChampionPoints: 254193, SummonerID: "sid"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("RiotClientV4.ChampionMasteryBySummonerIDChampionID() = %v, want %v", got, want)
+	}
+	if lastAPICallPath != "https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-summoner/sid/by-champion/12" {
+		t.Errorf("Wrong API call path %s", lastAPICallPath)
+	}
+
+	apiCallReturnJSON = []byte(`{}`)
+	if _, err := c.ChampionMasteryBySummonerIDChampionID("sid", "13"); err == nil {
+		t.Errorf("Expected error for empty Champion Mastery")
+	}
+}
+
+func TestRiotClientV4_ChampionMasteryScoreBySummonerID(t *testing.T) {
+	// Override real API call with our fake one
+	apiCall = (*RiotClientV4).mockAPICall
+
+	c := &RiotClientV4{
+		config: config.RiotClient{
+			APIVersion: "v4",
+			Region:     "euw1",
+		},
+		log: logging.Get("RiotClientV4"),
+	}
+
+	apiCallReturnErr = nil
+	apiCallReturnJSON = []byte(`231`)
+	got, err := c.ChampionMasteryScoreBySummonerID("sid")
+	if err != nil || got != 231 {
+		t.Errorf("RiotClientV4.ChampionMasteryScoreBySummonerID() = %d, %v, want 231", got, err)
+	}
+	if lastAPICallPath != "https://euw1.api.riotgames.com/lol/champion-mastery/v4/scores/by-summoner/sid" {
+		t.Errorf("Wrong API call path %s", lastAPICallPath)
+	}
+
+	apiCallReturnJSON = []byte(`"abc"`)
+	if _, err := c.ChampionMasteryScoreBySummonerID("sid"); err == nil {
+		t.Errorf("Expected error for invalid score")
+	}
+
+	apiCallReturnErr = fmt.Errorf("Some error")
+	if _, err := c.ChampionMasteryScoreBySummonerID("sid"); err == nil {
+		t.Errorf("Expected error when API call fails")
+	}
+}
@@ -22,8 +22,13 @@ func (c *RiotClientV4) Champions() (s riotclient.ChampionsList, err error) {
 		return nil, fmt.Errorf("Error getting champions from Data Dragon: %s", err)
 	}
 
+	return ParseChampions(championsData)
+}
+
+// ParseChampions parses the champion.json file of Data Dragon
+func ParseChampions(data []byte) (riotclient.ChampionsList, error) {
 	championsDat := championData{}
-	err = json.Unmarshal(championsData, &championsDat)
+	err := json.Unmarshal(data, &championsDat)
 	if err != nil {
 		return nil, err
 	}
@@ -39,3 +44,46 @@ func (c *RiotClientV4) Champions() (s riotclient.ChampionsList, err error) {
 
 	return champions, nil
 }
+
+// Used for parsing the champion/<ID>.json files coming from data dragon
+type championDetailsData struct {
+	Type    string                                `json:"type"`
+	Format  string                                `json:"format"`
+	Version string                                `json:"version"`
+	Data    map[string]riotclient.ChampionDetails `json:"data"`
+}
+
+// ChampionDetailsSpecificVersionLanguage gets the full data of the champion with the given ID, e.g., Annie,
+// for a specific gameVersion and language from Data Dragon
+func (c *RiotClientV4) ChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	data, err := c.ddragon.GetDataDragonChampionDetailsSpecificVersionLanguage(championID, gameVersion, language)
+	if err != nil {
+		return nil, fmt.Errorf("Error getting Champion %s from Data Dragon: %s", championID, err)
+	}
+
+	return ParseChampionDetails(data)
+}
+
+// ParseChampionDetails parses a champion/<ID>.json file of Data Dragon
+func ParseChampionDetails(data []byte) (*riotclient.ChampionDetails, error) {
+	championDat := championDetailsData{}
+	err := json.Unmarshal(data, &championDat)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(championDat.Data) != 1 {
+		return nil, fmt.Errorf("Expected data of one champion, got %d", len(championDat.Data))
+	}
+
+	var champion riotclient.ChampionDetails
+	for _, details := range championDat.Data {
+		champion = details
+	}
+	champion.Timestamp = now()
+	if len(champion.Version) == 0 {
+		champion.Version = championDat.Version
+	}
+
+	return &champion, nil
+}
@@ -200,3 +200,70 @@ func TestRiotClientV4_Champions(t *testing.T) {
 		})
 	}
 }
+
+func TestRiotClientV4_ChampionDetailsSpecificVersionLanguage(t *testing.T) {
+	now = func() time.Time {
+		return time.Date(2018, 12, 22, 13, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name    string
+		ddragon *MockRiotClientDD
+		want    *riotclient.ChampionDetails
+		wantErr bool
+	}{
+		{
+			name: "Test 1 - Receive valid Champion JSON",
+			ddragon: &MockRiotClientDD{
+				championJSON: []byte(`{"type":"champion","format":"standAloneComplex","version":"9.10.1","data":{"Annie":{"id":"Annie","key":"1","name":"Annie","title":"the Dark Child","lore":"Dangerous","allytips":["Use Pyromania"],"enemytips":["Beware Tibbers"],"skins":[{"id":"1000","num":0,"name":"default","chromas":false}],"spells":[{"id":"Disintegrate","name":"Disintegrate","leveltip":{"label":["Damage"],"effect":["{{ e1 }} -> {{ e1NL }}"]},"maxrank":5,"cooldown":[4,4,4,4,4],"cooldownBurn":"4","cost":[60,65,70,75,80],"costBurn":"60/65/70/75/80","range":[625,625,625,625,625],"rangeBurn":"625"}],"passive":{"name":"Pyromania","description":"Stuns","image":{"full":"Annie_Passive.png"}}}}}`),
+			},
+			want: &riotclient.ChampionDetails{
+				Champion:  riotclient.Champion{Version: "9.10.1", ID: "Annie", Key: "1", Name: "Annie", Title: "the Dark Child", Timestamp: now()},
+				Lore:      "Dangerous",
+				AllyTips:  []string{"Use Pyromania"},
+				EnemyTips: []string{"Beware Tibbers"},
+				Skins:     []riotclient.ChampionSkin{{ID: "1000", Num: 0, Name: "default"}},
+				Spells: []riotclient.ChampionSpell{{
+					ID:           "Disintegrate",
+					Name:         "Disintegrate",
+					Leveltip:     riotclient.ChampionSpellLevel{Label: []string{"Damage"}, Effect: []string{"{{ e1 }} -> {{ e1NL }}"}},
+					Maxrank:      5,
+					Cooldown:     []float64{4, 4, 4, 4, 4},
+					CooldownBurn: "4",
+					Cost:         []float64{60, 65, 70, 75, 80},
+					CostBurn:     "60/65/70/75/80",
+					Range:        []float64{625, 625, 625, 625, 625},
+					RangeBurn:    "625",
+				}},
+				Passive: riotclient.ChampionPassive{Name: "Pyromania", Description: "Stuns", Image: riotclient.ChampionImage{Full: "Annie_Passive.png"}},
+			},
+		},
+		{
+			name:    "Test 2 - Receive invalid Champion JSON",
+			ddragon: &MockRiotClientDD{championJSON: []byte(`{"data":{}}`)},
+			wantErr: true,
+		},
+		{
+			name:    "Test 3 - Data Dragon returns error",
+			ddragon: &MockRiotClientDD{err: fmt.Errorf("Some error")},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &RiotClientV4{
+				config:  config.RiotClient{APIVersion: "v4", Region: "euw1"},
+				log:     logging.Get("RiotClientV4"),
+				ddragon: tt.ddragon,
+			}
+			got, err := c.ChampionDetailsSpecificVersionLanguage("Annie", "9.10.1", "en_US")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RiotClientV4.ChampionDetailsSpecificVersionLanguage() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RiotClientV4.ChampionDetailsSpecificVersionLanguage() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
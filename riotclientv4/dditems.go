@@ -30,7 +30,7 @@ func (c *RiotClientV4) Items() (*riotclient.ItemList, error) {
 		return nil, fmt.Errorf("Error getting Items from Data Dragon: %s", err)
 	}
 
-	return c.parseItems(data)
+	return ParseItems(data)
 }
 
 // ItemsSpecificVersionLanguage gets all items for a specific gameVersion and language from Data Dragon
@@ -40,10 +40,11 @@ func (c *RiotClientV4) ItemsSpecificVersionLanguage(gameVersion, language string
 		return nil, fmt.Errorf("Error getting Items from Data Dragon: %s", err)
 	}
 
-	return c.parseItems(data)
+	return ParseItems(data)
 }
 
-func (c *RiotClientV4) parseItems(data []byte) (*riotclient.ItemList, error) {
+// ParseItems parses the item.json file of Data Dragon
+func ParseItems(data []byte) (*riotclient.ItemList, error) {
 	itemsDat := itemData{}
 	err := json.Unmarshal(data, &itemsDat)
 	if err != nil {
@@ -14,7 +14,7 @@ func (c *RiotClientV4) RunesReforged() (*riotclient.RunesReforgedList, error) {
 		return nil, fmt.Errorf("Error getting RunesReforged from Data Dragon: %s", err)
 	}
 
-	return c.parseRunesReforged(data)
+	return ParseRunesReforged(data)
 }
 
 // RunesReforgedSpecificVersionLanguage gets all items for a specific gameVersion and language from Data Dragon
@@ -24,10 +24,11 @@ func (c *RiotClientV4) RunesReforgedSpecificVersionLanguage(gameVersion, languag
 		return nil, fmt.Errorf("Error getting RunesReforged from Data Dragon: %s", err)
 	}
 
-	return c.parseRunesReforged(data)
+	return ParseRunesReforged(data)
 }
 
-func (c *RiotClientV4) parseRunesReforged(data []byte) (*riotclient.RunesReforgedList, error) {
+// ParseRunesReforged parses the runesReforged.json file of Data Dragon
+func ParseRunesReforged(data []byte) (*riotclient.RunesReforgedList, error) {
 	runesReforged := riotclient.RunesReforgedList{}
 	err := json.Unmarshal(data, &runesReforged)
 	if err != nil {
@@ -21,7 +21,7 @@ func (c *RiotClientV4) SummonerSpells() (*riotclient.SummonerSpellsList, error)
 		return nil, fmt.Errorf("Error getting SummonerSpells from Data Dragon: %s", err)
 	}
 
-	return c.parseSummonerSpells(data)
+	return ParseSummonerSpells(data)
 }
 
 // SummonerSpellsSpecificVersionLanguage gets all items for a specific gameVersion and language from Data Dragon
@@ -31,10 +31,11 @@ func (c *RiotClientV4) SummonerSpellsSpecificVersionLanguage(gameVersion, langua
 		return nil, fmt.Errorf("Error getting SummonerSpells for gameversion %s and language %s from Data Dragon: %s", gameVersion, language, err)
 	}
 
-	return c.parseSummonerSpells(data)
+	return ParseSummonerSpells(data)
 }
 
-func (c *RiotClientV4) parseSummonerSpells(data []byte) (*riotclient.SummonerSpellsList, error) {
+// ParseSummonerSpells parses the summoner.json file of Data Dragon
+func ParseSummonerSpells(data []byte) (*riotclient.SummonerSpellsList, error) {
 	summonerSpellsDat := summonerSpellsData{}
 	err := json.Unmarshal(data, &summonerSpellsDat)
 	if err != nil {
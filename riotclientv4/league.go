@@ -3,6 +3,8 @@ package riotclientv4
 import (
 	"encoding/json"
 	"fmt"
+	"strconv"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 )
@@ -10,9 +12,9 @@ import (
 func (c *RiotClientV4) leagueByQueue(leagueEndPoint string, queue string) (*riotclient.LeagueListDTO, error) {
 	// https://euw1.api.riotgames.com/lol/league/v4/[leagueEndPoint]/by-queue/[QUEUE]
 	if queue == "RANKED_SOLO_5x5" || queue == "RANKED_FLEX_SR" || queue == "RANKED_FLEX_TT" {
-		data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/league/"+c.config.APIVersion+"/"+leagueEndPoint+"/by-queue/"+queue, "GET", "")
+		data, err := apiCall(c, c.platformURL()+"/lol/league/"+c.config.APIVersion+"/"+leagueEndPoint+"/by-queue/"+queue, "GET", "")
 		if err != nil {
-			return nil, fmt.Errorf("Error in API call: %s", err)
+			return nil, err
 		}
 
 		league := riotclient.LeagueListDTO{}
@@ -42,9 +44,9 @@ func (c *RiotClientV4) LeagueByQueue(league string, queue string) (*riotclient.L
 // LeaguesForSummoner returns all Leagues a Summoner is ranked in
 func (c *RiotClientV4) LeaguesForSummoner(encSummonerID string) (*riotclient.LeaguePositionDTOList, error) {
 	// /lol/league/v4/positions/by-summoner/{encryptedSummonerId}
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/league/"+c.config.APIVersion+"/positions/by-summoner/"+encSummonerID, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/league/"+c.config.APIVersion+"/positions/by-summoner/"+encSummonerID, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	leaguePositions := []riotclient.LeaguePositionDTO{}
@@ -60,3 +62,47 @@ func (c *RiotClientV4) LeaguesForSummoner(encSummonerID string) (*riotclient.Lea
 
 	return &riotclient.LeaguePositionDTOList{LeaguePosition: leaguePositions}, nil
 }
+
+// leagueEntriesTiers are the tiers which can be requested from the League-EXP API
+var leagueEntriesTiers = map[string]bool{
+	"IRON": true, "BRONZE": true, "SILVER": true, "GOLD": true, "PLATINUM": true, "DIAMOND": true,
+	"MASTER": true, "GRANDMASTER": true, "CHALLENGER": true,
+}
+
+// leagueEntriesDivisions are the divisions which can be requested from the League-EXP API
+var leagueEntriesDivisions = map[string]bool{"I": true, "II": true, "III": true, "IV": true}
+
+// LeagueEntries returns one page of the entries of a tier and division in a queue, starting with page 1.
+// An empty result means that there are no more pages.
+// Allowed values for queue are "RANKED_SOLO_5x5", "RANKED_FLEX_SR", "RANKED_FLEX_TT",
+// for tier "IRON" to "CHALLENGER" and for division "I" to "IV"
+func (c *RiotClientV4) LeagueEntries(queue string, tier string, division string, page int) ([]riotclient.LeagueEntryDTO, error) {
+	// /lol/league-exp/v4/entries/{queue}/{tier}/{division}?page={page}
+	if queue != "RANKED_SOLO_5x5" && queue != "RANKED_FLEX_SR" && queue != "RANKED_FLEX_TT" {
+		return nil, fmt.Errorf("Invalid queue type %s, allowed are RANKED_SOLO_5x5, RANKED_FLEX_SR or RANKED_FLEX_TT", queue)
+	}
+	tier = strings.ToUpper(tier)
+	if !leagueEntriesTiers[tier] {
+		return nil, fmt.Errorf("Invalid tier %s", tier)
+	}
+	division = strings.ToUpper(division)
+	if !leagueEntriesDivisions[division] {
+		return nil, fmt.Errorf("Invalid division %s, allowed are I, II, III or IV", division)
+	}
+	if page < 1 {
+		return nil, fmt.Errorf("Invalid page %d, pages start with 1", page)
+	}
+
+	data, err := apiCall(c, c.platformURL()+"/lol/league-exp/"+c.config.APIVersion+"/entries/"+queue+"/"+tier+"/"+division+"?page="+strconv.Itoa(page), "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []riotclient.LeagueEntryDTO{}
+	err = json.Unmarshal(data, &entries)
+	if err != nil {
+		return nil, fmt.Errorf("%s. Data was: %s", err, data)
+	}
+
+	return entries, nil
+}
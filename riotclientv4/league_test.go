@@ -305,3 +305,106 @@ func TestRiotClientV4_LeaguesForSummoner(t *testing.T) {
 		})
 	}
 }
+
+func TestRiotClientV4_LeagueEntries(t *testing.T) {
+	// Override real API call with our fake one
+	apiCall = (*RiotClientV4).mockAPICall
+
+	c := &RiotClientV4{
+		config: config.RiotClient{
+			APIVersion: "v4",
+			Region:     "euw1",
+		},
+		log: logging.Get("RiotClientV4"),
+	}
+
+	type args struct {
+		queue    string
+		tier     string
+		division string
+		page     int
+	}
+	tests := []struct {
+		name            string
+		args            args
+		want            []riotclient.LeagueEntryDTO
+		wantErr         bool
+		setJSON         []byte
+		setError        error
+		wantAPICallPath string
+	}{
+		{
+			name: "Test 1 - Valid request - Receive valid League Entries JSON",
+			args: args{queue: "RANKED_SOLO_5x5", tier: "gold", division: "ii", page: 3},
+			want: []riotclient.LeagueEntryDTO{
+				{
+					LeagueID:     "1c4d9aa0-2d4d-11e9-a3c5-c81f66db96d8",
+					SummonerID:   "sid1",
+					SummonerName: "Summoner1",
+					QueueType:    "RANKED_SOLO_5x5",
+					Tier:         "GOLD",
+					Rank:         "II",
+					LeaguePoints: 42,
+					Wins:         12,
+					Losses:       10,
+					Inactive:     true,
+				},
+			},
+			setJSON:         []byte(`[{"leagueId":"1c4d9aa0-2d4d-11e9-a3c5-c81f66db96d8","summonerId":"sid1","summonerName":"Summoner1","queueType":"RANKED_SOLO_5x5","tier":"GOLD","rank":"II","leaguePoints":42,"wins":12,"losses":10,"inactive":true}]`),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/league-exp/v4/entries/RANKED_SOLO_5x5/GOLD/II?page=3",
+		},
+		{
+			name:            "Test 2 - Valid request - Empty page",
+			args:            args{queue: "RANKED_FLEX_SR", tier: "IRON", division: "IV", page: 1000},
+			want:            []riotclient.LeagueEntryDTO{},
+			setJSON:         []byte(`[]`),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/league-exp/v4/entries/RANKED_FLEX_SR/IRON/IV?page=1000",
+		},
+		{
+			name:    "Test 3 - Invalid queue specified",
+			args:    args{queue: "RANKED_BLA_BLA", tier: "GOLD", division: "I", page: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Test 4 - Invalid tier specified",
+			args:    args{queue: "RANKED_SOLO_5x5", tier: "WOOD", division: "I", page: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Test 5 - Invalid division specified",
+			args:    args{queue: "RANKED_SOLO_5x5", tier: "GOLD", division: "V", page: 1},
+			wantErr: true,
+		},
+		{
+			name:    "Test 6 - Invalid page specified",
+			args:    args{queue: "RANKED_SOLO_5x5", tier: "GOLD", division: "I", page: 0},
+			wantErr: true,
+		},
+		{
+			name:            "Test 7 - API Call returns error",
+			args:            args{queue: "RANKED_SOLO_5x5", tier: "GOLD", division: "I", page: 1},
+			wantErr:         true,
+			setError:        fmt.Errorf("Some error"),
+			wantAPICallPath: "https://euw1.api.riotgames.com/lol/league-exp/v4/entries/RANKED_SOLO_5x5/GOLD/I?page=1",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			apiCallReturnJSON = tt.setJSON
+			apiCallReturnErr = tt.setError
+			lastAPICallPath = ""
+
+			got, err := c.LeagueEntries(tt.args.queue, tt.args.tier, tt.args.division, tt.args.page)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("RiotClientV4.LeagueEntries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("RiotClientV4.LeagueEntries() = %v, want %v", got, tt.want)
+			}
+			if lastAPICallPath != tt.wantAPICallPath {
+				t.Errorf("lastAPICallPath = %v, want %v", lastAPICallPath, tt.wantAPICallPath)
+			}
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"sort"
 	"strconv"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 )
@@ -13,9 +14,9 @@ import (
 func (c *RiotClientV4) MatchByID(id uint64) (s *riotclient.MatchDTO, err error) {
 	// Example: https://euw1.api.riotgames.com/lol/match/v4/matches/3827449823
 	idStr := strconv.FormatUint(id, 10)
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/match/"+c.config.APIVersion+"/matches/"+idStr, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/match/"+c.config.APIVersion+"/matches/"+idStr, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	match := riotclient.MatchDTO{}
@@ -29,12 +30,17 @@ func (c *RiotClientV4) MatchByID(id uint64) (s *riotclient.MatchDTO, err error)
 	return &match, nil
 }
 
+// MultipleMatchlistQueues returns true, as match lists of API v4 can be filtered by several queues in one request
+func (c *RiotClientV4) MultipleMatchlistQueues() bool {
+	return true
+}
+
 // MatchesByAccountID gets a match by AccountID
-// args: List of arguments to the query. They are directly passed to the request.
+// args: List of arguments to the query. They are directly passed to the request, several values separated by commas are passed as repeated arguments.
 // Refer to https://developer.riotgames.com/api-methods/#match-v4/GET_getMatchlist for details.
 func (c *RiotClientV4) MatchesByAccountID(accountID string, args map[string]string) (s *riotclient.MatchlistDTO, err error) {
 	// Example: https://euw1.api.riotgames.com/lol/match/v4/matchlists/by-account/1boL9yr2g5kZbPExCP4I6ngN2NIQxe-gi6FWIC8_Di7D4g?endIndex=100&beginIndex=0
-	basicAPICall := "https://" + c.config.Region + ".api.riotgames.com/lol/match/" + c.config.APIVersion + "/matchlists/by-account/" + accountID
+	basicAPICall := c.platformURL() + "/lol/match/" + c.config.APIVersion + "/matchlists/by-account/" + accountID
 	fullAPICall := basicAPICall
 	if len(args) > 0 {
 		fullAPICall = fullAPICall + "?"
@@ -46,7 +52,10 @@ func (c *RiotClientV4) MatchesByAccountID(accountID string, args map[string]stri
 		sort.Strings(keys)
 
 		for _, k := range keys {
-			fullAPICall = fullAPICall + k + "=" + args[k] + "&"
+			// Arguments with several values, e.g., season=12,13, are repeated for every value
+			for _, v := range strings.Split(args[k], ",") {
+				fullAPICall = fullAPICall + k + "=" + v + "&"
+			}
 		}
 		if last := len(fullAPICall) - 1; last >= 0 && fullAPICall[last] == '&' {
 			fullAPICall = fullAPICall[:last]
@@ -54,7 +63,7 @@ func (c *RiotClientV4) MatchesByAccountID(accountID string, args map[string]stri
 	}
 	data, err := apiCall(c, fullAPICall, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	matchList := riotclient.MatchlistDTO{}
@@ -70,9 +79,9 @@ func (c *RiotClientV4) MatchesByAccountID(accountID string, args map[string]stri
 func (c *RiotClientV4) MatchTimeLineByID(matchID uint64) (t *riotclient.MatchTimelineDTO, err error) {
 	// /lol/match/v4/timelines/by-match/{matchId}
 	idStr := strconv.FormatUint(matchID, 10)
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/match/"+c.config.APIVersion+"/timelines/by-match/"+idStr, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/match/"+c.config.APIVersion+"/timelines/by-match/"+idStr, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	matchTimeLine := riotclient.MatchTimelineDTO{}
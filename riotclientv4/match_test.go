@@ -555,6 +555,7 @@ func TestRiotClientV4_MatchesByAccountID(t *testing.T) {
 					"beginTime":  "0",
 					"champion":   "123",
 					"queue":      "100",
+					"season":     "12,13",
 				},
 			},
 			wantS: &riotclient.MatchlistDTO{
@@ -597,7 +598,7 @@ func TestRiotClientV4_MatchesByAccountID(t *testing.T) {
 			setJSON:           []byte(`{"matches":[{"lane":"JUNGLE","gameId":3875655954,"champion":120,"platformId":"EUW1","timestamp":1545831637628,"queue":420,"role":"NONE","season":11},{"lane":"JUNGLE","gameId":3875554798,"champion":79,"platformId":"EUW1","timestamp":1545828172417,"queue":420,"role":"NONE","season":11},{"lane":"JUNGLE","gameId":3875459851,"champion":120,"platformId":"EUW1","timestamp":1545825706777,"queue":420,"role":"NONE","season":11}],"endIndex":100,"startIndex":0,"totalGames":3}`),
 			setError:          nil,
 			wantErr:           false,
-			wantAPICallPath:   "https://euw1.api.riotgames.com/lol/match/v4/matchlists/by-account/C9VDk9h0oZtvFNWWeQVaU2G_Kq6YWYR2pcKbhmd4TgSMvw?beginIndex=0&beginTime=0&champion=123&endIndex=100&queue=100",
+			wantAPICallPath:   "https://euw1.api.riotgames.com/lol/match/v4/matchlists/by-account/C9VDk9h0oZtvFNWWeQVaU2G_Kq6YWYR2pcKbhmd4TgSMvw?beginIndex=0&beginTime=0&champion=123&endIndex=100&queue=100&season=12&season=13",
 			wantAPICallMethod: "GET",
 			wantAPICallBody:   "",
 		},
@@ -0,0 +1,73 @@
+package riotclientv4
+
+import (
+	"net/url"
+	"strings"
+)
+
+// A methodRoute maps the path of an API call below /lol/<api>/<version>/ to the name of the method used by Riot
+// for rate limiting. An empty segment in the pattern matches any value, e.g., a Summoner ID.
+type methodRoute struct {
+	api     string
+	pattern []string
+	name    string
+}
+
+var methodRoutes = []methodRoute{
+	{"champion-mastery", []string{"champion-masteries", "by-summoner", ""}, "getAllChampionMasteries"},
+	{"champion-mastery", []string{"champion-masteries", "by-summoner", "", "by-champion", ""}, "getChampionMastery"},
+	{"champion-mastery", []string{"scores", "by-summoner", ""}, "getChampionMasteryScore"},
+	{"league", []string{"challengerleagues", "by-queue", ""}, "getChallengerLeague"},
+	{"league", []string{"grandmasterleagues", "by-queue", ""}, "getGrandmasterLeague"},
+	{"league", []string{"masterleagues", "by-queue", ""}, "getMasterLeague"},
+	{"league", []string{"positions", "by-summoner", ""}, "getAllLeaguePositionsForSummoner"},
+	{"league-exp", []string{"entries", "", "", ""}, "getLeagueEntries"},
+	{"match", []string{"matches", ""}, "getMatch"},
+	{"match", []string{"matchlists", "by-account", ""}, "getMatchlist"},
+	{"match", []string{"timelines", "by-match", ""}, "getMatchTimeline"},
+	{"match", []string{"matches", "", "timeline"}, "getTimeline"},
+	{"match", []string{"matches", "by-puuid", "", "ids"}, "getMatchIdsByPUUID"},
+	{"platform", []string{"champion-rotations"}, "getChampionInfo"},
+	{"spectator", []string{"active-games", "by-summoner", ""}, "getCurrentGameInfoBySummoner"},
+	{"spectator", []string{"featured-games"}, "getFeaturedGames"},
+	{"summoner", []string{"summoners", "by-account", ""}, "getByAccountId"},
+	{"summoner", []string{"summoners", "by-name", ""}, "getBySummonerName"},
+	{"summoner", []string{"summoners", "by-puuid", ""}, "getByPUUID"},
+	{"summoner", []string{"summoners", ""}, "getBySummonerId"},
+}
+
+func (r *methodRoute) matches(api string, segments []string) bool {
+	if r.api != api || len(r.pattern) != len(segments) {
+		return false
+	}
+	for i, p := range r.pattern {
+		if len(p) > 0 && p != segments[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// methodKey returns the normalized rate limit method key for an API call, e.g., match-v4.getMatch.
+// Calls of unknown methods share one key per API and version, e.g., match-v4. Calls which are not LoL API calls get an empty key.
+func methodKey(path string) string {
+	u, err := url.Parse(path)
+	if err != nil {
+		return ""
+	}
+
+	// /lol/<api>/<version>/<segments...>
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 3 || parts[0] != "lol" {
+		return ""
+	}
+	api, version, segments := parts[1], parts[2], parts[3:]
+
+	for i := range methodRoutes {
+		if methodRoutes[i].matches(api, segments) {
+			return api + "-" + version + "." + methodRoutes[i].name
+		}
+	}
+
+	return api + "-" + version
+}
@@ -0,0 +1,60 @@
+package riotclientv4
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/logging"
+)
+
+func Test_methodKey(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"https://euw1.api.riotgames.com/lol/match/v4/matches/3827449823", "match-v4.getMatch"},
+		{"https://euw1.api.riotgames.com/lol/match/v4/matchlists/by-account/abc?endIndex=100&beginIndex=0", "match-v4.getMatchlist"},
+		{"https://euw1.api.riotgames.com/lol/match/v4/timelines/by-match/3827449823", "match-v4.getMatchTimeline"},
+		{"https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823", "match-v5.getMatch"},
+		{"https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823/timeline", "match-v5.getTimeline"},
+		{"https://europe.api.riotgames.com/lol/match/v5/matches/by-puuid/puuid/ids?start=0", "match-v5.getMatchIdsByPUUID"},
+		{"https://euw1.api.riotgames.com/lol/summoner/v4/summoners/by-name/name", "summoner-v4.getBySummonerName"},
+		{"https://euw1.api.riotgames.com/lol/summoner/v4/summoners/by-account/aid", "summoner-v4.getByAccountId"},
+		{"https://euw1.api.riotgames.com/lol/summoner/v4/summoners/by-puuid/puuid", "summoner-v4.getByPUUID"},
+		{"https://euw1.api.riotgames.com/lol/summoner/v4/summoners/sid", "summoner-v4.getBySummonerId"},
+		{"https://euw1.api.riotgames.com/lol/league/v4/masterleagues/by-queue/RANKED_SOLO_5x5", "league-v4.getMasterLeague"},
+		{"https://euw1.api.riotgames.com/lol/league/v4/positions/by-summoner/sid", "league-v4.getAllLeaguePositionsForSummoner"},
+		{"https://euw1.api.riotgames.com/lol/league-exp/v4/entries/RANKED_SOLO_5x5/GOLD/I?page=1", "league-exp-v4.getLeagueEntries"},
+		{"https://euw1.api.riotgames.com/lol/champion-mastery/v4/champion-masteries/by-summoner/sid/by-champion/12", "champion-mastery-v4.getChampionMastery"},
+		{"https://euw1.api.riotgames.com/lol/platform/v3/champion-rotations", "platform-v3.getChampionInfo"},
+		{"https://euw1.api.riotgames.com/lol/spectator/v4/featured-games", "spectator-v4.getFeaturedGames"},
+		{"https://euw1.api.riotgames.com/lol/match/v4/something/new", "match-v4"},
+		{"https://ddragon.leagueoflegends.com/api/versions.json", ""},
+		{"://invalid", ""},
+	}
+	for _, tt := range tests {
+		if got := methodKey(tt.path); got != tt.want {
+			t.Errorf("methodKey(%s) = %s, want %s", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestRiotClientV4_queueForMethod(t *testing.T) {
+	c := &RiotClientV4{log: logging.Get("RiotClientV4")}
+	c.Start()
+	defer c.Stop()
+
+	if c.queueForMethod("") != c.workQueue {
+		t.Errorf("Expected general work queue for calls without method key")
+	}
+
+	match := c.queueForMethod("match-v4.getMatch")
+	if match == c.workQueue {
+		t.Errorf("Expected own work queue for method")
+	}
+	if c.queueForMethod("match-v4.getMatch") != match {
+		t.Errorf("Expected the same work queue for the same method")
+	}
+	if c.queueForMethod("summoner-v4.getBySummonerName") == match {
+		t.Errorf("Expected different work queues for different methods")
+	}
+}
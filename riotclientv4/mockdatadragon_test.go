@@ -3,6 +3,7 @@ package riotclientv4
 // MockRiotClientDD Mock of Riot LoL API DataDragon client
 type MockRiotClientDD struct {
 	championsJSON      []byte
+	championJSON       []byte
 	itemJSON           []byte
 	summonerSpellsJSON []byte
 	versionsJSON       []byte
@@ -18,6 +19,15 @@ func (c *MockRiotClientDD) GetDataDragonChampions() ([]byte, error) {
 	return c.championsJSON, nil
 }
 
+// GetDataDragonChampionDetailsSpecificVersionLanguage returns the full data of a champion for a specific gameVersion and language
+func (c *MockRiotClientDD) GetDataDragonChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) ([]byte, error) {
+	if c.err != nil {
+		return []byte(""), c.err
+	}
+
+	return c.championJSON, nil
+}
+
 // GetDataDragonItemsSpecificVersionLanguage returns the items for a specific gameVersion and langauge
 func (c *MockRiotClientDD) GetDataDragonItemsSpecificVersionLanguage(gameVersion, language string) ([]byte, error) {
 	if c.err != nil {
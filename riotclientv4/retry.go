@@ -0,0 +1,129 @@
+package riotclientv4
+
+import (
+	"context"
+	"math/rand"
+	"sync"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+var randFloat = rand.Float64
+var sleep = sleepContext
+
+// Default retry policies used when none is configured
+var (
+	defaultRetryServerErrors    = config.RetryPolicy{MaxAttempts: 4, BaseDelay: 500, MaxDelay: 10000, Jitter: 0.2}
+	defaultRetryTransportErrors = config.RetryPolicy{MaxAttempts: 4, BaseDelay: 500, MaxDelay: 10000, Jitter: 0.2}
+	defaultRetryRateLimited     = config.RetryPolicy{MaxAttempts: 4}
+)
+
+// sleepContext pauses for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// retryPolicy returns the retry policy for errors of the given kind, false if they are never retried
+func (c *RiotClientV4) retryPolicy(kind riotclient.ErrorKind) (config.RetryPolicy, bool) {
+	var policy, defaultPolicy config.RetryPolicy
+	switch kind {
+	case riotclient.ErrorServerError:
+		policy, defaultPolicy = c.config.RetryServerErrors, defaultRetryServerErrors
+	case riotclient.ErrorTransport, riotclient.ErrorTimeout:
+		policy, defaultPolicy = c.config.RetryTransportErrors, defaultRetryTransportErrors
+	case riotclient.ErrorRateLimited:
+		policy, defaultPolicy = c.config.RetryRateLimited, defaultRetryRateLimited
+	default:
+		return config.RetryPolicy{}, false
+	}
+
+	if policy.MaxAttempts == 0 {
+		return defaultPolicy, true
+	}
+	return policy, true
+}
+
+// backoff returns the delay before the given retry (starting at 1): The base delay doubled for every further retry,
+// capped at the maximum delay and randomized by the jitter
+func backoff(policy config.RetryPolicy, retry int) time.Duration {
+	delay := time.Duration(policy.BaseDelay) * time.Millisecond
+	maxDelay := time.Duration(policy.MaxDelay) * time.Millisecond
+
+	for i := 1; i < retry && (maxDelay == 0 || delay < maxDelay); i++ {
+		delay *= 2
+	}
+	if maxDelay > 0 && delay > maxDelay {
+		delay = maxDelay
+	}
+
+	if policy.Jitter > 0 {
+		delay = time.Duration(float64(delay) * (1 + policy.Jitter*(2*randFloat()-1)))
+	}
+	if delay < 0 {
+		delay = 0
+	}
+
+	return delay
+}
+
+// requestStats counts the requests of a client and its retries and failures per kind of error
+type requestStats struct {
+	mutex    sync.Mutex
+	requests uint64
+	retries  map[riotclient.ErrorKind]uint64
+	failures map[riotclient.ErrorKind]uint64
+}
+
+func newRequestStats() *requestStats {
+	return &requestStats{
+		retries:  make(map[riotclient.ErrorKind]uint64),
+		failures: make(map[riotclient.ErrorKind]uint64),
+	}
+}
+
+func (s *requestStats) request() {
+	s.mutex.Lock()
+	s.requests++
+	s.mutex.Unlock()
+}
+
+func (s *requestStats) retry(kind riotclient.ErrorKind) {
+	s.mutex.Lock()
+	s.retries[kind]++
+	s.mutex.Unlock()
+}
+
+func (s *requestStats) failure(kind riotclient.ErrorKind) {
+	s.mutex.Lock()
+	s.failures[kind]++
+	s.mutex.Unlock()
+}
+
+func (s *requestStats) get() riotclient.RequestStats {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	stats := riotclient.RequestStats{
+		Requests: s.requests,
+		Retries:  make(map[string]uint64),
+		Failures: make(map[string]uint64),
+	}
+	for kind, count := range s.retries {
+		stats.Retries[kind.String()] = count
+	}
+	for kind, count := range s.failures {
+		stats.Failures[kind.String()] = count
+	}
+
+	return stats
+}
@@ -0,0 +1,68 @@
+package riotclientv4
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func Test_backoff(t *testing.T) {
+	defer func() {
+		randFloat = rand.Float64
+	}()
+
+	tests := []struct {
+		name   string
+		policy config.RetryPolicy
+		retry  int
+		random float64
+		want   time.Duration
+	}{
+		{"First retry", config.RetryPolicy{BaseDelay: 500, MaxDelay: 10000}, 1, 0, 500 * time.Millisecond},
+		{"Doubled", config.RetryPolicy{BaseDelay: 500, MaxDelay: 10000}, 3, 0, 2 * time.Second},
+		{"Capped", config.RetryPolicy{BaseDelay: 500, MaxDelay: 10000}, 10, 0, 10 * time.Second},
+		{"Not capped", config.RetryPolicy{BaseDelay: 500}, 6, 0, 16 * time.Second},
+		{"No delay", config.RetryPolicy{}, 3, 0, 0},
+		{"Jitter low", config.RetryPolicy{BaseDelay: 1000, Jitter: 0.2}, 1, 0, 800 * time.Millisecond},
+		{"Jitter middle", config.RetryPolicy{BaseDelay: 1000, Jitter: 0.2}, 1, 0.5, 1000 * time.Millisecond},
+		{"Jitter high", config.RetryPolicy{BaseDelay: 1000, Jitter: 0.2}, 1, 1, 1200 * time.Millisecond},
+		{"Jitter too large", config.RetryPolicy{BaseDelay: 1000, Jitter: 2}, 1, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			randFloat = func() float64 {
+				return tt.random
+			}
+			if got := backoff(tt.policy, tt.retry); got != tt.want {
+				t.Errorf("backoff() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRiotClientV4_retryPolicy(t *testing.T) {
+	transportErrors := config.RetryPolicy{MaxAttempts: 2, BaseDelay: 100}
+	c := &RiotClientV4{config: config.RiotClient{RetryTransportErrors: transportErrors}}
+
+	tests := []struct {
+		kind      riotclient.ErrorKind
+		want      config.RetryPolicy
+		wantRetry bool
+	}{
+		{riotclient.ErrorServerError, defaultRetryServerErrors, true},
+		{riotclient.ErrorTransport, transportErrors, true},
+		{riotclient.ErrorTimeout, transportErrors, true},
+		{riotclient.ErrorRateLimited, defaultRetryRateLimited, true},
+		{riotclient.ErrorNotFound, config.RetryPolicy{}, false},
+		{riotclient.ErrorCanceled, config.RetryPolicy{}, false},
+	}
+	for _, tt := range tests {
+		got, retry := c.retryPolicy(tt.kind)
+		if got != tt.want || retry != tt.wantRetry {
+			t.Errorf("retryPolicy(%s) = %+v, %t, want %+v, %t", tt.kind, got, retry, tt.want, tt.wantRetry)
+		}
+	}
+}
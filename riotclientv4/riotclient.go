@@ -2,6 +2,7 @@
 package riotclientv4
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -13,6 +14,7 @@ import (
 
 	"git.abyle.org/hps/alolstats/config"
 	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
 
 	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
 )
@@ -35,12 +37,26 @@ type RiotClientV4 struct {
 	stopWorkers chan struct{}
 	workQueue   workQueue
 
-	ddragon   dataDragon
-	rateLimit *riotclientrl.RiotClientRL
+	// Every rate limit method key gets its own work queue and worker, so that a method waiting for its
+	// Method Rate Limit does not block calls to other methods
+	methodWorkQueues map[string]workQueue
+	workQueuesMutex  sync.Mutex
+
+	ddragon      dataDragon
+	rateLimit    *riotclientrl.RiotClientRL
+	keys         *keyPool
+	requestStats *requestStats
+
+	// priority and ctx are used for all API calls of this client.
+	// Clients created with WithPriority or WithContext have a parent which owns the workers and work queues.
+	priority riotclient.Priority
+	ctx      context.Context
+	parent   *RiotClientV4
 }
 
 type dataDragon interface {
 	GetDataDragonChampions() ([]byte, error)
+	GetDataDragonChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) ([]byte, error)
 
 	GetDataDragonItems() ([]byte, error)
 	GetDataDragonItemsSpecificVersionLanguage(gameVersion, language string) ([]byte, error)
@@ -58,8 +74,8 @@ func checkConfig(cfg config.RiotClient) error {
 	if cfg.APIVersion != "v4" {
 		return fmt.Errorf("APIVersion is not correct, must be v4")
 	}
-	if len(cfg.Key) == 0 {
-		return fmt.Errorf("Key is empty, check config file")
+	if len(cfg.Key) == 0 && len(cfg.Keys) == 0 {
+		return fmt.Errorf("Key and Keys are empty, check config file")
 	}
 	if len(cfg.Region) == 0 {
 		return fmt.Errorf("Region is empty, check config file")
@@ -84,10 +100,11 @@ func NewClient(httpClient httpClient, cfg config.RiotClient,
 		isStarted:   false,
 		workersWG:   sync.WaitGroup{},
 		stopWorkers: make(chan struct{}),
-		workQueue:   make(workQueue),
+		workQueue:   newWorkQueue(),
 
 		ddragon:   ddragon,
 		rateLimit: rateLimit,
+		keys:      newKeyPool(append([]string{cfg.Key}, cfg.Keys...), rateLimit),
 	}
 
 	cfg.Region = strings.ToLower(cfg.Region)
@@ -95,12 +112,67 @@ func NewClient(httpClient httpClient, cfg config.RiotClient,
 	return c, nil
 }
 
+// WithPriority returns a client which does all its API calls with the given priority.
+// It shares workers, work queues and rate limits with c, starting and stopping either of them affects both.
+func (c *RiotClientV4) WithPriority(priority riotclient.Priority) riotclient.Client {
+	client := c.view()
+	client.priority = priority
+	return client
+}
+
+// WithContext returns a client which does all its API calls with the given context.
+// When ctx is done, queued calls are dropped and calls in flight are aborted.
+// It shares workers, work queues and rate limits with c.
+func (c *RiotClientV4) WithContext(ctx context.Context) riotclient.Client {
+	client := c.view()
+	client.ctx = ctx
+	return client
+}
+
+// view returns a client sharing workers, work queues and rate limits with c
+func (c *RiotClientV4) view() *RiotClientV4 {
+	return &RiotClientV4{
+		config:     c.config,
+		httpClient: c.httpClient,
+		log:        c.log,
+		ddragon:    c.ddragon,
+		rateLimit:  c.rateLimit,
+		keys:       c.keys,
+		priority:   c.priority,
+		ctx:        c.ctx,
+		parent:     c.scheduler(),
+	}
+}
+
+// context returns the context used for API calls, context.Background() if none was set
+func (c *RiotClientV4) context() context.Context {
+	if c.ctx != nil {
+		return c.ctx
+	}
+	return context.Background()
+}
+
+// scheduler returns the client owning the workers and work queues
+func (c *RiotClientV4) scheduler() *RiotClientV4 {
+	if c.parent != nil {
+		return c.parent
+	}
+	return c
+}
+
 // Start starts the riot client and its workers
 func (c *RiotClientV4) Start() {
+	c = c.scheduler()
 	if !c.isStarted {
 		c.log.Println("Starting Riot Client")
 		c.stopWorkers = make(chan struct{})
-		c.workQueue = make(workQueue)
+		c.workQueue = newWorkQueue()
+		if c.requestStats == nil {
+			c.requestStats = newRequestStats()
+		}
+		c.workQueuesMutex.Lock()
+		c.methodWorkQueues = make(map[string]workQueue)
+		c.workQueuesMutex.Unlock()
 		c.workersWG.Add(1)
 		go c.worker(c.workQueue)
 		c.isStarted = true
@@ -111,6 +183,7 @@ func (c *RiotClientV4) Start() {
 
 // Stop stops the riot client and its workers
 func (c *RiotClientV4) Stop() {
+	c = c.scheduler()
 	if c.isStarted {
 		c.log.Println("Stopping Riot Client")
 		close(c.stopWorkers)
@@ -121,67 +194,89 @@ func (c *RiotClientV4) Stop() {
 	}
 }
 
+// RequestStats returns the counters of the requests sent to the Riot API, including those of clients created with WithPriority or WithContext,
+// and the state of the API keys
+func (c *RiotClientV4) RequestStats() riotclient.RequestStats {
+	c = c.scheduler()
+	var stats riotclient.RequestStats
+	if c.requestStats != nil {
+		stats = c.requestStats.get()
+	}
+	if c.keys != nil {
+		stats.APIKeys = c.keys.status()
+	}
+	return stats
+}
+
 // IsRunning returns if the Riot Client is currently started
 func (c *RiotClientV4) IsRunning() bool {
-	return c.isStarted
+	return c.scheduler().isStarted
 }
 
 func (c *RiotClientV4) checkResponseCodeOK(response *http.Response) error {
 	// Rate limit 429 is handeled in separate check function
-	switch response.StatusCode {
-	case 200:
+	if response.StatusCode == http.StatusOK {
 		return nil
-	case 400:
-		return fmt.Errorf("Status code 400 (Bad Request)")
-	case 401:
-		return fmt.Errorf("Status code 401 (Unauthorized)")
-	case 403:
-		return fmt.Errorf("Status code 403 (Forbidden)")
-	case 404:
-		return fmt.Errorf("Status code 404 (Not Found)")
-	case 405:
-		return fmt.Errorf("Status code 404 (Method not allowed)")
-	case 415:
-		return fmt.Errorf("Status code 415 (Unsupported Media Type)")
-	case 500:
-		return fmt.Errorf("Status code 500 (Internal Server Error)")
-	case 503:
-		return fmt.Errorf("Status code 503 (Service Unavailable)")
-	default:
-		return fmt.Errorf("Status code %d (Unknown Error)", response.StatusCode)
-	}
-}
-
-func (c *RiotClientV4) checkRateLimited(response *http.Response, method string) error {
-	c.rateLimit.UpdateRateLimits(response.Header, method)
+	}
+	return riotclient.NewStatusCodeError(response.StatusCode)
+}
+
+func (c *RiotClientV4) checkRateLimited(response *http.Response, method string, rateLimit *riotclientrl.RiotClientRL) error {
+	rateLimit.UpdateRateLimits(response.Header, method)
 
 	if response.StatusCode == 429 {
 		c.log.Warnf("Rate limited with header: %s", response.Header)
-		return fmt.Errorf("Status code 429 (Rate Limited)")
+		return riotclient.NewStatusCodeError(response.StatusCode)
 	}
 
 	return nil
 }
 
+// Region returns the region the client is configured for, e.g., euw1
+func (c *RiotClientV4) Region() string {
+	return c.config.Region
+}
+
+// platformURL returns the base URL for calls to the platform the client is configured for, or the configured BaseURL
+func (c *RiotClientV4) platformURL() string {
+	if len(c.config.BaseURL) > 0 {
+		return strings.TrimSuffix(c.config.BaseURL, "/")
+	}
+	return "https://" + c.config.Region + ".api.riotgames.com"
+}
+
+// APICall performs a call to the Riot API using the workers and rate limiting of this client.
+// It is meant for clients of newer API versions which reuse the v4 client for the endpoints which did not change.
+func (c *RiotClientV4) APICall(path string, method string, body string) ([]byte, error) {
+	return apiCall(c, path, method, body)
+}
+
 func (c *RiotClientV4) realAPICall(path string, method string, body string) (r []byte, e error) {
-	if !c.isStarted {
+	if !c.IsRunning() {
 		return nil, fmt.Errorf("Riot Client not started. Start by calling the Start() function")
 	}
 
 	c.log.Traceln("ApiCall: Got new Api Call:", path)
 
+	// Canceling the context when returning makes the worker drop the work order if it was not processed, yet
+	ctx, cancel := context.WithTimeout(c.context(), 180*time.Second)
+	defer cancel()
+
 	req, err := http.NewRequest(method, path, strings.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
+	req = req.WithContext(ctx)
 
-	req.Header.Add("X-Riot-Token", c.config.Key)
 	req.Header.Add("Content-Type", "application/json")
 
+	// The response channel is buffered, so that the worker does not block when the call already timed out
 	work := workOrder{request: req,
-		responseChan: make(workResponseChan)}
+		responseChan: make(workResponseChan, 1),
+		method:       methodKey(path),
+		priority:     c.priority}
 
-	c.workQueue <- work
+	c.queueForMethod(work.method).push(work)
 
 	select {
 	case res := <-work.responseChan:
@@ -190,9 +285,21 @@ func (c *RiotClientV4) realAPICall(path string, method string, body string) (r [
 			return nil, res.err
 		}
 		c.log.Traceln("ApiCall: Successfully finished Api Call")
+		defer res.response.Body.Close()
 		return ioutil.ReadAll(res.response.Body)
-	case <-time.After(180 * time.Second):
-		c.log.Debugln("ApiCall: API call timed out")
-		return nil, fmt.Errorf("Worker timed out")
+	case <-ctx.Done():
+		c.log.Debugln("ApiCall: API call did not finish:", ctx.Err())
+		return nil, contextError(ctx)
+	case <-c.scheduler().stopWorkers:
+		c.log.Debugln("ApiCall: Riot Client stopped")
+		return nil, riotclient.NewError(riotclient.ErrorCanceled, "Riot Client stopped")
+	}
+}
+
+// contextError returns the error for a call which did not finish because ctx is done
+func contextError(ctx context.Context) error {
+	if ctx.Err() == context.DeadlineExceeded {
+		return riotclient.NewError(riotclient.ErrorTimeout, "API call timed out")
 	}
+	return riotclient.NewError(riotclient.ErrorCanceled, "API call canceled: %s", ctx.Err())
 }
@@ -168,7 +168,7 @@ func TestRiotClientV4_checkRateLimited(t *testing.T) {
 				log:       tt.fields.log,
 				isStarted: tt.fields.isStarted,
 			}
-			if err := c.checkRateLimited(tt.args.response, tt.args.method); (err != nil) != tt.wantErr {
+			if err := c.checkRateLimited(tt.args.response, tt.args.method, c.rateLimit); (err != nil) != tt.wantErr {
 				t.Errorf("RiotClientV4.checkRateLimited() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -2,7 +2,6 @@ package riotclientv4
 
 import (
 	"encoding/json"
-	"fmt"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 )
@@ -10,9 +9,9 @@ import (
 // ActiveGameBySummonerID returns the active game (live game) for the given Summoner ID
 func (c *RiotClientV4) ActiveGameBySummonerID(summonerID string) (*riotclient.CurrentGameInfoDTO, error) {
 	// /lol/spectator/v4/active-games/by-summoner/{encryptedSummonerId}
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/spectator/"+c.config.APIVersion+"/active-games/by-summoner/"+summonerID, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/spectator/"+c.config.APIVersion+"/active-games/by-summoner/"+summonerID, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	currentGame := riotclient.CurrentGameInfoDTO{}
@@ -27,9 +26,9 @@ func (c *RiotClientV4) ActiveGameBySummonerID(summonerID string) (*riotclient.Cu
 // FeaturedGames returns the currently features games from Riot
 func (c *RiotClientV4) FeaturedGames() (*riotclient.FeaturedGamesDTO, error) {
 	// /lol/spectator/v4/featured-games
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/spectator/"+c.config.APIVersion+"/featured-games", "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/spectator/"+c.config.APIVersion+"/featured-games", "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	featuredGames := riotclient.FeaturedGamesDTO{}
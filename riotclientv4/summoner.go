@@ -9,9 +9,9 @@ import (
 
 // SummonerByName gets summoner data by its name
 func (c *RiotClientV4) SummonerByName(name string) (s *riotclient.SummonerDTO, err error) {
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/summoner/"+c.config.APIVersion+"/summoners/by-name/"+name, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/summoner/"+c.config.APIVersion+"/summoners/by-name/"+name, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	summoner := riotclient.SummonerDTO{}
@@ -19,7 +19,7 @@ func (c *RiotClientV4) SummonerByName(name string) (s *riotclient.SummonerDTO, e
 	if err != nil {
 		return nil, fmt.Errorf("%s. Data was: %s", err, data)
 	} else if summoner.ID == "" {
-		return nil, fmt.Errorf("User does not exist")
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "User does not exist")
 	}
 
 	summoner.Timestamp = now()
@@ -29,9 +29,9 @@ func (c *RiotClientV4) SummonerByName(name string) (s *riotclient.SummonerDTO, e
 
 // SummonerByAccountID gets summoner data by its AccountID
 func (c *RiotClientV4) SummonerByAccountID(accountID string) (s *riotclient.SummonerDTO, err error) {
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/summoner/"+c.config.APIVersion+"/summoners/by-account/"+accountID, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/summoner/"+c.config.APIVersion+"/summoners/by-account/"+accountID, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	summoner := riotclient.SummonerDTO{}
@@ -39,7 +39,7 @@ func (c *RiotClientV4) SummonerByAccountID(accountID string) (s *riotclient.Summ
 	if err != nil {
 		return nil, err
 	} else if summoner.ID == "" {
-		return nil, fmt.Errorf("User does not exist")
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "User does not exist")
 	}
 
 	summoner.Timestamp = now()
@@ -49,9 +49,9 @@ func (c *RiotClientV4) SummonerByAccountID(accountID string) (s *riotclient.Summ
 
 // SummonerBySummonerID gets summoner data by its SummonerID
 func (c *RiotClientV4) SummonerBySummonerID(summonerID string) (s *riotclient.SummonerDTO, err error) {
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/summoner/"+c.config.APIVersion+"/summoners/"+summonerID, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/summoner/"+c.config.APIVersion+"/summoners/"+summonerID, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	summoner := riotclient.SummonerDTO{}
@@ -59,7 +59,7 @@ func (c *RiotClientV4) SummonerBySummonerID(summonerID string) (s *riotclient.Su
 	if err != nil {
 		return nil, err
 	} else if summoner.ID == "" {
-		return nil, fmt.Errorf("User does not exist")
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "User does not exist")
 	}
 
 	summoner.Timestamp = now()
@@ -70,9 +70,9 @@ func (c *RiotClientV4) SummonerBySummonerID(summonerID string) (s *riotclient.Su
 // SummonerByPUUID gets summoner data by its PUUID
 func (c *RiotClientV4) SummonerByPUUID(PUUID string) (s *riotclient.SummonerDTO, err error) {
 	// /lol/summoner/v4/summoners/by-puuid/{encryptedPUUID}
-	data, err := apiCall(c, "https://"+c.config.Region+".api.riotgames.com/lol/summoner/"+c.config.APIVersion+"/summoners/by-puuid/"+PUUID, "GET", "")
+	data, err := apiCall(c, c.platformURL()+"/lol/summoner/"+c.config.APIVersion+"/summoners/by-puuid/"+PUUID, "GET", "")
 	if err != nil {
-		return nil, fmt.Errorf("Error in API call: %s", err)
+		return nil, err
 	}
 
 	summoner := riotclient.SummonerDTO{}
@@ -80,7 +80,7 @@ func (c *RiotClientV4) SummonerByPUUID(PUUID string) (s *riotclient.SummonerDTO,
 	if err != nil {
 		return nil, err
 	} else if summoner.ID == "" {
-		return nil, fmt.Errorf("User does not exist")
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "User does not exist")
 	}
 
 	summoner.Timestamp = now()
@@ -1,8 +1,12 @@
 package riotclientv4
 
 import (
+	"context"
+	"net"
 	"net/http"
-	"time"
+	"sync"
+
+	"git.abyle.org/hps/alolstats/riotclient"
 )
 
 type workResponseData struct {
@@ -16,56 +20,244 @@ type workOrder struct {
 	request      *http.Request
 	responseChan workResponseChan
 	method       string
+	priority     riotclient.Priority
+}
+
+// maxSkips is how often pending work orders may be passed over in favor of a higher priority before they are served
+const maxSkips = 8
+
+// priorityQueue holds pending work orders per priority. Higher priorities are served first,
+// but lower priorities which were passed over maxSkips times are served next, so that they never starve.
+type priorityQueue struct {
+	mutex   sync.Mutex
+	orders  [riotclient.NumPriorities][]workOrder
+	skipped [riotclient.NumPriorities]int
+
+	// signal notifies the worker about new work orders
+	signal chan struct{}
 }
 
-// A buffered channel that we can send work requests on.
-type workQueue = chan workOrder
+// A workQueue is what we send work requests on.
+type workQueue = *priorityQueue
+
+func newWorkQueue() workQueue {
+	return &priorityQueue{signal: make(chan struct{}, 1)}
+}
+
+// push adds a work order to the queue. Unknown priorities are treated as the lowest priority.
+func (q *priorityQueue) push(work workOrder) {
+	p := int(work.priority)
+	if p < 0 || p >= riotclient.NumPriorities {
+		p = riotclient.NumPriorities - 1
+	}
+
+	q.mutex.Lock()
+	q.orders[p] = append(q.orders[p], work)
+	q.mutex.Unlock()
+
+	select {
+	case q.signal <- struct{}{}:
+	default:
+	}
+}
+
+// pop removes the next work order to serve from the queue. It returns false if the queue is empty.
+func (q *priorityQueue) pop() (workOrder, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	next := -1
+	for p := range q.orders {
+		if len(q.orders[p]) == 0 {
+			q.skipped[p] = 0
+			continue
+		}
+		if next == -1 {
+			next = p
+		} else if q.skipped[p] >= maxSkips && q.skipped[p] > q.skipped[next] {
+			next = p
+		}
+	}
+	if next == -1 {
+		return workOrder{}, false
+	}
+
+	for p := range q.orders {
+		if p != next && len(q.orders[p]) > 0 {
+			q.skipped[p]++
+		}
+	}
+	q.skipped[next] = 0
+
+	work := q.orders[next][0]
+	q.orders[next][0] = workOrder{}
+	q.orders[next] = q.orders[next][1:]
+
+	return work, true
+}
 
 func (c *RiotClientV4) worker(workQueue workQueue) {
 	defer c.workersWG.Done()
 
 	c.log.Debugln("Worker: Starting")
 
+	// Every work order is processed in its own goroutine, slots limits how many of them are in flight
+	slots := make(chan struct{}, c.maxConcurrentRequests())
+
 	for {
 		select {
-		case work := <-workQueue:
-			tryAgain := true
-			tries := 0
-			var response *http.Response
-			var err error
-			for tryAgain && tries <= 3 {
-				tryAgain = false
-				tries++
-
-				sleepFor := time.Until(c.rateLimit.GetRateLimitRetryAt(work.method))
-				if sleepFor > 0 {
-					c.log.Debugln("Worker: Sleeping for", sleepFor.String(), "to adhere to rate limit")
-					time.Sleep(sleepFor)
+		case <-workQueue.signal:
+			for {
+				// The slot is taken before popping, so that the order of the queue decides which work order is served once a slot is free
+				select {
+				case <-c.stopWorkers:
+					c.log.Printf("Stopping worker")
+					return
+				case slots <- struct{}{}:
 				}
 
-				response, err = c.httpClient.Do(work.request)
-				if err != nil {
-					continue
-				}
-				err = c.checkRateLimited(response, work.method)
-				if err != nil {
-					tryAgain = true
-					c.log.Warnln("Worker: Was rate limited, repeating request")
-					continue
-				}
-				err = c.checkResponseCodeOK(response)
-				if err != nil {
-					continue
+				work, ok := workQueue.pop()
+				if !ok {
+					<-slots
+					break
 				}
+				c.workersWG.Add(1)
+				go func(work workOrder) {
+					defer c.workersWG.Done()
+					c.process(work)
+					<-slots
+				}(work)
 			}
-
-			work.responseChan <- workResponseData{response: response,
-				err: err}
-
-			c.log.Debugln("Worker: Done processing work order")
 		case <-c.stopWorkers:
 			c.log.Printf("Stopping worker")
 			return
 		}
 	}
 }
+
+// defaultMaxConcurrentRequests is how many requests per work queue may be in flight at the same time if nothing is configured
+const defaultMaxConcurrentRequests = 8
+
+// maxConcurrentRequests returns how many requests per work queue may be in flight at the same time
+func (c *RiotClientV4) maxConcurrentRequests() int {
+	if c.config.MaxConcurrentRequests > 0 {
+		return int(c.config.MaxConcurrentRequests)
+	}
+	return defaultMaxConcurrentRequests
+}
+
+func (c *RiotClientV4) process(work workOrder) {
+	ctx := work.request.Context()
+	if ctx.Err() != nil {
+		c.log.Debugln("Worker: Dropping canceled work order")
+		work.responseChan <- workResponseData{err: contextError(ctx)}
+		return
+	}
+
+	var response *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		response, err = c.do(ctx, work)
+		if err == nil {
+			break
+		}
+
+		kind := riotclient.KindOf(err)
+		policy, retry := c.retryPolicy(kind)
+		if !retry || attempt >= int(policy.MaxAttempts) {
+			c.requestStats.failure(kind)
+			break
+		}
+
+		delay := backoff(policy, attempt)
+		c.log.Warnf("Worker: Request failed with %s (attempt %d of %d), repeating request in %s", err, attempt, policy.MaxAttempts, delay)
+		c.requestStats.retry(kind)
+		if sleep(ctx, delay) != nil {
+			err = contextError(ctx)
+			break
+		}
+	}
+
+	work.responseChan <- workResponseData{response: response,
+		err: err}
+
+	c.log.Debugln("Worker: Done processing work order")
+}
+
+// do performs the request of a work order once with the API key with the most headroom. Unsuccessful responses are
+// closed and returned as riotclient.Error. A key rejected by the Riot API is disabled and the request is repeated
+// right away with another key.
+func (c *RiotClientV4) do(ctx context.Context, work workOrder) (*http.Response, error) {
+	keys := c.keyPool()
+	for {
+		key, err := keys.selectKey(work.method)
+		if err != nil {
+			return nil, err
+		}
+
+		response, err := c.doWithKey(ctx, work, key)
+		if riotclient.KindOf(err) == riotclient.ErrorForbidden {
+			if enabled := keys.disable(key); enabled > 0 {
+				c.log.Errorf("Worker: API key %s was rejected (%s) and is disabled, %d keys remain", maskKey(key.key), err, enabled)
+				c.requestStats.retry(riotclient.ErrorForbidden)
+				continue
+			}
+		}
+		return response, err
+	}
+}
+
+func (c *RiotClientV4) doWithKey(ctx context.Context, work workOrder, key *apiKey) (*http.Response, error) {
+	if err := key.rateLimit.WaitPriority(ctx, work.method, int(work.priority)); err != nil {
+		return nil, contextError(ctx)
+	}
+
+	work.request.Header.Set("X-Riot-Token", key.key)
+
+	c.requestStats.request()
+	response, err := c.httpClient.Do(work.request)
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, contextError(ctx)
+		}
+		if netErr, ok := err.(net.Error); ok && netErr.Timeout() {
+			return nil, riotclient.NewError(riotclient.ErrorTimeout, "%s", err)
+		}
+		return nil, riotclient.NewError(riotclient.ErrorTransport, "%s", err)
+	}
+
+	err = c.checkRateLimited(response, work.method, key.rateLimit)
+	if err == nil {
+		err = c.checkResponseCodeOK(response)
+	}
+	if err != nil {
+		response.Body.Close()
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// queueForMethod returns the work queue for a rate limit method key and starts a worker for it if necessary.
+// Calls without method key use the general work queue.
+func (c *RiotClientV4) queueForMethod(method string) workQueue {
+	c = c.scheduler()
+	if len(method) == 0 {
+		return c.workQueue
+	}
+
+	c.workQueuesMutex.Lock()
+	defer c.workQueuesMutex.Unlock()
+
+	if queue, ok := c.methodWorkQueues[method]; ok {
+		return queue
+	}
+
+	c.log.Debugln("Starting worker for method", method)
+	queue := newWorkQueue()
+	c.methodWorkQueues[method] = queue
+	c.workersWG.Add(1)
+	go c.worker(queue)
+
+	return queue
+}
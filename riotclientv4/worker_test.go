@@ -0,0 +1,322 @@
+package riotclientv4
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+func popPriorities(q workQueue) []riotclient.Priority {
+	var priorities []riotclient.Priority
+	for {
+		work, ok := q.pop()
+		if !ok {
+			return priorities
+		}
+		priorities = append(priorities, work.priority)
+	}
+}
+
+func Test_priorityQueue(t *testing.T) {
+	const (
+		I = riotclient.PriorityInteractive
+		B = riotclient.PriorityBackground
+		X = riotclient.PriorityBulk
+	)
+
+	q := newWorkQueue()
+	if _, ok := q.pop(); ok {
+		t.Fatalf("Expected empty queue")
+	}
+
+	q.push(workOrder{priority: X})
+	q.push(workOrder{priority: B})
+	q.push(workOrder{priority: I})
+	q.push(workOrder{priority: riotclient.Priority(42)})
+	want := []riotclient.Priority{I, B, X, riotclient.Priority(42)}
+	if got := popPriorities(q); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected higher priorities first, got %v, want %v", got, want)
+	}
+
+	for i := 0; i < 2*maxSkips; i++ {
+		q.push(workOrder{priority: I})
+	}
+	q.push(workOrder{priority: B})
+	q.push(workOrder{priority: X})
+
+	got := popPriorities(q)
+	if len(got) != 2*maxSkips+2 {
+		t.Fatalf("Expected all work orders to be served, got %d", len(got))
+	}
+	for i, p := range got {
+		switch {
+		case i < maxSkips:
+			if p != I {
+				t.Errorf("Expected interactive work order at position %d, got %d", i, p)
+			}
+		case i == maxSkips:
+			if p != B {
+				t.Errorf("Expected starving background work order at position %d, got %d", i, p)
+			}
+		case i == maxSkips+1:
+			if p != X {
+				t.Errorf("Expected starving bulk work order at position %d, got %d", i, p)
+			}
+		default:
+			if p != I {
+				t.Errorf("Expected interactive work order at position %d, got %d", i, p)
+			}
+		}
+	}
+}
+
+func TestRiotClientV4_WithPriority(t *testing.T) {
+	c := &RiotClientV4{log: logging.Get("RiotClientV4")}
+	bulk := c.WithPriority(riotclient.PriorityBulk).(*RiotClientV4)
+	if bulk.priority != riotclient.PriorityBulk {
+		t.Errorf("Expected bulk priority, got %d", bulk.priority)
+	}
+	if c.priority != riotclient.PriorityInteractive {
+		t.Errorf("Expected interactive priority as default, got %d", c.priority)
+	}
+
+	background := bulk.WithPriority(riotclient.PriorityBackground).(*RiotClientV4)
+	if background.scheduler() != c {
+		t.Errorf("Expected clients with priority to share the workers of the original client")
+	}
+
+	bulk.Start()
+	if !c.IsRunning() || !background.IsRunning() {
+		t.Errorf("Expected all clients to be running")
+	}
+	if bulk.queueForMethod("match-v4.getMatch") != c.queueForMethod("match-v4.getMatch") {
+		t.Errorf("Expected clients with priority to share the work queues of the original client")
+	}
+	background.Stop()
+	if c.IsRunning() || bulk.IsRunning() {
+		t.Errorf("Expected all clients to be stopped")
+	}
+}
+
+// blockingHTTPClient blocks every request until its context is done
+type blockingHTTPClient struct {
+	requests chan *http.Request
+}
+
+func (c *blockingHTTPClient) Get(url string) (resp *http.Response, err error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *blockingHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	c.requests <- req
+	<-req.Context().Done()
+	return nil, req.Context().Err()
+}
+
+func newBlockingTestClient() (*RiotClientV4, *blockingHTTPClient) {
+	httpClient := &blockingHTTPClient{requests: make(chan *http.Request, 10)}
+	rateLimit, _ := riotclientrl.New()
+	c := &RiotClientV4{
+		config:     config.RiotClient{Key: "abcd"},
+		httpClient: httpClient,
+		log:        logging.Get("RiotClientV4"),
+		rateLimit:  rateLimit,
+	}
+	return c, httpClient
+}
+
+func TestRiotClientV4_WithContext(t *testing.T) {
+	c, httpClient := newBlockingTestClient()
+	c.config.MaxConcurrentRequests = 1
+	c.Start()
+	defer c.Stop()
+
+	// The first call keeps the worker busy, the second one is canceled while it is queued
+	ctx1, cancel1 := context.WithCancel(context.Background())
+	done1 := make(chan error)
+	go func() {
+		_, err := c.WithContext(ctx1).(*RiotClientV4).realAPICall("https://euw1.api.riotgames.com/lol/match/v4/matches/1", "GET", "")
+		done1 <- err
+	}()
+	<-httpClient.requests
+
+	ctx2, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	_, err := c.WithContext(ctx2).(*RiotClientV4).realAPICall("https://euw1.api.riotgames.com/lol/match/v4/matches/2", "GET", "")
+	if err == nil {
+		t.Errorf("Expected an error for the canceled call")
+	}
+
+	cancel1()
+	if err := <-done1; riotclient.KindOf(err) != riotclient.ErrorCanceled {
+		t.Errorf("Expected the call in flight to be canceled, got %v", err)
+	}
+
+	select {
+	case req := <-httpClient.requests:
+		t.Errorf("Expected the queued call to be dropped, but %s was requested", req.URL)
+	case <-time.After(10 * time.Millisecond):
+	}
+}
+
+func TestRiotClientV4_StopWithCallInFlight(t *testing.T) {
+	c, httpClient := newBlockingTestClient()
+	c.Start()
+
+	done := make(chan error)
+	go func() {
+		_, err := c.realAPICall("https://euw1.api.riotgames.com/lol/match/v4/matches/1", "GET", "")
+		done <- err
+	}()
+	<-httpClient.requests
+
+	stopped := make(chan struct{})
+	go func() {
+		c.Stop()
+		close(stopped)
+	}()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Errorf("Expected an error for the call in flight when stopping the client")
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Call in flight did not return when stopping the client")
+	}
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatalf("Stopping the client did not finish")
+	}
+}
+
+func TestRiotClientV4_MaxConcurrentRequests(t *testing.T) {
+	c, httpClient := newBlockingTestClient()
+	c.config.MaxConcurrentRequests = 2
+	c.Start()
+	defer c.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 3)
+	for i := 1; i <= 3; i++ {
+		go func(i int) {
+			_, err := c.WithContext(ctx).(*RiotClientV4).realAPICall(fmt.Sprintf("https://euw1.api.riotgames.com/lol/match/v4/matches/%d", i), "GET", "")
+			done <- err
+		}(i)
+	}
+
+	// Two calls of the same method are in flight at the same time, the third one has to wait for a free slot
+	for i := 0; i < 2; i++ {
+		select {
+		case <-httpClient.requests:
+		case <-time.After(time.Second):
+			t.Fatalf("Expected %d calls in flight, got %d", 2, i)
+		}
+	}
+	select {
+	case req := <-httpClient.requests:
+		t.Errorf("Expected at most 2 calls in flight, but %s was requested, too", req.URL)
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	cancel()
+	for i := 0; i < 3; i++ {
+		<-done
+	}
+}
+
+// statusHTTPClient responds with the given status codes in order, the last one is repeated
+type statusHTTPClient struct {
+	statusCodes []int
+	calls       int
+}
+
+func (c *statusHTTPClient) Get(url string) (resp *http.Response, err error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *statusHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	statusCode := c.statusCodes[len(c.statusCodes)-1]
+	if c.calls < len(c.statusCodes) {
+		statusCode = c.statusCodes[c.calls]
+	}
+	c.calls++
+	return &http.Response{StatusCode: statusCode, Header: http.Header{}, Body: ioutil.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestRiotClientV4_processRetries(t *testing.T) {
+	var delays []time.Duration
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return nil
+	}
+	defer func() {
+		sleep = sleepContext
+	}()
+
+	serverErrors := config.RetryPolicy{MaxAttempts: 3, BaseDelay: 100, MaxDelay: 1000}
+	tests := []struct {
+		name        string
+		statusCodes []int
+		wantKind    riotclient.ErrorKind
+		wantErr     bool
+		wantDelays  []time.Duration
+		wantStats   riotclient.RequestStats
+	}{
+		{"Success", []int{200}, riotclient.ErrorUnknown, false, nil,
+			riotclient.RequestStats{Requests: 1, Retries: map[string]uint64{}, Failures: map[string]uint64{}}},
+		{"Not Found is not retried", []int{404}, riotclient.ErrorNotFound, true, nil,
+			riotclient.RequestStats{Requests: 1, Retries: map[string]uint64{}, Failures: map[string]uint64{"Not Found": 1}}},
+		{"Forbidden is not retried", []int{403}, riotclient.ErrorForbidden, true, nil,
+			riotclient.RequestStats{Requests: 1, Retries: map[string]uint64{}, Failures: map[string]uint64{"Forbidden": 1}}},
+		{"Server error is retried", []int{503, 200}, riotclient.ErrorUnknown, false, []time.Duration{100 * time.Millisecond},
+			riotclient.RequestStats{Requests: 2, Retries: map[string]uint64{"Server Error": 1}, Failures: map[string]uint64{}}},
+		{"Server error gives up", []int{500}, riotclient.ErrorServerError, true, []time.Duration{100 * time.Millisecond, 200 * time.Millisecond},
+			riotclient.RequestStats{Requests: 3, Retries: map[string]uint64{"Server Error": 2}, Failures: map[string]uint64{"Server Error": 1}}},
+		{"Rate limited uses the default policy", []int{429}, riotclient.ErrorRateLimited, true, []time.Duration{0, 0, 0},
+			riotclient.RequestStats{Requests: 4, Retries: map[string]uint64{"Rate Limited": 3}, Failures: map[string]uint64{"Rate Limited": 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delays = nil
+			httpClient := &statusHTTPClient{statusCodes: tt.statusCodes}
+			rateLimit, _ := riotclientrl.New()
+			c := &RiotClientV4{
+				config:       config.RiotClient{RetryServerErrors: serverErrors},
+				httpClient:   httpClient,
+				log:          logging.Get("RiotClientV4"),
+				rateLimit:    rateLimit,
+				requestStats: newRequestStats(),
+			}
+
+			req, _ := http.NewRequest("GET", "https://euw1.api.riotgames.com/lol/match/v4/matches/1", nil)
+			work := workOrder{request: req, responseChan: make(workResponseChan, 1)}
+			c.process(work)
+			res := <-work.responseChan
+
+			if (res.err != nil) != tt.wantErr {
+				t.Errorf("process() error = %v, wantErr %v", res.err, tt.wantErr)
+			}
+			if got := riotclient.KindOf(res.err); got != tt.wantKind {
+				t.Errorf("process() error kind = %s, want %s", got, tt.wantKind)
+			}
+			if !reflect.DeepEqual(delays, tt.wantDelays) {
+				t.Errorf("process() waited %v before retries, want %v", delays, tt.wantDelays)
+			}
+			if got := c.RequestStats(); !reflect.DeepEqual(got, tt.wantStats) {
+				t.Errorf("RequestStats() = %+v, want %+v", got, tt.wantStats)
+			}
+		})
+	}
+}
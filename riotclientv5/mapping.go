@@ -0,0 +1,249 @@
+package riotclientv5
+
+import (
+	"strings"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// laneAndRole returns the v4 lane and role for a v5 participant.
+// The team position is preferred, as it is the position the player was assigned to in champion select.
+func laneAndRole(p *ParticipantDTO) (lane string, role string) {
+	switch strings.ToUpper(p.TeamPosition) {
+	case "TOP":
+		return "TOP", "SOLO"
+	case "JUNGLE":
+		return "JUNGLE", "NONE"
+	case "MIDDLE":
+		return "MIDDLE", "SOLO"
+	case "BOTTOM":
+		return "BOTTOM", "DUO_CARRY"
+	case "UTILITY":
+		return "BOTTOM", "DUO_SUPPORT"
+	}
+
+	switch strings.ToUpper(p.Role) {
+	case "CARRY":
+		return p.Lane, "DUO_CARRY"
+	case "SUPPORT":
+		return p.Lane, "DUO_SUPPORT"
+	default:
+		return p.Lane, p.Role
+	}
+}
+
+// perkStyle returns the perk style with the given description, e.g., primaryStyle
+func perkStyle(perks *PerksDTO, description string) PerkStyleDTO {
+	for _, style := range perks.Styles {
+		if style.Description == description {
+			return style
+		}
+	}
+	return PerkStyleDTO{}
+}
+
+// perkSelection returns the n-th selection of a perk style
+func perkSelection(style *PerkStyleDTO, n int) PerkStyleSelectionDTO {
+	if n < len(style.Selections) {
+		return style.Selections[n]
+	}
+	return PerkStyleSelectionDTO{}
+}
+
+func toParticipantStatsDTO(p *ParticipantDTO) riotclient.ParticipantStatsDTO {
+	primary := perkStyle(&p.Perks, "primaryStyle")
+	sub := perkStyle(&p.Perks, "subStyle")
+	perks := []PerkStyleSelectionDTO{
+		perkSelection(&primary, 0),
+		perkSelection(&primary, 1),
+		perkSelection(&primary, 2),
+		perkSelection(&primary, 3),
+		perkSelection(&sub, 0),
+		perkSelection(&sub, 1),
+	}
+
+	return riotclient.ParticipantStatsDTO{
+		ParticipantID:                  p.ParticipantID,
+		Win:                            p.Win,
+		Item0:                          p.Item0,
+		Item1:                          p.Item1,
+		Item2:                          p.Item2,
+		Item3:                          p.Item3,
+		Item4:                          p.Item4,
+		Item5:                          p.Item5,
+		Item6:                          p.Item6,
+		Kills:                          p.Kills,
+		Deaths:                         p.Deaths,
+		Assists:                        p.Assists,
+		LargestKillingSpree:            p.LargestKillingSpree,
+		LargestMultiKill:               p.LargestMultiKill,
+		KillingSprees:                  p.KillingSprees,
+		LongestTimeSpentLiving:         p.LongestTimeSpentLiving,
+		DoubleKills:                    p.DoubleKills,
+		TripleKills:                    p.TripleKills,
+		QuadraKills:                    p.QuadraKills,
+		PentaKills:                     p.PentaKills,
+		UnrealKills:                    p.UnrealKills,
+		TotalDamageDealt:               p.TotalDamageDealt,
+		MagicDamageDealt:               p.MagicDamageDealt,
+		PhysicalDamageDealt:            p.PhysicalDamageDealt,
+		TrueDamageDealt:                p.TrueDamageDealt,
+		LargestCriticalStrike:          p.LargestCriticalStrike,
+		TotalDamageDealtToChampions:    p.TotalDamageDealtToChampions,
+		MagicDamageDealtToChampions:    p.MagicDamageDealtToChampions,
+		PhysicalDamageDealtToChampions: p.PhysicalDamageDealtToChampions,
+		TrueDamageDealtToChampions:     p.TrueDamageDealtToChampions,
+		TotalHeal:                      p.TotalHeal,
+		TotalUnitsHealed:               p.TotalUnitsHealed,
+		DamageSelfMitigated:            p.DamageSelfMitigated,
+		DamageDealtToObjectives:        p.DamageDealtToObjectives,
+		DamageDealtToTurrets:           p.DamageDealtToTurrets,
+		VisionScore:                    p.VisionScore,
+		TimeCCingOthers:                p.TimeCCingOthers,
+		TotalDamageTaken:               p.TotalDamageTaken,
+		MagicalDamageTaken:             p.MagicDamageTaken,
+		PhysicalDamageTaken:            p.PhysicalDamageTaken,
+		TrueDamageTaken:                p.TrueDamageTaken,
+		GoldEarned:                     p.GoldEarned,
+		GoldSpent:                      p.GoldSpent,
+		TurretKills:                    p.TurretKills,
+		InhibitorKills:                 p.InhibitorKills,
+		TotalMinionsKilled:             p.TotalMinionsKilled,
+		NeutralMinionsKilled:           p.NeutralMinionsKilled,
+		TotalTimeCrowdControlDealt:     p.TotalTimeCCDealt,
+		ChampLevel:                     p.ChampLevel,
+		VisionWardsBoughtInGame:        p.VisionWardsBoughtInGame,
+		SightWardsBoughtInGame:         p.SightWardsBoughtInGame,
+		WardsPlaced:                    p.WardsPlaced,
+		WardsKilled:                    p.WardsKilled,
+		FirstBloodKill:                 p.FirstBloodKill,
+		FirstBloodAssist:               p.FirstBloodAssist,
+		FirstTowerKill:                 p.FirstTowerKill,
+		FirstTowerAssist:               p.FirstTowerAssist,
+		Perk0:                          perks[0].Perk,
+		Perk0Var1:                      perks[0].Var1,
+		Perk0Var2:                      perks[0].Var2,
+		Perk0Var3:                      perks[0].Var3,
+		Perk1:                          perks[1].Perk,
+		Perk1Var1:                      perks[1].Var1,
+		Perk1Var2:                      perks[1].Var2,
+		Perk1Var3:                      perks[1].Var3,
+		Perk2:                          perks[2].Perk,
+		Perk2Var1:                      perks[2].Var1,
+		Perk2Var2:                      perks[2].Var2,
+		Perk2Var3:                      perks[2].Var3,
+		Perk3:                          perks[3].Perk,
+		Perk3Var1:                      perks[3].Var1,
+		Perk3Var2:                      perks[3].Var2,
+		Perk3Var3:                      perks[3].Var3,
+		Perk4:                          perks[4].Perk,
+		Perk4Var1:                      perks[4].Var1,
+		Perk4Var2:                      perks[4].Var2,
+		Perk4Var3:                      perks[4].Var3,
+		Perk5:                          perks[5].Perk,
+		Perk5Var1:                      perks[5].Var1,
+		Perk5Var2:                      perks[5].Var2,
+		Perk5Var3:                      perks[5].Var3,
+		StatPerk0:                      p.Perks.StatPerks.Offense,
+		StatPerk1:                      p.Perks.StatPerks.Flex,
+		StatPerk2:                      p.Perks.StatPerks.Defense,
+		PerkPrimaryStyle:               primary.Style,
+		PerkSubStyle:                   sub.Style,
+	}
+}
+
+func toTeamStatsDTO(t *TeamDTO) riotclient.TeamStatsDTO {
+	win := "Fail"
+	if t.Win {
+		win = "Win"
+	}
+
+	team := riotclient.TeamStatsDTO{
+		TeamID:          t.TeamID,
+		Win:             win,
+		FirstBlood:      t.Objectives.Champion.First,
+		FirstTower:      t.Objectives.Tower.First,
+		FirstInhibitor:  t.Objectives.Inhibitor.First,
+		FirstBaron:      t.Objectives.Baron.First,
+		FirstDragon:     t.Objectives.Dragon.First,
+		FirstRiftHerald: t.Objectives.RiftHerald.First,
+		TowerKills:      t.Objectives.Tower.Kills,
+		InhibitorKills:  t.Objectives.Inhibitor.Kills,
+		BaronKills:      t.Objectives.Baron.Kills,
+		DragonKills:     t.Objectives.Dragon.Kills,
+		RiftHeraldKills: t.Objectives.RiftHerald.Kills,
+	}
+	for _, ban := range t.Bans {
+		team.Bans = append(team.Bans, riotclient.TeamStatsBansDTO{ChampionID: ban.ChampionID, PickTurn: ban.PickTurn})
+	}
+
+	return team
+}
+
+// toMatchDTO maps a v5 match to the v4 based riotclient.MatchDTO.
+// The v5 API does not provide the season, the highest achieved season tier and the account ids of the participants,
+// these fields stay empty. The PUUIDs of the participants are provided instead.
+func toMatchDTO(m *MatchDTO) *riotclient.MatchDTO {
+	gameDuration := m.Info.GameDuration
+	if m.Info.GameEndTimestamp == 0 {
+		// Older matches report the duration in milliseconds
+		gameDuration = gameDuration / 1000
+	}
+
+	match := riotclient.MatchDTO{
+		GameID:       m.Info.GameID,
+		PlatformID:   m.Info.PlatformID,
+		GameCreation: m.Info.GameCreation,
+		GameDuration: int(gameDuration),
+		QueueID:      m.Info.QueueID,
+		MapID:        m.Info.MapID,
+		GameVersion:  m.Info.GameVersion,
+		GameMode:     m.Info.GameMode,
+		GameType:     m.Info.GameType,
+	}
+
+	for i := range m.Info.Teams {
+		match.Teams = append(match.Teams, toTeamStatsDTO(&m.Info.Teams[i]))
+	}
+
+	for i := range m.Info.Participants {
+		p := &m.Info.Participants[i]
+		lane, role := laneAndRole(p)
+
+		match.Participants = append(match.Participants, riotclient.ParticipantDTO{
+			ParticipantID: p.ParticipantID,
+			TeamID:        p.TeamID,
+			ChampionID:    p.ChampionID,
+			Spell1ID:      p.Summoner1ID,
+			Spell2ID:      p.Summoner2ID,
+			Stats:         toParticipantStatsDTO(p),
+			Timeline: riotclient.ParticipantTimelineDTO{
+				ParticipantID: p.ParticipantID,
+				Lane:          lane,
+				Role:          role,
+			},
+		})
+
+		match.ParticipantIdentities = append(match.ParticipantIdentities, riotclient.ParticipantIdentityDTO{
+			ParticipantID: p.ParticipantID,
+			Player: riotclient.PlayerDTO{
+				PlatformID:        m.Info.PlatformID,
+				CurrentPlatformID: m.Info.PlatformID,
+				SummonerName:      p.SummonerName,
+				SummonerID:        p.SummonerID,
+				ProfileIcon:       p.ProfileIcon,
+				PUUID:             p.PUUID,
+			},
+		})
+	}
+
+	return &match
+}
+
+// toMatchTimelineDTO maps a v5 timeline to the v4 based riotclient.MatchTimelineDTO
+func toMatchTimelineDTO(t *TimelineDTO) *riotclient.MatchTimelineDTO {
+	return &riotclient.MatchTimelineDTO{
+		Frames:        t.Info.Frames,
+		FrameInterval: t.Info.FrameInterval,
+	}
+}
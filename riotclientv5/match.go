@@ -0,0 +1,241 @@
+package riotclientv5
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// MatchID returns the v5 match id for a game id played on a platform, e.g., EUW1_3827449823
+func MatchID(platformID string, gameID uint64) string {
+	return strings.ToUpper(platformID) + "_" + strconv.FormatUint(gameID, 10)
+}
+
+// ParseMatchID splits a v5 match id into platform id and game id
+func ParseMatchID(matchID string) (platformID string, gameID uint64, err error) {
+	parts := strings.Split(matchID, "_")
+	if len(parts) != 2 || len(parts[0]) == 0 {
+		return "", 0, fmt.Errorf("Invalid match id %s", matchID)
+	}
+
+	gameID, err = strconv.ParseUint(parts[1], 10, 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("Invalid game id in match id %s: %s", matchID, err)
+	}
+
+	return parts[0], gameID, nil
+}
+
+// queryString builds the query string for the given arguments, sorted by key
+func queryString(args map[string]string) string {
+	if len(args) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(args))
+	for k := range args {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	query := make([]string, 0, len(keys))
+	for _, k := range keys {
+		query = append(query, k+"="+args[k])
+	}
+
+	return "?" + strings.Join(query, "&")
+}
+
+// MatchByMatchID gets a match by its v5 match id
+func (c *RiotClientV5) MatchByMatchID(matchID string) (*MatchDTO, error) {
+	// Example: https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823
+	data, err := apiCall(c, c.regionalURL()+"/lol/match/"+c.config.APIVersion+"/matches/"+matchID, "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	match := MatchDTO{}
+	err = json.Unmarshal(data, &match)
+	if err != nil {
+		return nil, fmt.Errorf("MatchByMatchID error unmarshaling: %s", err)
+	} else if match.Info.GameID == 0 {
+		return nil, fmt.Errorf("Match GameID invalid, probably empty data")
+	}
+
+	return &match, nil
+}
+
+// MatchTimeLineByMatchID gets the timeline of a match by its v5 match id
+func (c *RiotClientV5) MatchTimeLineByMatchID(matchID string) (*TimelineDTO, error) {
+	// Example: https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823/timeline
+	data, err := apiCall(c, c.regionalURL()+"/lol/match/"+c.config.APIVersion+"/matches/"+matchID+"/timeline", "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	timeline := TimelineDTO{}
+	err = json.Unmarshal(data, &timeline)
+	if err != nil {
+		return nil, fmt.Errorf("MatchTimeLineByMatchID error unmarshaling: %s", err)
+	}
+
+	return &timeline, nil
+}
+
+// MatchIDsByPUUID gets the v5 match ids of the matches played by a Summoner identified by PUUID
+// args: List of arguments to the query. They are directly passed to the request.
+// Refer to https://developer.riotgames.com/apis#match-v5/GET_getMatchIdsByPUUID for details.
+func (c *RiotClientV5) MatchIDsByPUUID(PUUID string, args map[string]string) ([]string, error) {
+	// Example: https://europe.api.riotgames.com/lol/match/v5/matches/by-puuid/4bf9J3x.../ids?count=100&start=0
+	data, err := apiCall(c, c.regionalURL()+"/lol/match/"+c.config.APIVersion+"/matches/by-puuid/"+PUUID+"/ids"+queryString(args), "GET", "")
+	if err != nil {
+		return nil, err
+	}
+
+	var matchIDs []string
+	err = json.Unmarshal(data, &matchIDs)
+	if err != nil {
+		return nil, fmt.Errorf("MatchIDsByPUUID error unmarshaling: %s", err)
+	}
+
+	return matchIDs, nil
+}
+
+// MatchByID gets a match by its game id on the platform of the client and maps it to a riotclient.MatchDTO
+func (c *RiotClientV5) MatchByID(id uint64) (s *riotclient.MatchDTO, err error) {
+	match, err := c.MatchByMatchID(MatchID(c.config.Region, id))
+	if err != nil {
+		return nil, err
+	}
+
+	return toMatchDTO(match), nil
+}
+
+// MatchTimeLineByID gets the Match TimeLine for a certain match identified by its game id on the platform of the client
+func (c *RiotClientV5) MatchTimeLineByID(matchID uint64) (t *riotclient.MatchTimelineDTO, err error) {
+	timeline, err := c.MatchTimeLineByMatchID(MatchID(c.config.Region, matchID))
+	if err != nil {
+		return nil, err
+	}
+
+	return toMatchTimelineDTO(timeline), nil
+}
+
+// matchListArgs translates the v4 match list arguments to v5 arguments.
+// It returns the translated arguments, the start index and the number of requested matches (0 if not limited).
+func matchListArgs(args map[string]string) (v5Args map[string]string, start uint64, count uint64, err error) {
+	v5Args = make(map[string]string)
+
+	var end uint64
+	for k, v := range args {
+		switch k {
+		case "beginIndex", "endIndex":
+			n, err := strconv.ParseUint(v, 10, 64)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("Invalid %s %s: %s", k, v, err)
+			}
+			if k == "beginIndex" {
+				start = n
+			} else {
+				end = n
+			}
+		case "beginTime", "endTime":
+			// v4 uses epoch milliseconds, v5 epoch seconds
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				return nil, 0, 0, fmt.Errorf("Invalid %s %s: %s", k, v, err)
+			}
+			v5Args[strings.Replace(k, "begin", "start", 1)] = strconv.FormatInt(n/1000, 10)
+		case "queue":
+			if strings.Contains(v, ",") {
+				return nil, 0, 0, fmt.Errorf("Match lists of API v5 can only be filtered by one queue, got %s", v)
+			}
+			v5Args["queue"] = v
+		case "season":
+			// Not supported by API v5, matches of other seasons have to be dropped after fetching them
+		default:
+			return nil, 0, 0, fmt.Errorf("Match list argument %s is not supported by API v5", k)
+		}
+	}
+
+	if end > 0 {
+		if end < start {
+			return nil, 0, 0, fmt.Errorf("endIndex %d is smaller than beginIndex %d", end, start)
+		}
+		count = end - start
+		v5Args["count"] = strconv.FormatUint(count, 10)
+	}
+	v5Args["start"] = strconv.FormatUint(start, 10)
+
+	return v5Args, start, count, nil
+}
+
+// MultipleMatchlistQueues returns false, as match lists of API v5 can only be filtered by one queue per request
+func (c *RiotClientV5) MultipleMatchlistQueues() bool {
+	return false
+}
+
+// MatchesByAccountID gets the match list of a Summoner identified by AccountID
+// args: See MatchesByPUUID. As v5 match lists are requested by PUUID, this needs an additional Summoner API call.
+func (c *RiotClientV5) MatchesByAccountID(accountID string, args map[string]string) (s *riotclient.MatchlistDTO, err error) {
+	if _, _, _, err := matchListArgs(args); err != nil {
+		return nil, err
+	}
+
+	data, err := apiCall(c, c.platformURL()+"/lol/summoner/v4/summoners/by-account/"+accountID, "GET", "")
+	if err != nil {
+		return nil, err
+	}
+	summoner := riotclient.SummonerDTO{}
+	err = json.Unmarshal(data, &summoner)
+	if err != nil {
+		return nil, err
+	} else if len(summoner.PuuID) == 0 {
+		return nil, riotclient.NewError(riotclient.ErrorNotFound, "User does not exist")
+	}
+
+	return c.MatchesByPUUID(summoner.PuuID, args)
+}
+
+// MatchesByPUUID gets the match list of a Summoner identified by PUUID
+// args: The v4 arguments beginIndex, endIndex, beginTime, endTime and queue (only one) are supported and translated to API v5, season is ignored.
+// The v5 API does not report the total number of games. TotalGames is larger than EndIndex when there may be more matches.
+func (c *RiotClientV5) MatchesByPUUID(PUUID string, args map[string]string) (s *riotclient.MatchlistDTO, err error) {
+	v5Args, start, count, err := matchListArgs(args)
+	if err != nil {
+		return nil, err
+	}
+
+	matchIDs, err := c.MatchIDsByPUUID(PUUID, v5Args)
+	if err != nil {
+		return nil, err
+	}
+
+	matchList := riotclient.MatchlistDTO{
+		Matches:    make([]riotclient.MatchReferenceDTO, 0, len(matchIDs)),
+		StartIndex: int(start),
+		EndIndex:   int(start) + len(matchIDs),
+	}
+	for _, matchID := range matchIDs {
+		platformID, gameID, err := ParseMatchID(matchID)
+		if err != nil {
+			c.log.Warnln(err)
+			continue
+		}
+		matchList.Matches = append(matchList.Matches, riotclient.MatchReferenceDTO{
+			GameID:     int64(gameID),
+			PlatformID: platformID,
+		})
+	}
+
+	matchList.TotalGames = matchList.EndIndex
+	if count > 0 && uint64(len(matchIDs)) == count {
+		matchList.TotalGames += int(count)
+	}
+
+	return &matchList, nil
+}
@@ -0,0 +1,281 @@
+package riotclientv5
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+const testMatchJSON = `{
+	"metadata": {"dataVersion": "2", "matchId": "EUW1_3827449823", "participants": ["puuid1"]},
+	"info": {
+		"gameCreation": 1553000000000,
+		"gameDuration": 1830,
+		"gameEndTimestamp": 1553001900000,
+		"gameId": 3827449823,
+		"gameMode": "CLASSIC",
+		"gameType": "MATCHED_GAME",
+		"gameVersion": "11.14.385.9967",
+		"mapId": 11,
+		"platformId": "EUW1",
+		"queueId": 420,
+		"participants": [{
+			"participantId": 1,
+			"teamId": 100,
+			"championId": 12,
+			"summoner1Id": 4,
+			"summoner2Id": 14,
+			"puuid": "puuid1",
+			"summonerId": "sid1",
+			"summonerName": "Summoner1",
+			"profileIcon": 7,
+			"teamPosition": "UTILITY",
+			"lane": "BOTTOM",
+			"role": "SUPPORT",
+			"kills": 1,
+			"deaths": 2,
+			"assists": 15,
+			"win": true,
+			"totalTimeCCDealt": 120,
+			"perks": {
+				"statPerks": {"defense": 5002, "flex": 5008, "offense": 5005},
+				"styles": [
+					{"description": "primaryStyle", "style": 8400, "selections": [
+						{"perk": 8439, "var1": 1}, {"perk": 8463}, {"perk": 8473}, {"perk": 8242}
+					]},
+					{"description": "subStyle", "style": 8300, "selections": [{"perk": 8345}, {"perk": 8347}]}
+				]
+			}
+		}],
+		"teams": [{
+			"teamId": 100,
+			"win": true,
+			"bans": [{"championId": 157, "pickTurn": 1}],
+			"objectives": {"tower": {"first": true, "kills": 9}, "dragon": {"first": false, "kills": 2}}
+		}]
+	}
+}`
+
+func TestMatchID(t *testing.T) {
+	if got := MatchID("euw1", 3827449823); got != "EUW1_3827449823" {
+		t.Errorf("MatchID() = %s, want EUW1_3827449823", got)
+	}
+
+	platformID, gameID, err := ParseMatchID("EUW1_3827449823")
+	if err != nil || platformID != "EUW1" || gameID != 3827449823 {
+		t.Errorf("ParseMatchID() = %s, %d, %v", platformID, gameID, err)
+	}
+	for _, invalid := range []string{"", "EUW1", "_123", "EUW1_abc", "EUW1_1_2"} {
+		if _, _, err := ParseMatchID(invalid); err == nil {
+			t.Errorf("Expected error when parsing match id %s", invalid)
+		}
+	}
+}
+
+func TestRiotClientV5_MatchByID(t *testing.T) {
+	apiCall = (*RiotClientV5).mockAPICall
+	apiCallReturnErr = nil
+	apiCallReturnJSON = map[string][]byte{
+		"https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823": []byte(testMatchJSON),
+	}
+
+	client := newTestClient(t, "euw1")
+	match, err := client.MatchByID(3827449823)
+	if err != nil {
+		t.Fatalf("Could not get match: %s", err)
+	}
+
+	if match.GameID != 3827449823 || match.PlatformID != "EUW1" || match.QueueID != 420 || match.MapID != 11 ||
+		match.GameVersion != "11.14.385.9967" || match.GameDuration != 1830 {
+		t.Errorf("Match info not mapped correctly, got %+v", match)
+	}
+
+	if len(match.Participants) != 1 || len(match.ParticipantIdentities) != 1 {
+		t.Fatalf("Expected one participant, got %d", len(match.Participants))
+	}
+	participant := match.Participants[0]
+	if participant.ChampionID != 12 || participant.Spell1ID != 4 || participant.Spell2ID != 14 || participant.TeamID != 100 {
+		t.Errorf("Participant not mapped correctly, got %+v", participant)
+	}
+	if participant.Timeline.Lane != "BOTTOM" || participant.Timeline.Role != "DUO_SUPPORT" {
+		t.Errorf("Expected BOTTOM/DUO_SUPPORT, got %s/%s", participant.Timeline.Lane, participant.Timeline.Role)
+	}
+	stats := participant.Stats
+	if !stats.Win || stats.Kills != 1 || stats.Deaths != 2 || stats.Assists != 15 || stats.TotalTimeCrowdControlDealt != 120 {
+		t.Errorf("Participant stats not mapped correctly, got %+v", stats)
+	}
+	if stats.PerkPrimaryStyle != 8400 || stats.PerkSubStyle != 8300 ||
+		stats.Perk0 != 8439 || stats.Perk0Var1 != 1 || stats.Perk3 != 8242 || stats.Perk4 != 8345 || stats.Perk5 != 8347 ||
+		stats.StatPerk0 != 5005 || stats.StatPerk1 != 5008 || stats.StatPerk2 != 5002 {
+		t.Errorf("Perks not mapped correctly, got %+v", stats)
+	}
+
+	wantPlayer := riotclient.PlayerDTO{
+		PlatformID:        "EUW1",
+		CurrentPlatformID: "EUW1",
+		SummonerName:      "Summoner1",
+		SummonerID:        "sid1",
+		ProfileIcon:       7,
+		PUUID:             "puuid1",
+	}
+	if !reflect.DeepEqual(match.ParticipantIdentities[0].Player, wantPlayer) {
+		t.Errorf("Player = %+v, want %+v", match.ParticipantIdentities[0].Player, wantPlayer)
+	}
+
+	wantTeams := []riotclient.TeamStatsDTO{{
+		TeamID:      100,
+		Win:         "Win",
+		FirstTower:  true,
+		TowerKills:  9,
+		DragonKills: 2,
+		Bans:        []riotclient.TeamStatsBansDTO{{ChampionID: 157, PickTurn: 1}},
+	}}
+	if !reflect.DeepEqual(match.Teams, wantTeams) {
+		t.Errorf("Teams = %+v, want %+v", match.Teams, wantTeams)
+	}
+
+	if _, err := client.MatchByID(1); err == nil {
+		t.Errorf("Expected error for unknown match")
+	}
+
+	apiCallReturnJSON = map[string][]byte{
+		"https://europe.api.riotgames.com/lol/match/v5/matches/EUW1_3827449823": []byte(`{}`),
+	}
+	if _, err := client.MatchByID(3827449823); err == nil {
+		t.Errorf("Expected error for empty match data")
+	}
+}
+
+func TestRiotClientV5_MatchTimeLineByID(t *testing.T) {
+	apiCall = (*RiotClientV5).mockAPICall
+	apiCallReturnErr = nil
+	apiCallReturnJSON = map[string][]byte{
+		"https://americas.api.riotgames.com/lol/match/v5/matches/NA1_1234/timeline": []byte(`{
+			"metadata": {"matchId": "NA1_1234"},
+			"info": {"frameInterval": 60000, "gameId": 1234, "frames": [{"timestamp": 0}, {"timestamp": 60000}]}
+		}`),
+	}
+
+	client := newTestClient(t, "na1")
+	timeline, err := client.MatchTimeLineByID(1234)
+	if err != nil {
+		t.Fatalf("Could not get timeline: %s", err)
+	}
+	if timeline.FrameInterval != 60000 || len(timeline.Frames) != 2 || timeline.Frames[1].Timestamp != 60000 {
+		t.Errorf("Timeline not mapped correctly, got %+v", timeline)
+	}
+}
+
+func TestRiotClientV5_MatchesByAccountID(t *testing.T) {
+	apiCall = (*RiotClientV5).mockAPICall
+	apiCallReturnErr = nil
+	apiCallReturnJSON = map[string][]byte{
+		"https://euw1.api.riotgames.com/lol/summoner/v4/summoners/by-account/aid":                                                    []byte(`{"accountId": "aid", "puuid": "puuid1"}`),
+		"https://europe.api.riotgames.com/lol/match/v5/matches/by-puuid/puuid1/ids?count=2&queue=420&start=100&startTime=1553000000": []byte(`["EUW1_2", "EUW1_1"]`),
+		"https://europe.api.riotgames.com/lol/match/v5/matches/by-puuid/puuid1/ids?start=0":                                          []byte(`["EUW1_3", "invalid"]`),
+	}
+
+	client := newTestClient(t, "euw1")
+
+	tests := []struct {
+		name    string
+		args    map[string]string
+		want    *riotclient.MatchlistDTO
+		wantErr bool
+	}{
+		{
+			name: "Test 1 - Full page, there may be more matches",
+			args: map[string]string{"beginIndex": "100", "endIndex": "102", "queue": "420", "beginTime": "1553000000123"},
+			want: &riotclient.MatchlistDTO{
+				Matches: []riotclient.MatchReferenceDTO{
+					{GameID: 2, PlatformID: "EUW1"},
+					{GameID: 1, PlatformID: "EUW1"},
+				},
+				StartIndex: 100,
+				EndIndex:   102,
+				TotalGames: 104,
+			},
+		},
+		{
+			name: "Test 2 - No limits, invalid match ids are skipped",
+			args: map[string]string{},
+			want: &riotclient.MatchlistDTO{
+				Matches:    []riotclient.MatchReferenceDTO{{GameID: 3, PlatformID: "EUW1"}},
+				StartIndex: 0,
+				EndIndex:   2,
+				TotalGames: 2,
+			},
+		},
+		{
+			name: "Test 3 - Season is ignored",
+			args: map[string]string{"season": "12,13"},
+			want: &riotclient.MatchlistDTO{
+				Matches:    []riotclient.MatchReferenceDTO{{GameID: 3, PlatformID: "EUW1"}},
+				StartIndex: 0,
+				EndIndex:   2,
+				TotalGames: 2,
+			},
+		},
+		{
+			name:    "Test 4 - Unsupported argument",
+			args:    map[string]string{"champion": "120"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 5 - endIndex smaller than beginIndex",
+			args:    map[string]string{"beginIndex": "100", "endIndex": "10"},
+			wantErr: true,
+		},
+		{
+			name:    "Test 6 - Several queues",
+			args:    map[string]string{"queue": "420,440"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.MatchesByAccountID("aid", tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("MatchesByAccountID() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("MatchesByAccountID() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+
+	apiCallReturnErr = fmt.Errorf("API error")
+	if _, err := client.MatchesByAccountID("aid", nil); err == nil {
+		t.Errorf("Expected error when API call fails")
+	}
+}
+
+func TestRiotClientV5_MatchesByPUUID(t *testing.T) {
+	apiCall = (*RiotClientV5).mockAPICall
+	apiCallReturnErr = nil
+	apiCallPaths = nil
+	apiCallReturnJSON = map[string][]byte{
+		"https://europe.api.riotgames.com/lol/match/v5/matches/by-puuid/puuid1/ids?count=2&start=0": []byte(`["EUW1_2", "EUW1_1"]`),
+	}
+
+	client := newTestClient(t, "euw1")
+
+	got, err := client.MatchesByPUUID("puuid1", map[string]string{"beginIndex": "0", "endIndex": "2"})
+	if err != nil {
+		t.Fatalf("MatchesByPUUID() error = %v", err)
+	}
+	want := &riotclient.MatchlistDTO{
+		Matches:    []riotclient.MatchReferenceDTO{{GameID: 2, PlatformID: "EUW1"}, {GameID: 1, PlatformID: "EUW1"}},
+		StartIndex: 0,
+		EndIndex:   2,
+		TotalGames: 4,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("MatchesByPUUID() = %+v, want %+v", got, want)
+	}
+	if len(apiCallPaths) != 1 {
+		t.Errorf("MatchesByPUUID() made the API calls %v, want only the match list", apiCallPaths)
+	}
+}
@@ -0,0 +1,192 @@
+package riotclientv5
+
+import (
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// MetadataDTO contains the metadata of a v5 match or timeline
+type MetadataDTO struct {
+	DataVersion  string   `json:"dataVersion"`
+	MatchID      string   `json:"matchId"`
+	Participants []string `json:"participants"` // PUUIDs of the participants
+}
+
+// PerkStyleSelectionDTO contains one selected perk of a perk style
+type PerkStyleSelectionDTO struct {
+	Perk int `json:"perk"`
+	Var1 int `json:"var1"`
+	Var2 int `json:"var2"`
+	Var3 int `json:"var3"`
+}
+
+// PerkStyleDTO contains the selected perks of the primary or the sub style
+type PerkStyleDTO struct {
+	Description string                  `json:"description"` // primaryStyle or subStyle
+	Selections  []PerkStyleSelectionDTO `json:"selections"`
+	Style       int                     `json:"style"`
+}
+
+// PerkStatsDTO contains the selected stat perks
+type PerkStatsDTO struct {
+	Defense int `json:"defense"`
+	Flex    int `json:"flex"`
+	Offense int `json:"offense"`
+}
+
+// PerksDTO contains the perks (Runes Reforged) of a participant
+type PerksDTO struct {
+	StatPerks PerkStatsDTO   `json:"statPerks"`
+	Styles    []PerkStyleDTO `json:"styles"`
+}
+
+// ParticipantDTO contains match data specific to one player
+type ParticipantDTO struct {
+	Assists                        int      `json:"assists"`
+	BaronKills                     int      `json:"baronKills"`
+	ChampLevel                     int      `json:"champLevel"`
+	ChampionID                     int      `json:"championId"`
+	ChampionName                   string   `json:"championName"`
+	DamageDealtToObjectives        int      `json:"damageDealtToObjectives"`
+	DamageDealtToTurrets           int      `json:"damageDealtToTurrets"`
+	DamageSelfMitigated            int      `json:"damageSelfMitigated"`
+	Deaths                         int      `json:"deaths"`
+	DoubleKills                    int      `json:"doubleKills"`
+	DragonKills                    int      `json:"dragonKills"`
+	FirstBloodAssist               bool     `json:"firstBloodAssist"`
+	FirstBloodKill                 bool     `json:"firstBloodKill"`
+	FirstTowerAssist               bool     `json:"firstTowerAssist"`
+	FirstTowerKill                 bool     `json:"firstTowerKill"`
+	GoldEarned                     int      `json:"goldEarned"`
+	GoldSpent                      int      `json:"goldSpent"`
+	IndividualPosition             string   `json:"individualPosition"`
+	InhibitorKills                 int      `json:"inhibitorKills"`
+	Item0                          int      `json:"item0"`
+	Item1                          int      `json:"item1"`
+	Item2                          int      `json:"item2"`
+	Item3                          int      `json:"item3"`
+	Item4                          int      `json:"item4"`
+	Item5                          int      `json:"item5"`
+	Item6                          int      `json:"item6"`
+	KillingSprees                  int      `json:"killingSprees"`
+	Kills                          int      `json:"kills"`
+	Lane                           string   `json:"lane"`
+	LargestCriticalStrike          int      `json:"largestCriticalStrike"`
+	LargestKillingSpree            int      `json:"largestKillingSpree"`
+	LargestMultiKill               int      `json:"largestMultiKill"`
+	LongestTimeSpentLiving         int      `json:"longestTimeSpentLiving"`
+	MagicDamageDealt               int      `json:"magicDamageDealt"`
+	MagicDamageDealtToChampions    int      `json:"magicDamageDealtToChampions"`
+	MagicDamageTaken               int      `json:"magicDamageTaken"`
+	NeutralMinionsKilled           int      `json:"neutralMinionsKilled"`
+	ParticipantID                  int      `json:"participantId"`
+	PentaKills                     int      `json:"pentaKills"`
+	Perks                          PerksDTO `json:"perks"`
+	PhysicalDamageDealt            int      `json:"physicalDamageDealt"`
+	PhysicalDamageDealtToChampions int      `json:"physicalDamageDealtToChampions"`
+	PhysicalDamageTaken            int      `json:"physicalDamageTaken"`
+	ProfileIcon                    int      `json:"profileIcon"`
+	PUUID                          string   `json:"puuid"`
+	QuadraKills                    int      `json:"quadraKills"`
+	Role                           string   `json:"role"`
+	SightWardsBoughtInGame         int      `json:"sightWardsBoughtInGame"`
+	Summoner1ID                    int      `json:"summoner1Id"`
+	Summoner2ID                    int      `json:"summoner2Id"`
+	SummonerID                     string   `json:"summonerId"`
+	SummonerLevel                  int      `json:"summonerLevel"`
+	SummonerName                   string   `json:"summonerName"`
+	TeamID                         int      `json:"teamId"`
+	TeamPosition                   string   `json:"teamPosition"`
+	TimeCCingOthers                int      `json:"timeCCingOthers"`
+	TotalDamageDealt               int      `json:"totalDamageDealt"`
+	TotalDamageDealtToChampions    int      `json:"totalDamageDealtToChampions"`
+	TotalDamageTaken               int      `json:"totalDamageTaken"`
+	TotalHeal                      int      `json:"totalHeal"`
+	TotalMinionsKilled             int      `json:"totalMinionsKilled"`
+	TotalTimeCCDealt               int      `json:"totalTimeCCDealt"`
+	TotalUnitsHealed               int      `json:"totalUnitsHealed"`
+	TripleKills                    int      `json:"tripleKills"`
+	TrueDamageDealt                int      `json:"trueDamageDealt"`
+	TrueDamageDealtToChampions     int      `json:"trueDamageDealtToChampions"`
+	TrueDamageTaken                int      `json:"trueDamageTaken"`
+	TurretKills                    int      `json:"turretKills"`
+	UnrealKills                    int      `json:"unrealKills"`
+	VisionScore                    int      `json:"visionScore"`
+	VisionWardsBoughtInGame        int      `json:"visionWardsBoughtInGame"`
+	WardsKilled                    int      `json:"wardsKilled"`
+	WardsPlaced                    int      `json:"wardsPlaced"`
+	Win                            bool     `json:"win"`
+}
+
+// ObjectiveDTO contains information about one objective of a team
+type ObjectiveDTO struct {
+	First bool `json:"first"`
+	Kills int  `json:"kills"`
+}
+
+// ObjectivesDTO contains the objectives of a team
+type ObjectivesDTO struct {
+	Baron      ObjectiveDTO `json:"baron"`
+	Champion   ObjectiveDTO `json:"champion"`
+	Dragon     ObjectiveDTO `json:"dragon"`
+	Inhibitor  ObjectiveDTO `json:"inhibitor"`
+	RiftHerald ObjectiveDTO `json:"riftHerald"`
+	Tower      ObjectiveDTO `json:"tower"`
+}
+
+// BanDTO contains a ban in a match
+type BanDTO struct {
+	ChampionID int `json:"championId"`
+	PickTurn   int `json:"pickTurn"`
+}
+
+// TeamDTO contains Team information
+type TeamDTO struct {
+	Bans       []BanDTO      `json:"bans"`
+	Objectives ObjectivesDTO `json:"objectives"`
+	TeamID     int           `json:"teamId"`
+	Win        bool          `json:"win"`
+}
+
+// InfoDTO contains the actual match data
+type InfoDTO struct {
+	GameCreation       int64            `json:"gameCreation"`
+	GameDuration       int64            `json:"gameDuration"` // in seconds when GameEndTimestamp is set, in milliseconds otherwise
+	GameEndTimestamp   int64            `json:"gameEndTimestamp"`
+	GameID             int64            `json:"gameId"`
+	GameMode           string           `json:"gameMode"`
+	GameName           string           `json:"gameName"`
+	GameStartTimestamp int64            `json:"gameStartTimestamp"`
+	GameType           string           `json:"gameType"`
+	GameVersion        string           `json:"gameVersion"`
+	MapID              int              `json:"mapId"`
+	Participants       []ParticipantDTO `json:"participants"`
+	PlatformID         string           `json:"platformId"`
+	QueueID            int              `json:"queueId"`
+	Teams              []TeamDTO        `json:"teams"`
+}
+
+// MatchDTO contains the complete match data as returned by the v5 Match API
+type MatchDTO struct {
+	Metadata MetadataDTO `json:"metadata"`
+	Info     InfoDTO     `json:"info"`
+}
+
+// TimelineParticipantDTO maps the participant ids used in a timeline to PUUIDs
+type TimelineParticipantDTO struct {
+	ParticipantID int    `json:"participantId"`
+	PUUID         string `json:"puuid"`
+}
+
+// TimelineInfoDTO contains the actual timeline data. The frames did not change compared to v4.
+type TimelineInfoDTO struct {
+	FrameInterval int                        `json:"frameInterval"`
+	Frames        []riotclient.MatchFrameDTO `json:"frames"`
+	GameID        int64                      `json:"gameId"`
+	Participants  []TimelineParticipantDTO   `json:"participants"`
+}
+
+// TimelineDTO contains a timeline for events in a match as returned by the v5 Match API
+type TimelineDTO struct {
+	Metadata MetadataDTO     `json:"metadata"`
+	Info     TimelineInfoDTO `json:"info"`
+}
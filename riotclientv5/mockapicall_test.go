@@ -0,0 +1,21 @@
+package riotclientv5
+
+import "fmt"
+
+// apiCallReturnJSON holds the JSON returned by the mock API call per requested path
+var apiCallReturnJSON map[string][]byte
+var apiCallReturnErr error
+
+var apiCallPaths []string
+
+func (c *RiotClientV5) mockAPICall(path string, method string, body string) (r []byte, e error) {
+	apiCallPaths = append(apiCallPaths, path)
+
+	if apiCallReturnErr != nil {
+		return nil, apiCallReturnErr
+	}
+	if data, ok := apiCallReturnJSON[path]; ok {
+		return data, nil
+	}
+	return nil, fmt.Errorf("Unexpected API call %s", path)
+}
@@ -0,0 +1,133 @@
+// Package riotclientv5 provides the Riot API client for API version v5.
+// Only the Match API changed with v5, all other calls are handled by the embedded v4 client.
+// Matches are fetched from the regional routing hosts (europe, americas, asia) and mapped to the
+// v4 based riotclient types, so that consumers of riotclient.MatchDTO keep working.
+package riotclientv5
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/logging"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclientv4"
+
+	riotclientdd "git.abyle.org/hps/alolstats/riotclient/datadragon"
+	riotclientrl "git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+var apiCall = (*RiotClientV5).realAPICall
+
+// regionalRouting maps the platform routing values to the regional routing values used by the v5 Match API
+var regionalRouting = map[string]string{
+	"br1":  "americas",
+	"la1":  "americas",
+	"la2":  "americas",
+	"na1":  "americas",
+	"oc1":  "americas",
+	"jp1":  "asia",
+	"kr":   "asia",
+	"eun1": "europe",
+	"euw1": "europe",
+	"ru":   "europe",
+	"tr1":  "europe",
+}
+
+// RiotClientV5 Riot LoL API client
+type RiotClientV5 struct {
+	*riotclientv4.RiotClientV4
+
+	config  config.RiotClient
+	log     *logrus.Entry
+	routing string
+}
+
+func checkConfig(cfg config.RiotClient) error {
+	if cfg.APIVersion != "v5" {
+		return fmt.Errorf("APIVersion is not correct, must be v5")
+	}
+	if len(cfg.Key) == 0 && len(cfg.Keys) == 0 {
+		return fmt.Errorf("Key and Keys are empty, check config file")
+	}
+	if len(cfg.Region) == 0 {
+		return fmt.Errorf("Region is empty, check config file")
+	}
+	if _, ok := regionalRouting[strings.ToLower(cfg.Region)]; !ok {
+		return fmt.Errorf("Region %s has no known regional routing value, check config file", cfg.Region)
+	}
+	return nil
+}
+
+// NewClient creates a new Riot LoL API client
+func NewClient(httpClient *http.Client, cfg config.RiotClient,
+	ddragon *riotclientdd.RiotClientDD,
+	rateLimit *riotclientrl.RiotClientRL) (*RiotClientV5, error) {
+	err := checkConfig(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg.Region = strings.ToLower(cfg.Region)
+
+	v4Config := cfg
+	v4Config.APIVersion = "v4"
+	v4Client, err := riotclientv4.NewClient(httpClient, v4Config, ddragon, rateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &RiotClientV5{
+		RiotClientV4: v4Client,
+
+		config:  cfg,
+		log:     logging.Get(fmt.Sprintf("RiotClientV5 [%s]", cfg.Region)),
+		routing: regionalRouting[cfg.Region],
+	}
+
+	return c, nil
+}
+
+// WithPriority returns a client which does all its API calls with the given priority.
+// It shares workers, work queues and rate limits with c.
+func (c *RiotClientV5) WithPriority(priority riotclient.Priority) riotclient.Client {
+	return c.withV4Client(c.RiotClientV4.WithPriority(priority))
+}
+
+// WithContext returns a client which does all its API calls with the given context.
+// It shares workers, work queues and rate limits with c.
+func (c *RiotClientV5) WithContext(ctx context.Context) riotclient.Client {
+	return c.withV4Client(c.RiotClientV4.WithContext(ctx))
+}
+
+// withV4Client returns a copy of c using the given v4 client
+func (c *RiotClientV5) withV4Client(v4Client riotclient.Client) *RiotClientV5 {
+	client := *c
+	client.RiotClientV4 = v4Client.(*riotclientv4.RiotClientV4)
+	return &client
+}
+
+func (c *RiotClientV5) realAPICall(path string, method string, body string) ([]byte, error) {
+	return c.RiotClientV4.APICall(path, method, body)
+}
+
+// platformURL returns the base URL for calls to the platform the client is configured for, or the configured BaseURL
+func (c *RiotClientV5) platformURL() string {
+	if len(c.config.BaseURL) > 0 {
+		return strings.TrimSuffix(c.config.BaseURL, "/")
+	}
+	return "https://" + c.config.Region + ".api.riotgames.com"
+}
+
+// regionalURL returns the base URL for calls which use the regional routing value, e.g., europe for euw1,
+// or the configured BaseURL
+func (c *RiotClientV5) regionalURL() string {
+	if len(c.config.BaseURL) > 0 {
+		return strings.TrimSuffix(c.config.BaseURL, "/")
+	}
+	return "https://" + c.routing + ".api.riotgames.com"
+}
@@ -0,0 +1,117 @@
+package riotclientv5
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/riotclient/datadragon"
+	"git.abyle.org/hps/alolstats/riotclient/ratelimit"
+)
+
+func newTestClient(t *testing.T, region string) *RiotClientV5 {
+	httpClient := &http.Client{}
+	ddragon, _ := riotclientdd.New(httpClient, config.RiotClient{})
+	rateLimit, _ := riotclientrl.New()
+	client, err := NewClient(httpClient, config.RiotClient{APIVersion: "v5", Key: "abcd", Region: region}, ddragon, rateLimit)
+	if err != nil || client == nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	return client
+}
+
+func TestNewClient(t *testing.T) {
+	httpClient := &http.Client{}
+	ddragon, _ := riotclientdd.New(httpClient, config.RiotClient{})
+	rateLimit, _ := riotclientrl.New()
+	client, err := NewClient(httpClient, config.RiotClient{APIVersion: "v4", Key: "abcd", Region: "euw1"}, ddragon, rateLimit)
+	if err == nil || client != nil {
+		t.Fatalf("Could get a new client even though APIVersion is wrong")
+	}
+	client, err = NewClient(httpClient, config.RiotClient{APIVersion: "v5", Region: "euw1"}, ddragon, rateLimit)
+	if err == nil || client != nil {
+		t.Fatalf("Could get a new client even though Key is missing from config")
+	}
+	client, err = NewClient(httpClient, config.RiotClient{APIVersion: "v5", Key: "abcd"}, ddragon, rateLimit)
+	if err == nil || client != nil {
+		t.Fatalf("Could get a new client even though Region is missing from config")
+	}
+	client, err = NewClient(httpClient, config.RiotClient{APIVersion: "v5", Key: "abcd", Region: "xx1"}, ddragon, rateLimit)
+	if err == nil || client != nil {
+		t.Fatalf("Could get a new client even though Region is unknown")
+	}
+	client, err = NewClient(httpClient, config.RiotClient{APIVersion: "v5", Keys: []string{"abcd", "efgh"}, Region: "euw1"}, ddragon, rateLimit)
+	if err != nil || client == nil {
+		t.Fatalf("Could not get a new client with Keys instead of Key")
+	}
+
+	tests := []struct {
+		region       string
+		wantPlatform string
+		wantRegional string
+	}{
+		{"EUW1", "https://euw1.api.riotgames.com", "https://europe.api.riotgames.com"},
+		{"na1", "https://na1.api.riotgames.com", "https://americas.api.riotgames.com"},
+		{"kr", "https://kr.api.riotgames.com", "https://asia.api.riotgames.com"},
+	}
+	for _, tt := range tests {
+		client := newTestClient(t, tt.region)
+		if got := client.platformURL(); got != tt.wantPlatform {
+			t.Errorf("platformURL() for %s = %s, want %s", tt.region, got, tt.wantPlatform)
+		}
+		if got := client.regionalURL(); got != tt.wantRegional {
+			t.Errorf("regionalURL() for %s = %s, want %s", tt.region, got, tt.wantRegional)
+		}
+	}
+
+	client, err = NewClient(httpClient, config.RiotClient{APIVersion: "v5", Key: "abcd", Region: "euw1", BaseURL: "http://127.0.0.1:8081/"}, ddragon, rateLimit)
+	if err != nil {
+		t.Fatalf("Could not get a new client: %s", err)
+	}
+	if got := client.platformURL(); got != "http://127.0.0.1:8081" {
+		t.Errorf("platformURL() with BaseURL = %s, want http://127.0.0.1:8081", got)
+	}
+	if got := client.regionalURL(); got != "http://127.0.0.1:8081" {
+		t.Errorf("regionalURL() with BaseURL = %s, want http://127.0.0.1:8081", got)
+	}
+}
+
+func TestRiotClientV5_WithPriority(t *testing.T) {
+	client := newTestClient(t, "euw1")
+	background, ok := client.WithPriority(riotclient.PriorityBackground).(*RiotClientV5)
+	if !ok {
+		t.Fatalf("Expected a RiotClientV5 with priority")
+	}
+	if background.RiotClientV4 == client.RiotClientV4 {
+		t.Errorf("Expected a new v4 client with priority")
+	}
+	if background.regionalURL() != client.regionalURL() {
+		t.Errorf("Expected the same regional routing for the client with priority")
+	}
+
+	client.Start()
+	defer client.Stop()
+	if !background.IsRunning() {
+		t.Errorf("Expected the client with priority to share the workers of the client")
+	}
+}
+
+func TestRiotClientV5_WithContext(t *testing.T) {
+	apiCall = (*RiotClientV5).realAPICall
+	client := newTestClient(t, "euw1")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	canceled, ok := client.WithContext(ctx).(*RiotClientV5)
+	if !ok {
+		t.Fatalf("Expected a RiotClientV5 with context")
+	}
+
+	client.Start()
+	defer client.Stop()
+	if _, err := canceled.MatchByMatchID("EUW1_1"); err == nil {
+		t.Errorf("Expected an error for a canceled context")
+	}
+}
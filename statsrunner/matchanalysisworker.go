@@ -82,29 +82,6 @@ type championCounters struct {
 type championsCounters map[int]championCounters            // [id], e.g., 1, 10, 43, ...
 type championsCountersPerTier map[string]championsCounters // [tier], e.g., "GOLD", "SILVER", "UNRANKED"
 
-func determineMatchTier(participants []riotclient.ParticipantDTO) string {
-	tierCounts := make(map[string]uint64)
-	for _, participant := range participants {
-		currentTier := strings.ToUpper(strings.TrimSpace(participant.HighestAchievedSeasonTier))
-		tierCounts[currentTier]++
-	}
-
-	var maxTier string
-	maxCounts := uint64(0)
-	for tier, cnt := range tierCounts {
-		if cnt > maxCounts {
-			maxTier = tier
-			maxCounts = cnt
-		}
-	}
-
-	if maxTier == "" {
-		return "UNRANKED"
-	}
-
-	return maxTier
-}
-
 func (sr *StatsRunner) newChampionsCounters(champions riotclient.ChampionsList, gameVersion string) championsCounters {
 	champsCounters := make(championsCounters)
 	for _, champ := range champions {
@@ -264,7 +241,7 @@ func (sr *StatsRunner) matchAnalysisWorker() {
 					totalGamesForGameVersion := uint64(0)
 					totalGamesForGameVersionTier := make(map[string]uint64)
 
-					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(majorMinor, mapID, queueID)
+					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(sr.config.PlatformID, majorMinor, mapID, queueID)
 					if err != nil {
 						sr.log.Errorf("Error performing matchAnalysisWorker calculation for Game Version %s: %s", gameVersion, err)
 						continue
@@ -290,7 +267,7 @@ func (sr *StatsRunner) matchAnalysisWorker() {
 
 						totalGamesForGameVersion++
 
-						matchTier := determineMatchTier(currentMatch.Participants)
+						matchTier := riotclient.MatchTier(currentMatch.Participants)
 						totalGamesForGameVersionTier[matchTier]++
 
 						// Champion Picks
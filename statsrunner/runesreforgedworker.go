@@ -60,7 +60,7 @@ func (sr *StatsRunner) runesReforgedWorker() {
 
 					sr.log.Infof("Calculation of runesReforgedWorker for Game Version %s and Queue %s started", gameVersion, queue)
 
-					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(majorMinor, mapID, queueID)
+					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(sr.config.PlatformID, majorMinor, mapID, queueID)
 					if err != nil {
 						sr.log.Errorf("Error performing runesReforgedWorker calculation for Game Version %s: %s", gameVersion, err)
 						continue
@@ -116,7 +116,7 @@ func (sr *StatsRunner) summonerSpellsWorker() {
 					totalGamesForGameVersion := uint64(0)
 					totalGamesForGameVersionTier := make(map[string]uint64)
 
-					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(majorMinor, mapID, queueID)
+					cur, err := sr.storage.GetMatchesCursorByGameVersionMapQueueID(sr.config.PlatformID, majorMinor, mapID, queueID)
 					if err != nil {
 						sr.log.Errorf("Error performing summonerSpellsWorker calculation for Game Version %s: %s", gameVersion, err)
 						continue
@@ -143,7 +143,7 @@ func (sr *StatsRunner) summonerSpellsWorker() {
 
 						totalGamesForGameVersion++
 
-						matchTier := determineMatchTier(currentMatch.Participants)
+						matchTier := riotclient.MatchTier(currentMatch.Participants)
 						totalGamesForGameVersionTier[matchTier]++
 
 						for _, participant := range currentMatch.Participants {
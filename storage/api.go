@@ -1,27 +1,40 @@
 package storage
 
-import "git.abyle.org/hps/alolstats/api"
+import (
+	"net/http"
+
+	"git.abyle.org/hps/alolstats/api"
+)
+
+// withRequestContext returns a handler which runs endpoint on a Storage bound to the context of the request,
+// so that Riot API calls are canceled when the client disconnects
+func (s *Storage) withRequestContext(endpoint func(*Storage, http.ResponseWriter, *http.Request)) func(http.ResponseWriter, *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		endpoint(s.WithContext(r.Context()), w, r)
+	}
+}
 
 func (s *Storage) registerAPIChampions(api *api.API) {
-	api.AttachModuleGet("/champions", s.championsEndpoint)
+	api.AttachModuleGet("/champions", s.withRequestContext((*Storage).championsEndpoint))
 
-	api.AttachModuleGet("/champion/bykey", s.championByKeyEndpoint)
-	api.AttachModuleGet("/champion/byid", s.championByIDEndpoint)
+	api.AttachModuleGet("/champion/bykey", s.withRequestContext((*Storage).championByKeyEndpoint))
+	api.AttachModuleGet("/champion/byid", s.withRequestContext((*Storage).championByIDEndpoint))
 
-	api.AttachModuleGet("/champion-rotations", s.freeRotationEndpoint)
+	api.AttachModuleGet("/champion-rotations", s.withRequestContext((*Storage).freeRotationEndpoint))
 }
 
 func (s *Storage) registerAPIMatch(api *api.API) {
-	api.AttachModuleGet("/match", s.getMatchEndpoint)
+	api.AttachModuleGet("/match", s.withRequestContext((*Storage).getMatchEndpoint))
 }
 
 func (s *Storage) registerAPISpectator(api *api.API) {
-	api.AttachModuleGet("/active-game", s.getActiveGameBySummonerNameEndpoint)
-	api.AttachModuleGet("/featured-games", s.getFeaturedGamesEndpoint)
+	api.AttachModuleGet("/active-game", s.withRequestContext((*Storage).getActiveGameBySummonerNameEndpoint))
+	api.AttachModuleGet("/featured-games", s.withRequestContext((*Storage).getFeaturedGamesEndpoint))
 }
 
 func (s *Storage) registerAPISummoner(api *api.API) {
-	api.AttachModuleGet("/summoner/byname", s.summonerByNameEndpoint)
+	api.AttachModuleGet("/summoner/byname", s.withRequestContext((*Storage).summonerByNameEndpoint))
+	api.AttachModuleGet("/summoner/mastery", s.withRequestContext((*Storage).summonerMasteryEndpoint))
 }
 
 func (s *Storage) registerAPIItems(api *api.API) {
@@ -34,6 +47,7 @@ func (s *Storage) registerAPISummonerSpells(api *api.API) {
 
 func (s *Storage) registerAPIStorage(api *api.API) {
 	api.AttachModuleGet("/storage/summary", s.storageSummaryEndpoint)
+	api.AttachModuleGet("/riotclient/stats", s.riotClientStatsEndpoint)
 }
 
 func (s *Storage) registerAPIStats(api *api.API) {
@@ -12,6 +12,9 @@ type BackendChampion interface {
 	GetChampionsTimeStamp() time.Time
 
 	StoreChampions(championsList riotclient.ChampionsList) error
+
+	GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error)
+	StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error
 }
 
 // BackendFreeRotation defines an interface to store/retrieve the Champions Free Rotation from Storage Backend
@@ -22,6 +25,14 @@ type BackendFreeRotation interface {
 	StoreFreeRotation(freeRotation *riotclient.FreeRotation) error
 }
 
+// BackendChampionMastery defines an interface to store/retrieve the Champion Masteries of Summoners from Storage Backend
+type BackendChampionMastery interface {
+	GetChampionMasteries(summonerID string) (*ChampionMasteries, error)
+	GetChampionMasteriesTimeStamp(summonerID string) time.Time
+
+	StoreChampionMasteries(masteries *ChampionMasteries) error
+}
+
 // BackendSummonerSpells defines an interface to store/retrieve Summoner Spells data from Storage Backend
 type BackendSummonerSpells interface {
 	GetSummonerSpells(gameVersion, language string) (riotclient.SummonerSpellsList, error)
@@ -45,10 +56,11 @@ type BackendItems interface {
 
 // BackendLeague defines an interface to store/retrieve League data from Storage Backend
 type BackendLeague interface {
-	GetLeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error)
-	GetLeagueByQueueTimeStamp(league string, queue string) (time.Time, error)
+	GetLeagueByQueue(region string, tier string, queue string) (*League, error)
+	GetLeagueByQueueTimeStamp(region string, tier string, queue string) (time.Time, error)
+	GetLeagueHistoryByQueue(region string, tier string, queue string, from time.Time, to time.Time) ([]League, error)
 
-	StoreLeague(*riotclient.LeagueListDTO) error
+	StoreLeague(*League) error
 
 	GetLeaguesForSummoner(summonerName string) (*SummonerLeagues, error)
 	GetLeaguesForSummonerTimeStamp(summonerName string) (time.Time, error)
@@ -61,11 +73,12 @@ type BackendLeague interface {
 
 // BackendMatch defines an interface to store/retrieve Match data from Storage Backend
 // Matches have no TimeStamp as they are always valid
+// Matches are identified by platform and match id, as match ids are only unique per platform
 type BackendMatch interface {
-	GetMatch(matchID uint64) (*riotclient.MatchDTO, error)
+	GetMatch(platformID string, matchID uint64) (*riotclient.MatchDTO, error)
 	StoreMatch(data *riotclient.MatchDTO) error
 
-	GetMatchTimeLine(matchID uint64) (*riotclient.MatchTimelineDTO, error)
+	GetMatchTimeLine(platformID string, matchID uint64) (*riotclient.MatchTimelineDTO, error)
 	StoreMatchTimeLine(match *riotclient.MatchDTO, data *riotclient.MatchTimelineDTO) error
 
 	// Specialized fetching functions
@@ -73,11 +86,11 @@ type BackendMatch interface {
 	GetMatchesByGameVersionChampionIDMapQueue(gameVersion string, championID uint64, mapID uint64, queue uint64) (*riotclient.Matches, error)
 	GetMatchesByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (*riotclient.Matches, error)
 
-	// Cursor fetching functions
-	GetMatchesCursorByGameVersion(gameVersion string) (QueryCursor, error)
-	GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error)
-	GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error)
-	GetMatchesCursorByGameVersionMapQueueID(gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error)
+	// Cursor fetching functions, an empty platformID returns matches from all platforms
+	GetMatchesCursorByGameVersion(platformID string, gameVersion string) (QueryCursor, error)
+	GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error)
+	GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error)
+	GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error)
 }
 
 // BackendSummoner defines an interface to store/retrieve Summoner data from Storage Backend
@@ -126,14 +139,25 @@ type BackendMisc interface {
 	StoreKnownGameVersions(gameVersions *GameVersions) error
 }
 
+// BackendCrawlFrontier defines an interface to store/retrieve the crawl frontier of the FetchRunner from Storage Backend
+// Crawl entries are identified by region and account id
+type BackendCrawlFrontier interface {
+	GetCrawlEntry(region string, accountID string) (*CrawlEntry, error)
+	GetCrawlEntries(region string, minPriority int, crawledBefore time.Time, limit int) ([]CrawlEntry, error)
+
+	StoreCrawlEntry(entry *CrawlEntry) error
+}
+
 // Backend defines an interface to store/retrieve data from Storage Backend
 type Backend interface {
 	Connect() error
+	Close() error
 
 	BackendChampion
 	BackendFreeRotation
 	BackendMatch
 	BackendSummoner
+	BackendChampionMastery
 
 	BackendItems
 	BackendRunesReforged
@@ -146,4 +170,6 @@ type Backend interface {
 	BackendStats
 
 	BackendMisc
+
+	BackendCrawlFrontier
 }
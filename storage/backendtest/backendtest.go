@@ -0,0 +1,139 @@
+// Package backendtest provides a conformance test suite for storage.Backend implementations.
+//
+// A backend package runs the suite from one of its tests by handing over a Factory
+// which returns new, empty and connected Backends:
+//
+//	func TestConformance(t *testing.T) {
+//		backendtest.Run(t, func(t *testing.T) (storage.Backend, func()) {
+//			backend, _ := NewBackend()
+//			return backend, func() {}
+//		})
+//	}
+//
+// The suite defines the contract every Backend has to fulfill:
+//
+//   - Getters for single documents (Champion Details, Summoners, Matches, TimeLines, Leagues, statistics) return an error when nothing is stored.
+//     Getters for lists (Champions, Items, Runes Reforged, Summoner Spells) and for the Free Rotation and known game versions
+//     return an empty value and no error instead.
+//   - TimeStamp getters return the zero time.Time when nothing is stored, otherwise the timestamp of the stored data.
+//     For lists it is the oldest timestamp of all elements.
+//   - Matches and Match TimeLines are unique per game id and platform id. Storing them a second time returns an error
+//     and does not alter the stored data. They are looked up by platform id (case-insensitive) and game id.
+//   - Summoners are unique per name, Summoner ID, Account ID and PUUID. Storing a Summoner replaces all stored Summoners
+//     which share any of those, e.g., after a name change only the new name can be found. Names are looked up case-insensitive.
+//   - Summoner Leagues are unique per Summoner name and Summoner ID, with the same replace rule as for Summoners.
+//   - Champion Masteries are unique per Summoner ID and are replaced when stored again.
+//   - League snapshots are unique per region, tier, queue and timestamp. Storing a snapshot keeps all older ones,
+//     only a snapshot with the same timestamp is replaced. Tiers are looked up case-insensitive. The league getters
+//     return the newest snapshot, the history returns the snapshots within the requested time range (including both ends)
+//     sorted from oldest to newest.
+//   - Champions are unique per key, Champion Details, Items, Runes Reforged and Summoner Spells per id, game version and language.
+//     Storing them again updates the stored elements (upsert).
+//   - Statistics are unique per champion id, game version, tier and queue (summaries per game version, tier and queue).
+//     Storing them again replaces the stored statistics (upsert).
+//   - The Free Rotation and the known game versions are single documents which are replaced when stored again.
+//   - Crawl entries are unique per region and account id and are replaced when stored again. They are listed per region
+//     with at least the requested priority and a last crawl time before the requested one, ordered as defined by
+//     storage.CrawlEntryLess. A limit of 0 returns all of them.
+//   - Match queries and cursors interpret the game version as a regular expression which has to match at the beginning
+//     of the stored game version, e.g., "9\\.5\\." matches "9.5.263.1", but not "9.50.1.1". Queue ranges include both ends.
+//     Cursors can be restricted to a platform (case-insensitive), an empty platform id returns Matches from all platforms.
+//     Every matching Match is returned exactly once. Cursors return the Matches sorted by ascending game id (and platform id
+//     for the same game id), the order of the other match queries is not specified.
+//
+// All timestamps used by the suite have millisecond precision, as this is what MongoDB stores.
+package backendtest
+
+import (
+	"sort"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// Factory returns a new, empty and connected Backend and a function which cleans it up after the test
+type Factory func(t *testing.T) (storage.Backend, func())
+
+// Run runs the conformance test suite against Backends returned by newBackend.
+// Every sub test gets its own Backend.
+func Run(t *testing.T, newBackend Factory) {
+	tests := []struct {
+		name string
+		test func(t *testing.T, backend storage.Backend)
+	}{
+		{"Champions", testChampions},
+		{"ChampionDetails", testChampionDetails},
+		{"FreeRotation", testFreeRotation},
+		{"SummonerSpells", testSummonerSpells},
+		{"RunesReforged", testRunesReforged},
+		{"Items", testItems},
+		{"KnownGameVersions", testKnownGameVersions},
+		{"Summoners", testSummoners},
+		{"SummonerLeagues", testSummonerLeagues},
+		{"ChampionMasteries", testChampionMasteries},
+		{"Leagues", testLeagues},
+		{"Matches", testMatches},
+		{"MatchTimeLines", testMatchTimeLines},
+		{"MatchQueries", testMatchQueries},
+		{"MatchCursors", testMatchCursors},
+		{"ChampionStats", testChampionStats},
+		{"ChampionStatsSummary", testChampionStatsSummary},
+		{"ItemStats", testItemStats},
+		{"SummonerSpellsStats", testSummonerSpellsStats},
+		{"RunesReforgedStats", testRunesReforgedStats},
+		{"CrawlFrontier", testCrawlFrontier},
+	}
+
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			backend, cleanup := newBackend(t)
+			defer cleanup()
+			tt.test(t, backend)
+		})
+	}
+}
+
+// timestamp returns a fixed point in time with millisecond precision, shifted by the given number of minutes
+func timestamp(minutes int) time.Time {
+	return time.Date(2019, 3, 14, 12, 0, 0, 0, time.UTC).Add(time.Duration(minutes) * time.Minute)
+}
+
+func checkTimeStamp(t *testing.T, what string, got time.Time, want time.Time) {
+	t.Helper()
+	if !got.Equal(want) {
+		t.Errorf("%s: got timestamp %v, want %v", what, got, want)
+	}
+}
+
+func sortedGameIDs(gameIDs []int64) []int64 {
+	sorted := append([]int64(nil), gameIDs...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
+
+func checkGameIDs(t *testing.T, what string, got []int64, want []int64) {
+	t.Helper()
+
+	seen := make(map[int64]bool)
+	for _, id := range got {
+		if seen[id] {
+			t.Errorf("%s: game id %d returned more than once", what, id)
+		}
+		seen[id] = true
+	}
+
+	got = sortedGameIDs(got)
+	want = sortedGameIDs(want)
+	if len(got) != len(want) {
+		t.Errorf("%s: got game ids %v, want %v", what, got, want)
+		return
+	}
+	for i := range got {
+		if got[i] != want[i] {
+			t.Errorf("%s: got game ids %v, want %v", what, got, want)
+			return
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package backendtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func championMasteries(summonerID string, minutes int, championIDs ...int64) *storage.ChampionMasteries {
+	masteries := &storage.ChampionMasteries{
+		ChampionMasteryDTOList: riotclient.ChampionMasteryDTOList{Timestamp: timestamp(minutes)},
+		SummonerID:             summonerID,
+	}
+	for i, championID := range championIDs {
+		masteries.ChampionMasteryDTOList.ChampionMasteries = append(masteries.ChampionMasteryDTOList.ChampionMasteries, riotclient.ChampionMasteryDTO{
+			ChampionID:     championID,
+			ChampionLevel:  i + 1,
+			ChampionPoints: 1000 * (i + 1),
+			SummonerID:     summonerID,
+		})
+	}
+	return masteries
+}
+
+func testChampionMasteries(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetChampionMasteries("nobody"); err == nil {
+		t.Errorf("GetChampionMasteries for unknown Summoner returned no error")
+	}
+	checkTimeStamp(t, "GetChampionMasteriesTimeStamp for unknown Summoner", backend.GetChampionMasteriesTimeStamp("nobody"), time.Time{})
+
+	for _, m := range []*storage.ChampionMasteries{
+		championMasteries("sid1", 1, 12, 42),
+		championMasteries("sid2", 2, 7),
+	} {
+		if err := backend.StoreChampionMasteries(m); err != nil {
+			t.Fatalf("StoreChampionMasteries returned error: %s", err)
+		}
+	}
+
+	stored, err := backend.GetChampionMasteries("sid1")
+	if err != nil {
+		t.Fatalf("GetChampionMasteries returned error: %s", err)
+	}
+	if want := championMasteries("sid1", 1, 12, 42); !reflect.DeepEqual(stored.ChampionMasteryDTOList.ChampionMasteries, want.ChampionMasteryDTOList.ChampionMasteries) {
+		t.Errorf("GetChampionMasteries = %v, want %v", stored.ChampionMasteryDTOList.ChampionMasteries, want.ChampionMasteryDTOList.ChampionMasteries)
+	}
+	checkTimeStamp(t, "GetChampionMasteriesTimeStamp", backend.GetChampionMasteriesTimeStamp("sid1"), timestamp(1))
+
+	// Storing again replaces the Champion Masteries of the Summoner
+	if err := backend.StoreChampionMasteries(championMasteries("sid1", 3, 99)); err != nil {
+		t.Fatalf("StoreChampionMasteries returned error: %s", err)
+	}
+	stored, err = backend.GetChampionMasteries("sid1")
+	if err != nil {
+		t.Fatalf("GetChampionMasteries returned error: %s", err)
+	}
+	if len(stored.ChampionMasteryDTOList.ChampionMasteries) != 1 || stored.ChampionMasteryDTOList.ChampionMasteries[0].ChampionID != 99 {
+		t.Errorf("StoreChampionMasteries did not replace stored Champion Masteries, got %v", stored.ChampionMasteryDTOList.ChampionMasteries)
+	}
+	checkTimeStamp(t, "GetChampionMasteriesTimeStamp after replace", backend.GetChampionMasteriesTimeStamp("sid1"), timestamp(3))
+
+	other, err := backend.GetChampionMasteries("sid2")
+	if err != nil {
+		t.Fatalf("GetChampionMasteries returned error: %s", err)
+	}
+	if other.SummonerID != "sid2" || len(other.ChampionMasteryDTOList.ChampionMasteries) != 1 {
+		t.Errorf("GetChampionMasteries returned wrong Champion Masteries: %v", other)
+	}
+}
@@ -0,0 +1,101 @@
+package backendtest
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func accountIDs(entries []storage.CrawlEntry) []string {
+	ids := []string{}
+	for _, entry := range entries {
+		ids = append(ids, entry.AccountID)
+	}
+	return ids
+}
+
+func testCrawlFrontier(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetCrawlEntry("euw1", "nobody"); err == nil {
+		t.Errorf("GetCrawlEntry for unknown account returned no error")
+	}
+	entries, err := backend.GetCrawlEntries("euw1", 0, timestamp(100), 10)
+	if err != nil {
+		t.Fatalf("GetCrawlEntries on empty backend returned error: %s", err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("GetCrawlEntries on empty backend returned %d entries", len(entries))
+	}
+
+	for _, entry := range []storage.CrawlEntry{
+		{Region: "euw1", AccountID: "a1", Priority: 1, Added: timestamp(1)},
+		{Region: "euw1", AccountID: "a2", Priority: 2, Tier: "GOLD", LastCrawled: timestamp(5), LastMatchTimestamp: 1552564800000, Added: timestamp(1)},
+		{Region: "euw1", AccountID: "a3", Priority: 2, Added: timestamp(2)},
+		{Region: "euw1", AccountID: "a4", Priority: 2, LastCrawled: timestamp(50), Added: timestamp(2)},
+		{Region: "eun1", AccountID: "a5", Priority: 3, Added: timestamp(3)},
+	} {
+		entry := entry
+		if err := backend.StoreCrawlEntry(&entry); err != nil {
+			t.Fatalf("StoreCrawlEntry returned error: %s", err)
+		}
+	}
+
+	stored, err := backend.GetCrawlEntry("euw1", "a2")
+	if err != nil {
+		t.Fatalf("GetCrawlEntry returned error: %s", err)
+	}
+	if stored.Priority != 2 || stored.Tier != "GOLD" || stored.LastMatchTimestamp != 1552564800000 {
+		t.Errorf("GetCrawlEntry returned wrong entry: %+v", stored)
+	}
+	checkTimeStamp(t, "GetCrawlEntry LastCrawled", stored.LastCrawled, timestamp(5))
+	checkTimeStamp(t, "GetCrawlEntry Added", stored.Added, timestamp(1))
+
+	// Higher priority first, then never crawled and least recently crawled, restricted to region, priority and crawl time
+	entries, err = backend.GetCrawlEntries("euw1", 0, timestamp(10), 0)
+	if err != nil {
+		t.Fatalf("GetCrawlEntries returned error: %s", err)
+	}
+	if got := accountIDs(entries); !reflect.DeepEqual(got, []string{"a3", "a2", "a1"}) {
+		t.Errorf("GetCrawlEntries = %v, want [a3 a2 a1]", got)
+	}
+	entries, err = backend.GetCrawlEntries("euw1", 2, timestamp(100), 2)
+	if err != nil {
+		t.Fatalf("GetCrawlEntries returned error: %s", err)
+	}
+	if got := accountIDs(entries); !reflect.DeepEqual(got, []string{"a3", "a2"}) {
+		t.Errorf("GetCrawlEntries with limit = %v, want [a3 a2]", got)
+	}
+
+	// Storing again replaces the entry of the account and region
+	stored.LastCrawled = timestamp(20)
+	stored.LastMatchTimestamp = 1552568400000
+	if err := backend.StoreCrawlEntry(stored); err != nil {
+		t.Fatalf("StoreCrawlEntry returned error: %s", err)
+	}
+	entries, err = backend.GetCrawlEntries("euw1", 0, timestamp(10), 0)
+	if err != nil {
+		t.Fatalf("GetCrawlEntries returned error: %s", err)
+	}
+	if got := accountIDs(entries); !reflect.DeepEqual(got, []string{"a3", "a1"}) {
+		t.Errorf("GetCrawlEntries after update = %v, want [a3 a1]", got)
+	}
+	stored, err = backend.GetCrawlEntry("euw1", "a2")
+	if err != nil {
+		t.Fatalf("GetCrawlEntry returned error: %s", err)
+	}
+	if stored.LastMatchTimestamp != 1552568400000 {
+		t.Errorf("StoreCrawlEntry did not replace stored entry, got %+v", stored)
+	}
+
+	if _, err := backend.GetCrawlEntry("eun1", "a2"); err == nil {
+		t.Errorf("GetCrawlEntry returned an entry of another region")
+	}
+	entries, err = backend.GetCrawlEntries("eun1", 0, time.Now(), 0)
+	if err != nil {
+		t.Fatalf("GetCrawlEntries returned error: %s", err)
+	}
+	if got := accountIDs(entries); !reflect.DeepEqual(got, []string{"a5"}) {
+		t.Errorf("GetCrawlEntries for eun1 = %v, want [a5]", got)
+	}
+}
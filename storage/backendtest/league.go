@@ -0,0 +1,114 @@
+package backendtest
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func league(region, tier, queue, leagueID string, minutes int) *storage.League {
+	return &storage.League{
+		LeagueListDTO: riotclient.LeagueListDTO{
+			Tier:      tier,
+			Queue:     queue,
+			LeagueID:  leagueID,
+			Entries:   []riotclient.LeagueItemDTO{{SummonerID: "sid1"}, {SummonerID: "sid2"}},
+			Timestamp: timestamp(minutes),
+		},
+		Region:    region,
+		Tier:      tier,
+		Queue:     queue,
+		Timestamp: timestamp(minutes),
+	}
+}
+
+func checkLeagueIDs(t *testing.T, what string, got []storage.League, want ...string) {
+	t.Helper()
+
+	if len(got) != len(want) {
+		t.Errorf("%s: got %d snapshots, want %d", what, len(got), len(want))
+		return
+	}
+	for i := range got {
+		if got[i].LeagueListDTO.LeagueID != want[i] {
+			t.Errorf("%s: snapshot %d has league id %s, want %s", what, i, got[i].LeagueListDTO.LeagueID, want[i])
+		}
+	}
+}
+
+func testLeagues(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetLeagueByQueue("euw1", "MASTER", "RANKED_SOLO_5x5"); err == nil {
+		t.Errorf("GetLeagueByQueue for unknown League returned no error")
+	}
+	if ts, err := backend.GetLeagueByQueueTimeStamp("euw1", "MASTER", "RANKED_SOLO_5x5"); err == nil || !ts.IsZero() {
+		t.Errorf("GetLeagueByQueueTimeStamp for unknown League returned %v, %v", ts, err)
+	}
+	history, err := backend.GetLeagueHistoryByQueue("euw1", "MASTER", "RANKED_SOLO_5x5", timestamp(0), timestamp(100))
+	if err != nil {
+		t.Errorf("GetLeagueHistoryByQueue for unknown League returned error: %s", err)
+	}
+	checkLeagueIDs(t, "GetLeagueHistoryByQueue for unknown League", history)
+
+	// Stored out of order to make sure the backends do not rely on the order of storing
+	for _, l := range []*storage.League{
+		league("euw1", "MASTER", "RANKED_SOLO_5x5", "second", 2),
+		league("euw1", "MASTER", "RANKED_SOLO_5x5", "first", 1),
+		league("euw1", "MASTER", "RANKED_SOLO_5x5", "third", 3),
+		league("na1", "MASTER", "RANKED_SOLO_5x5", "na", 4),
+		league("euw1", "CHALLENGER", "RANKED_SOLO_5x5", "challenger", 5),
+		league("euw1", "MASTER", "RANKED_FLEX_SR", "flex", 6),
+	} {
+		if err := backend.StoreLeague(l); err != nil {
+			t.Fatalf("StoreLeague returned error: %s", err)
+		}
+	}
+
+	stored, err := backend.GetLeagueByQueue("euw1", "master", "RANKED_SOLO_5x5")
+	if err != nil {
+		t.Fatalf("GetLeagueByQueue is not case-insensitive for the tier: %s", err)
+	}
+	if stored.LeagueListDTO.LeagueID != "third" || stored.Region != "euw1" || len(stored.LeagueListDTO.Entries) != 2 {
+		t.Errorf("GetLeagueByQueue did not return the newest snapshot: %v", stored)
+	}
+	checkTimeStamp(t, "GetLeagueByQueue", stored.Timestamp, timestamp(3))
+
+	ts, err := backend.GetLeagueByQueueTimeStamp("euw1", "MASTER", "RANKED_SOLO_5x5")
+	if err != nil {
+		t.Errorf("GetLeagueByQueueTimeStamp returned error: %s", err)
+	}
+	checkTimeStamp(t, "GetLeagueByQueueTimeStamp", ts, timestamp(3))
+
+	stored, err = backend.GetLeagueByQueue("na1", "MASTER", "RANKED_SOLO_5x5")
+	if err != nil || stored.LeagueListDTO.LeagueID != "na" {
+		t.Errorf("GetLeagueByQueue does not separate regions: %v, %v", stored, err)
+	}
+	stored, err = backend.GetLeagueByQueue("euw1", "MASTER", "RANKED_FLEX_SR")
+	if err != nil || stored.LeagueListDTO.LeagueID != "flex" {
+		t.Errorf("GetLeagueByQueue does not separate queues: %v, %v", stored, err)
+	}
+	if _, err := backend.GetLeagueByQueue("kr", "MASTER", "RANKED_SOLO_5x5"); err == nil {
+		t.Errorf("GetLeagueByQueue for unknown region returned no error")
+	}
+
+	history, err = backend.GetLeagueHistoryByQueue("euw1", "MASTER", "RANKED_SOLO_5x5", timestamp(0), timestamp(100))
+	if err != nil {
+		t.Fatalf("GetLeagueHistoryByQueue returned error: %s", err)
+	}
+	checkLeagueIDs(t, "GetLeagueHistoryByQueue", history, "first", "second", "third")
+
+	history, err = backend.GetLeagueHistoryByQueue("euw1", "MASTER", "RANKED_SOLO_5x5", timestamp(2), timestamp(3))
+	if err != nil {
+		t.Fatalf("GetLeagueHistoryByQueue returned error: %s", err)
+	}
+	checkLeagueIDs(t, "GetLeagueHistoryByQueue with time range", history, "second", "third")
+
+	if err := backend.StoreLeague(league("euw1", "MASTER", "RANKED_SOLO_5x5", "second replaced", 2)); err != nil {
+		t.Fatalf("StoreLeague returned error: %s", err)
+	}
+	history, err = backend.GetLeagueHistoryByQueue("euw1", "MASTER", "RANKED_SOLO_5x5", timestamp(0), timestamp(100))
+	if err != nil {
+		t.Fatalf("GetLeagueHistoryByQueue returned error: %s", err)
+	}
+	checkLeagueIDs(t, "GetLeagueHistoryByQueue after replacing a snapshot", history, "first", "second replaced", "third")
+}
@@ -0,0 +1,262 @@
+package backendtest
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func match(gameID int64, platformID string, gameVersion string, mapID int, queueID int, championIDs ...int) *riotclient.MatchDTO {
+	m := riotclient.MatchDTO{
+		GameID:      gameID,
+		PlatformID:  platformID,
+		GameVersion: gameVersion,
+		MapID:       mapID,
+		QueueID:     queueID,
+		GameMode:    "CLASSIC",
+	}
+	for i, championID := range championIDs {
+		m.Participants = append(m.Participants, riotclient.ParticipantDTO{ParticipantID: i + 1, ChampionID: championID})
+	}
+	return &m
+}
+
+func storeMatches(t *testing.T, backend storage.Backend, matches ...*riotclient.MatchDTO) {
+	t.Helper()
+	for _, m := range matches {
+		if err := backend.StoreMatch(m); err != nil {
+			t.Fatalf("StoreMatch for game id %d returned error: %s", m.GameID, err)
+		}
+	}
+}
+
+func numberOfMatches(t *testing.T, backend storage.Backend) uint64 {
+	t.Helper()
+	summary, err := backend.GetStorageSummary()
+	if err != nil {
+		t.Fatalf("GetStorageSummary returned error: %s", err)
+	}
+	return summary.NumberOfMatches
+}
+
+func testMatches(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetMatch("EUW1", 1); err == nil {
+		t.Errorf("GetMatch for unknown Match returned no error")
+	}
+
+	storeMatches(t, backend, match(1, "EUW1", "9.5.263.1", 11, 420, 1, 2))
+
+	stored, err := backend.GetMatch("EUW1", 1)
+	if err != nil {
+		t.Fatalf("GetMatch returned error: %s", err)
+	}
+	if stored.GameID != 1 || stored.PlatformID != "EUW1" || stored.GameVersion != "9.5.263.1" || len(stored.Participants) != 2 || stored.Participants[1].ChampionID != 2 {
+		t.Errorf("GetMatch returned wrong Match: %v", stored)
+	}
+	if _, err := backend.GetMatch("euw1", 1); err != nil {
+		t.Errorf("GetMatch is not case-insensitive for the platform: %s", err)
+	}
+	if _, err := backend.GetMatch("NA1", 1); err == nil {
+		t.Errorf("GetMatch for a Match stored for another platform returned no error")
+	}
+
+	// Matches are unique per game id and platform id
+	if err := backend.StoreMatch(match(1, "EUW1", "9.6.1.1", 12, 450)); err == nil {
+		t.Errorf("StoreMatch for an already stored Match returned no error")
+	}
+	if n := numberOfMatches(t, backend); n != 1 {
+		t.Errorf("Got %d stored Matches after storing the same Match twice, want 1", n)
+	}
+	if stored, err = backend.GetMatch("EUW1", 1); err != nil || stored.GameVersion != "9.5.263.1" {
+		t.Errorf("Storing a Match twice altered the stored Match: %v, %v", stored, err)
+	}
+
+	storeMatches(t, backend, match(1, "NA1", "9.6.1.1", 11, 420))
+	if n := numberOfMatches(t, backend); n != 2 {
+		t.Errorf("Got %d stored Matches after storing the same game id for another platform, want 2", n)
+	}
+	if stored, err = backend.GetMatch("EUW1", 1); err != nil || stored.PlatformID != "EUW1" || stored.GameVersion != "9.5.263.1" {
+		t.Errorf("GetMatch returned the Match of the wrong platform: %v, %v", stored, err)
+	}
+	if stored, err = backend.GetMatch("NA1", 1); err != nil || stored.PlatformID != "NA1" || stored.GameVersion != "9.6.1.1" {
+		t.Errorf("GetMatch returned the Match of the wrong platform: %v, %v", stored, err)
+	}
+}
+
+func testMatchTimeLines(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetMatchTimeLine("EUW1", 1); err == nil {
+		t.Errorf("GetMatchTimeLine for unknown Match returned no error")
+	}
+
+	m := match(1, "EUW1", "9.5.263.1", 11, 420)
+	timeLine := riotclient.MatchTimelineDTO{
+		FrameInterval: 60000,
+		Frames:        []riotclient.MatchFrameDTO{{Timestamp: 0}, {Timestamp: 60000}},
+	}
+	if err := backend.StoreMatchTimeLine(m, &timeLine); err != nil {
+		t.Fatalf("StoreMatchTimeLine returned error: %s", err)
+	}
+	if err := backend.StoreMatchTimeLine(m, &riotclient.MatchTimelineDTO{FrameInterval: 1}); err == nil {
+		t.Errorf("StoreMatchTimeLine for an already stored Match TimeLine returned no error")
+	}
+	if err := backend.StoreMatchTimeLine(match(1, "NA1", "9.5.263.1", 11, 420), &riotclient.MatchTimelineDTO{FrameInterval: 30000}); err != nil {
+		t.Errorf("StoreMatchTimeLine for the same game id on another platform returned error: %s", err)
+	}
+
+	stored, err := backend.GetMatchTimeLine("EUW1", 1)
+	if err != nil {
+		t.Fatalf("GetMatchTimeLine returned error: %s", err)
+	}
+	if stored.FrameInterval != 60000 || len(stored.Frames) != 2 || stored.Frames[1].Timestamp != 60000 {
+		t.Errorf("GetMatchTimeLine returned wrong TimeLine: %v", stored)
+	}
+
+	stored, err = backend.GetMatchTimeLine("na1", 1)
+	if err != nil || stored.FrameInterval != 30000 {
+		t.Errorf("GetMatchTimeLine returned the TimeLine of the wrong platform: %v, %v", stored, err)
+	}
+	if _, err := backend.GetMatchTimeLine("KR", 1); err == nil {
+		t.Errorf("GetMatchTimeLine for a TimeLine stored for other platforms returned no error")
+	}
+}
+
+// matchQueryFixture stores a set of Matches used to check query filters.
+// The comments list what distinguishes a Match from the first one.
+func matchQueryFixture(t *testing.T, backend storage.Backend) {
+	storeMatches(t, backend,
+		match(1, "EUW1", "9.5.263.1", 11, 420, 1, 2),
+		match(2, "EUW1", "9.5.264.7", 11, 440, 1, 3),  // queue
+		match(3, "NA1", "9.5.263.1", 11, 420, 2, 3),   // platform, champions
+		match(4, "EUW1", "9.50.1.1", 11, 420, 1, 2),   // version with the same prefix when not escaped
+		match(5, "EUW1", "9.6.270.1", 11, 420, 1, 2),  // version
+		match(6, "EUW1", "9.5.263.1", 12, 450, 1, 2),  // map, queue
+		match(7, "EUW1", "19.5.263.1", 11, 420, 1, 2), // version containing the version
+		match(8, "KR", "9.5.263.1", 11, 400, 1),       // platform, queue
+	)
+}
+
+func matchesGameIDs(matches *riotclient.Matches) []int64 {
+	var gameIDs []int64
+	for _, m := range matches.Matches {
+		gameIDs = append(gameIDs, m.GameID)
+	}
+	return gameIDs
+}
+
+func testMatchQueries(t *testing.T, backend storage.Backend) {
+	matchQueryFixture(t, backend)
+
+	matches, err := backend.GetMatchesByGameVersionAndChampionID(`9\.5\.`, 1)
+	if err != nil {
+		t.Fatalf("GetMatchesByGameVersionAndChampionID returned error: %s", err)
+	}
+	checkGameIDs(t, "GetMatchesByGameVersionAndChampionID", matchesGameIDs(matches), []int64{1, 2, 6, 8})
+
+	matches, err = backend.GetMatchesByGameVersionAndChampionID(`9.5`, 1)
+	if err != nil {
+		t.Fatalf("GetMatchesByGameVersionAndChampionID returned error: %s", err)
+	}
+	checkGameIDs(t, "GetMatchesByGameVersionAndChampionID with unescaped version", matchesGameIDs(matches), []int64{1, 2, 4, 6, 8})
+
+	matches, err = backend.GetMatchesByGameVersionChampionIDMapQueue(`9\.5\.`, 2, 11, 420)
+	if err != nil {
+		t.Fatalf("GetMatchesByGameVersionChampionIDMapQueue returned error: %s", err)
+	}
+	checkGameIDs(t, "GetMatchesByGameVersionChampionIDMapQueue", matchesGameIDs(matches), []int64{1, 3})
+
+	matches, err = backend.GetMatchesByGameVersionChampionIDMapBetweenQueueIDs(`9\.5\.`, 1, 11, 440, 420)
+	if err != nil {
+		t.Fatalf("GetMatchesByGameVersionChampionIDMapBetweenQueueIDs returned error: %s", err)
+	}
+	checkGameIDs(t, "GetMatchesByGameVersionChampionIDMapBetweenQueueIDs", matchesGameIDs(matches), []int64{1, 2})
+
+	matches, err = backend.GetMatchesByGameVersionAndChampionID(`9\.5\.`, 999)
+	if err != nil {
+		t.Fatalf("GetMatchesByGameVersionAndChampionID returned error: %s", err)
+	}
+	if len(matches.Matches) != 0 {
+		t.Errorf("GetMatchesByGameVersionAndChampionID returned Matches for a Champion not played")
+	}
+}
+
+func cursorGameIDs(t *testing.T, what string, cur storage.QueryCursor, err error) []int64 {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("%s returned error: %s", what, err)
+	}
+	defer cur.Close()
+
+	var gameIDs []int64
+	for cur.Next() {
+		m := riotclient.MatchDTO{}
+		if err := cur.Decode(&m); err != nil {
+			t.Errorf("%s: Decode returned error: %s", what, err)
+			continue
+		}
+		if m.GameMode != "CLASSIC" {
+			t.Errorf("%s: Decode returned an incomplete Match: %v", what, m)
+		}
+		gameIDs = append(gameIDs, m.GameID)
+	}
+	for i := 1; i < len(gameIDs); i++ {
+		if gameIDs[i] < gameIDs[i-1] {
+			t.Errorf("%s: got game ids %v, want them sorted by ascending game id", what, gameIDs)
+			break
+		}
+	}
+	for i := 1; i < len(gameIDs); i++ {
+		if gameIDs[i] < gameIDs[i-1] {
+			t.Errorf("%s: got game ids %v, want them sorted by ascending game id", what, gameIDs)
+			break
+		}
+	}
+	return gameIDs
+}
+
+func testMatchCursors(t *testing.T, backend storage.Backend) {
+	matchQueryFixture(t, backend)
+
+	cur, err := backend.GetMatchesCursorByGameVersion("", `9\.5\.`)
+	checkGameIDs(t, "GetMatchesCursorByGameVersion", cursorGameIDs(t, "GetMatchesCursorByGameVersion", cur, err), []int64{1, 2, 3, 6, 8})
+
+	cur, err = backend.GetMatchesCursorByGameVersion("", `9\.7\.`)
+	checkGameIDs(t, "GetMatchesCursorByGameVersion without results", cursorGameIDs(t, "GetMatchesCursorByGameVersion", cur, err), nil)
+
+	// Stored out of order to make sure the cursors are sorted by the numeric game id
+	storeMatches(t, backend,
+		match(12, "EUW1", "9.7.1.1", 11, 420, 1),
+		match(9, "EUW1", "9.7.1.1", 11, 420, 1),
+		match(100, "NA1", "9.7.1.1", 11, 420, 1),
+		match(10, "KR", "9.7.1.1", 11, 420, 1),
+	)
+	cur, err = backend.GetMatchesCursorByGameVersion("", `9\.7\.`)
+	checkGameIDs(t, "GetMatchesCursorByGameVersion sorted by game id", cursorGameIDs(t, "GetMatchesCursorByGameVersion", cur, err), []int64{9, 10, 12, 100})
+
+	cur, err = backend.GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs("", `9\.5\.`, 3, 11, 440, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs", cursorGameIDs(t, "GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs", cur, err), []int64{2, 3})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapBetweenQueueIDs("", `9\.5\.`, 11, 420, 400)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapBetweenQueueIDs", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapBetweenQueueIDs", cur, err), []int64{1, 3, 8})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapQueueID("", `9\.5\.`, 11, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID", cur, err), []int64{1, 3})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapQueueID("", `9\.5`, 11, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID with version prefix without trailing dot", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID", cur, err), []int64{1, 3, 4})
+
+	cur, err = backend.GetMatchesCursorByGameVersion("EUW1", `9\.5\.`)
+	checkGameIDs(t, "GetMatchesCursorByGameVersion for one platform", cursorGameIDs(t, "GetMatchesCursorByGameVersion", cur, err), []int64{1, 2, 6})
+
+	cur, err = backend.GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs("na1", `9\.5\.`, 3, 11, 440, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs for one platform", cursorGameIDs(t, "GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs", cur, err), []int64{3})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapBetweenQueueIDs("KR", `9\.5\.`, 11, 420, 400)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapBetweenQueueIDs for one platform", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapBetweenQueueIDs", cur, err), []int64{8})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapQueueID("EUW1", `9\.5\.`, 11, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID for one platform", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID", cur, err), []int64{1})
+
+	cur, err = backend.GetMatchesCursorByGameVersionMapQueueID("EUN1", `9\.5\.`, 11, 420)
+	checkGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID for a platform without matches", cursorGameIDs(t, "GetMatchesCursorByGameVersionMapQueueID", cur, err), nil)
+}
@@ -0,0 +1,272 @@
+package backendtest
+
+import (
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func testChampions(t *testing.T, backend storage.Backend) {
+	champions, err := backend.GetChampions()
+	if err != nil {
+		t.Fatalf("GetChampions on empty backend returned error: %s", err)
+	}
+	if len(champions) != 0 {
+		t.Errorf("GetChampions on empty backend returned %d champions", len(champions))
+	}
+	checkTimeStamp(t, "GetChampionsTimeStamp on empty backend", backend.GetChampionsTimeStamp(), time.Time{})
+
+	err = backend.StoreChampions(riotclient.ChampionsList{
+		"Annie": riotclient.Champion{ID: "Annie", Key: "1", Name: "Annie", Timestamp: timestamp(1)},
+		"Olaf":  riotclient.Champion{ID: "Olaf", Key: "2", Name: "Olaf", Timestamp: timestamp(2)},
+	})
+	if err != nil {
+		t.Fatalf("StoreChampions returned error: %s", err)
+	}
+
+	// Same key updates the stored champion
+	err = backend.StoreChampions(riotclient.ChampionsList{
+		"Annie": riotclient.Champion{ID: "Annie", Key: "1", Name: "Annie the Dark Child", Timestamp: timestamp(3)},
+	})
+	if err != nil {
+		t.Fatalf("StoreChampions returned error: %s", err)
+	}
+
+	champions, err = backend.GetChampions()
+	if err != nil {
+		t.Fatalf("GetChampions returned error: %s", err)
+	}
+	if len(champions) != 2 {
+		t.Fatalf("GetChampions returned %d champions, want 2", len(champions))
+	}
+	if champions["Annie"].Name != "Annie the Dark Child" || champions["Olaf"].Key != "2" {
+		t.Errorf("GetChampions returned wrong champions: %v", champions)
+	}
+	checkTimeStamp(t, "GetChampionsTimeStamp", backend.GetChampionsTimeStamp(), timestamp(2))
+
+	summary, err := backend.GetStorageSummary()
+	if err != nil {
+		t.Fatalf("GetStorageSummary returned error: %s", err)
+	}
+	if summary.NumberOfChampions != 2 {
+		t.Errorf("GetStorageSummary returned %d champions, want 2", summary.NumberOfChampions)
+	}
+}
+
+func testChampionDetails(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetChampionDetails("Annie", "9.5.1", "en_US"); err == nil {
+		t.Errorf("GetChampionDetails on empty backend returned no error")
+	}
+
+	store := func(gameVersion, language, lore string) {
+		err := backend.StoreChampionDetails(gameVersion, language, &riotclient.ChampionDetails{
+			Champion: riotclient.Champion{ID: "Annie", Key: "1", Name: "Annie", Timestamp: timestamp(1)},
+			Lore:     lore,
+			Spells: []riotclient.ChampionSpell{
+				{ID: "Disintegrate", Maxrank: 5, Cooldown: []float64{4, 4, 4, 4, 4}, Cost: []float64{60, 65, 70, 75, 80}},
+				{ID: "Incinerate", Maxrank: 5},
+			},
+			Passive: riotclient.ChampionPassive{Name: "Pyromania"},
+		})
+		if err != nil {
+			t.Fatalf("StoreChampionDetails returned error: %s", err)
+		}
+	}
+	store("9.5.1", "en_US", "Dangerous")
+	store("9.5.1", "de_DE", "Gefährlich")
+	store("9.5.1", "en_US", "Dangerous, yet disarmingly precocious")
+
+	championDetails, err := backend.GetChampionDetails("Annie", "9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetChampionDetails returned error: %s", err)
+	}
+	if championDetails.Lore != "Dangerous, yet disarmingly precocious" || championDetails.Key != "1" ||
+		len(championDetails.Spells) != 2 || championDetails.Spells[0].Cost[4] != 80 || championDetails.Passive.Name != "Pyromania" {
+		t.Errorf("GetChampionDetails returned wrong details: %+v", championDetails)
+	}
+	checkTimeStamp(t, "GetChampionDetails", championDetails.Timestamp, timestamp(1))
+
+	championDetails, err = backend.GetChampionDetails("Annie", "9.5.1", "de_DE")
+	if err != nil {
+		t.Fatalf("GetChampionDetails returned error: %s", err)
+	}
+	if championDetails.Lore != "Gefährlich" {
+		t.Errorf("GetChampionDetails returned wrong details for de_DE: %+v", championDetails)
+	}
+
+	if _, err := backend.GetChampionDetails("Annie", "9.6.1", "en_US"); err == nil {
+		t.Errorf("GetChampionDetails returned no error for a game version not stored")
+	}
+}
+
+func testFreeRotation(t *testing.T, backend storage.Backend) {
+	freeRotation, err := backend.GetFreeRotation()
+	if err != nil {
+		t.Fatalf("GetFreeRotation on empty backend returned error: %s", err)
+	}
+	if len(freeRotation.FreeChampionIds) != 0 {
+		t.Errorf("GetFreeRotation on empty backend returned %v", freeRotation)
+	}
+	checkTimeStamp(t, "GetFreeRotationTimeStamp on empty backend", backend.GetFreeRotationTimeStamp(), time.Time{})
+
+	for i, ids := range [][]int{{1, 2, 3}, {4, 5}} {
+		err = backend.StoreFreeRotation(&riotclient.FreeRotation{FreeChampionIds: ids, MaxNewPlayerLevel: 10, Timestamp: timestamp(i)})
+		if err != nil {
+			t.Fatalf("StoreFreeRotation returned error: %s", err)
+		}
+	}
+
+	freeRotation, err = backend.GetFreeRotation()
+	if err != nil {
+		t.Fatalf("GetFreeRotation returned error: %s", err)
+	}
+	if len(freeRotation.FreeChampionIds) != 2 || freeRotation.FreeChampionIds[0] != 4 || freeRotation.MaxNewPlayerLevel != 10 {
+		t.Errorf("GetFreeRotation did not return the last stored rotation: %v", freeRotation)
+	}
+	checkTimeStamp(t, "GetFreeRotationTimeStamp", backend.GetFreeRotationTimeStamp(), timestamp(1))
+}
+
+func testSummonerSpells(t *testing.T, backend storage.Backend) {
+	spells, err := backend.GetSummonerSpells("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetSummonerSpells on empty backend returned error: %s", err)
+	}
+	if len(spells) != 0 {
+		t.Errorf("GetSummonerSpells on empty backend returned %d spells", len(spells))
+	}
+
+	store := func(gameVersion, language, name string) {
+		err := backend.StoreSummonerSpells(gameVersion, language, riotclient.SummonerSpellsList{
+			"SummonerFlash": riotclient.SummonerSpell{ID: "SummonerFlash", Key: "4", Name: name},
+			"SummonerHeal":  riotclient.SummonerSpell{ID: "SummonerHeal", Key: "7", Name: "Heal"},
+		})
+		if err != nil {
+			t.Fatalf("StoreSummonerSpells returned error: %s", err)
+		}
+	}
+	store("9.5.1", "en_US", "Flash")
+	store("9.5.1", "de_DE", "Blitz")
+	store("9.6.1", "en_US", "Flash")
+	store("9.5.1", "en_US", "Flash!")
+
+	spells, err = backend.GetSummonerSpells("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetSummonerSpells returned error: %s", err)
+	}
+	if len(spells) != 2 || spells["SummonerFlash"].Name != "Flash!" || spells["SummonerHeal"].Key != "7" {
+		t.Errorf("GetSummonerSpells returned wrong spells: %v", spells)
+	}
+
+	spells, err = backend.GetSummonerSpells("9.5.1", "de_DE")
+	if err != nil {
+		t.Fatalf("GetSummonerSpells returned error: %s", err)
+	}
+	if len(spells) != 2 || spells["SummonerFlash"].Name != "Blitz" {
+		t.Errorf("GetSummonerSpells returned wrong spells for de_DE: %v", spells)
+	}
+}
+
+func testRunesReforged(t *testing.T, backend storage.Backend) {
+	runes, err := backend.GetRunesReforged("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetRunesReforged on empty backend returned error: %s", err)
+	}
+	if len(runes) != 0 {
+		t.Errorf("GetRunesReforged on empty backend returned %d runes", len(runes))
+	}
+
+	store := func(gameVersion, language, name string) {
+		err := backend.StoreRunesReforged(gameVersion, language, riotclient.RunesReforgedList{
+			8100: riotclient.RunesReforgedSet{ID: 8100, Key: "Domination", Name: name},
+			8300: riotclient.RunesReforgedSet{ID: 8300, Key: "Inspiration", Name: "Inspiration"},
+		})
+		if err != nil {
+			t.Fatalf("StoreRunesReforged returned error: %s", err)
+		}
+	}
+	store("9.5.1", "en_US", "Domination")
+	store("9.5.1", "de_DE", "Herrschaft")
+	store("9.5.1", "en_US", "Domination!")
+
+	runes, err = backend.GetRunesReforged("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetRunesReforged returned error: %s", err)
+	}
+	if len(runes) != 2 || runes[8100].Name != "Domination!" || runes[8300].Key != "Inspiration" {
+		t.Errorf("GetRunesReforged returned wrong runes: %v", runes)
+	}
+
+	runes, err = backend.GetRunesReforged("9.6.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetRunesReforged returned error: %s", err)
+	}
+	if len(runes) != 0 {
+		t.Errorf("GetRunesReforged returned runes for a game version not stored: %v", runes)
+	}
+}
+
+func testItems(t *testing.T, backend storage.Backend) {
+	items, err := backend.GetItems("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetItems on empty backend returned error: %s", err)
+	}
+	if len(items) != 0 {
+		t.Errorf("GetItems on empty backend returned %d items", len(items))
+	}
+
+	store := func(gameVersion, language, name string) {
+		err := backend.StoreItems(gameVersion, language, riotclient.ItemList{
+			1001: riotclient.Item{Key: 1001, Name: name},
+			3006: riotclient.Item{Key: 3006, Name: "Berserker's Greaves"},
+		})
+		if err != nil {
+			t.Fatalf("StoreItems returned error: %s", err)
+		}
+	}
+	store("9.5.1", "en_US", "Boots of Speed")
+	store("9.5.1", "de_DE", "Stiefel")
+	store("9.5.1", "en_US", "Boots of Speed!")
+
+	items, err = backend.GetItems("9.5.1", "en_US")
+	if err != nil {
+		t.Fatalf("GetItems returned error: %s", err)
+	}
+	if len(items) != 2 || items[1001].Name != "Boots of Speed!" || items[3006].Key != 3006 {
+		t.Errorf("GetItems returned wrong items: %v", items)
+	}
+
+	items, err = backend.GetItems("9.5.1", "de_DE")
+	if err != nil {
+		t.Fatalf("GetItems returned error: %s", err)
+	}
+	if len(items) != 2 || items[1001].Name != "Stiefel" {
+		t.Errorf("GetItems returned wrong items for de_DE: %v", items)
+	}
+}
+
+func testKnownGameVersions(t *testing.T, backend storage.Backend) {
+	gameVersions, err := backend.GetKnownGameVersions()
+	if err != nil {
+		t.Fatalf("GetKnownGameVersions on empty backend returned error: %s", err)
+	}
+	if len(gameVersions.Versions) != 0 {
+		t.Errorf("GetKnownGameVersions on empty backend returned %v", gameVersions)
+	}
+
+	for _, versions := range [][]string{{"9.4", "9.5"}, {"9.5", "9.6", "9.7"}} {
+		err = backend.StoreKnownGameVersions(&storage.GameVersions{Versions: versions})
+		if err != nil {
+			t.Fatalf("StoreKnownGameVersions returned error: %s", err)
+		}
+	}
+
+	gameVersions, err = backend.GetKnownGameVersions()
+	if err != nil {
+		t.Fatalf("GetKnownGameVersions returned error: %s", err)
+	}
+	if len(gameVersions.Versions) != 3 || gameVersions.Versions[0] != "9.5" {
+		t.Errorf("GetKnownGameVersions did not return the last stored versions: %v", gameVersions)
+	}
+}
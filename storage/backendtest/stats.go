@@ -0,0 +1,195 @@
+package backendtest
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/statstypes"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// statsKey identifies statistics for a champion, game version, tier and queue
+type statsKey struct {
+	championID, gameVersion, tier, queue string
+}
+
+var statsKeys = []statsKey{
+	{"1", "9.5", "ALL", "RANKED_SOLO"},
+	{"1", "9.5", "GOLD", "RANKED_SOLO"},
+	{"1", "9.5", "ALL", "RANKED_FLEX"},
+	{"1", "9.6", "ALL", "RANKED_SOLO"},
+	{"2", "9.5", "ALL", "RANKED_SOLO"},
+}
+
+// testStatsUpsert stores statistics for all statsKeys, stores the first one again with a different sample size
+// and checks that every key returns its own statistics and that the second store replaced the first one
+func testStatsUpsert(t *testing.T, what string, store func(key statsKey, sampleSize uint64) error, get func(key statsKey) (uint64, error)) {
+	if _, err := get(statsKeys[0]); err == nil {
+		t.Errorf("Get %s on empty backend returned no error", what)
+	}
+
+	for i, key := range statsKeys {
+		if err := store(key, uint64(i+1)); err != nil {
+			t.Fatalf("Store %s for %v returned error: %s", what, key, err)
+		}
+	}
+	if err := store(statsKeys[0], 100); err != nil {
+		t.Fatalf("Store %s for %v returned error: %s", what, statsKeys[0], err)
+	}
+
+	for i, key := range statsKeys {
+		want := uint64(i + 1)
+		if i == 0 {
+			want = 100
+		}
+
+		sampleSize, err := get(key)
+		if err != nil {
+			t.Errorf("Get %s for %v returned error: %s", what, key, err)
+			continue
+		}
+		if sampleSize != want {
+			t.Errorf("Get %s for %v returned sample size %d, want %d", what, key, sampleSize, want)
+		}
+	}
+}
+
+func checkStatsKey(t *testing.T, what string, key statsKey, championID, gameVersion, tier, queue string) {
+	t.Helper()
+	if (statsKey{championID, gameVersion, tier, queue}) != key {
+		t.Errorf("Got %s for %v, want %v", what, statsKey{championID, gameVersion, tier, queue}, key)
+	}
+}
+
+func testChampionStats(t *testing.T, backend storage.Backend) {
+	testStatsUpsert(t, "Champion Stats",
+		func(key statsKey, sampleSize uint64) error {
+			return backend.StoreChampionStats(&storage.ChampionStatsStorage{
+				ChampionID:   key.championID,
+				ChampionKey:  "key" + key.championID,
+				ChampionName: "Champion " + key.championID,
+				GameVersion:  key.gameVersion,
+				Tier:         key.tier,
+				Queue:        key.queue,
+				SampleSize:   sampleSize,
+			})
+		},
+		func(key statsKey) (uint64, error) {
+			stats, err := backend.GetChampionStatsByChampionIDGameVersionTierQueue(key.championID, key.gameVersion, key.tier, key.queue)
+			if err != nil {
+				return 0, err
+			}
+			checkStatsKey(t, "Champion Stats", key, stats.ChampionID, stats.GameVersion, stats.Tier, stats.Queue)
+			return stats.SampleSize, nil
+		})
+}
+
+func testItemStats(t *testing.T, backend storage.Backend) {
+	testStatsUpsert(t, "Item Stats",
+		func(key statsKey, sampleSize uint64) error {
+			return backend.StoreItemStats(&storage.ItemStatsStorage{
+				ChampionID:   key.championID,
+				ChampionKey:  "key" + key.championID,
+				ChampionName: "Champion " + key.championID,
+				GameVersion:  key.gameVersion,
+				Tier:         key.tier,
+				Queue:        key.queue,
+				SampleSize:   sampleSize,
+			})
+		},
+		func(key statsKey) (uint64, error) {
+			stats, err := backend.GetItemStatsByChampionIDGameVersionTierQueue(key.championID, key.gameVersion, key.tier, key.queue)
+			if err != nil {
+				return 0, err
+			}
+			checkStatsKey(t, "Item Stats", key, stats.ChampionID, stats.GameVersion, stats.Tier, stats.Queue)
+			return stats.SampleSize, nil
+		})
+}
+
+func testSummonerSpellsStats(t *testing.T, backend storage.Backend) {
+	testStatsUpsert(t, "Summoner Spells Stats",
+		func(key statsKey, sampleSize uint64) error {
+			return backend.StoreSummonerSpellsStats(&storage.SummonerSpellsStatsStorage{
+				ChampionID:   key.championID,
+				ChampionKey:  "key" + key.championID,
+				ChampionName: "Champion " + key.championID,
+				GameVersion:  key.gameVersion,
+				Tier:         key.tier,
+				Queue:        key.queue,
+				SampleSize:   sampleSize,
+			})
+		},
+		func(key statsKey) (uint64, error) {
+			stats, err := backend.GetSummonerSpellsStatsByChampionIDGameVersionTierQueue(key.championID, key.gameVersion, key.tier, key.queue)
+			if err != nil {
+				return 0, err
+			}
+			checkStatsKey(t, "Summoner Spells Stats", key, stats.ChampionID, stats.GameVersion, stats.Tier, stats.Queue)
+			return stats.SampleSize, nil
+		})
+}
+
+func testRunesReforgedStats(t *testing.T, backend storage.Backend) {
+	testStatsUpsert(t, "Runes Reforged Stats",
+		func(key statsKey, sampleSize uint64) error {
+			return backend.StoreRunesReforgedStats(&storage.RunesReforgedStatsStorage{
+				ChampionID:   key.championID,
+				ChampionKey:  "key" + key.championID,
+				ChampionName: "Champion " + key.championID,
+				GameVersion:  key.gameVersion,
+				Tier:         key.tier,
+				Queue:        key.queue,
+				SampleSize:   sampleSize,
+			})
+		},
+		func(key statsKey) (uint64, error) {
+			stats, err := backend.GetRunesReforgedStatsByChampionIDGameVersionTierQueue(key.championID, key.gameVersion, key.tier, key.queue)
+			if err != nil {
+				return 0, err
+			}
+			checkStatsKey(t, "Runes Reforged Stats", key, stats.ChampionID, stats.GameVersion, stats.Tier, stats.Queue)
+			return stats.SampleSize, nil
+		})
+}
+
+func testChampionStatsSummary(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetChampionStatsSummaryByGameVersionTierQueue("9.5", "ALL", "RANKED_SOLO"); err == nil {
+		t.Errorf("GetChampionStatsSummaryByGameVersionTierQueue on empty backend returned no error")
+	}
+
+	store := func(gameVersion, tier, queue string, champions int) {
+		err := backend.StoreChampionStatsSummary(&storage.ChampionStatsSummaryStorage{
+			ChampionsStatsSummary: make([]statstypes.ChampionStatsSummary, champions),
+			GameVersion:           gameVersion,
+			Tier:                  tier,
+			Queue:                 queue,
+		})
+		if err != nil {
+			t.Fatalf("StoreChampionStatsSummary returned error: %s", err)
+		}
+	}
+	store("9.5", "ALL", "RANKED_SOLO", 1)
+	store("9.5", "GOLD", "RANKED_SOLO", 2)
+	store("9.5", "ALL", "RANKED_FLEX", 3)
+	store("9.6", "ALL", "RANKED_SOLO", 4)
+	store("9.5", "ALL", "RANKED_SOLO", 5)
+
+	for _, tt := range []struct {
+		gameVersion, tier, queue string
+		champions                int
+	}{
+		{"9.5", "ALL", "RANKED_SOLO", 5},
+		{"9.5", "GOLD", "RANKED_SOLO", 2},
+		{"9.5", "ALL", "RANKED_FLEX", 3},
+		{"9.6", "ALL", "RANKED_SOLO", 4},
+	} {
+		summary, err := backend.GetChampionStatsSummaryByGameVersionTierQueue(tt.gameVersion, tt.tier, tt.queue)
+		if err != nil {
+			t.Errorf("GetChampionStatsSummaryByGameVersionTierQueue(%s, %s, %s) returned error: %s", tt.gameVersion, tt.tier, tt.queue, err)
+			continue
+		}
+		if len(summary.ChampionsStatsSummary) != tt.champions || summary.GameVersion != tt.gameVersion || summary.Tier != tt.tier || summary.Queue != tt.queue {
+			t.Errorf("GetChampionStatsSummaryByGameVersionTierQueue(%s, %s, %s) returned wrong summary with %d champions", tt.gameVersion, tt.tier, tt.queue, len(summary.ChampionsStatsSummary))
+		}
+	}
+}
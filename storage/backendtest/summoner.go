@@ -0,0 +1,168 @@
+package backendtest
+
+import (
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+func summoner(name, summonerID, accountID, PUUID string, minutes int) *storage.Summoner {
+	return &storage.Summoner{
+		SummonerDTO: riotclient.SummonerDTO{
+			Name:      name,
+			ID:        summonerID,
+			AccountID: accountID,
+			PuuID:     PUUID,
+			Timestamp: timestamp(minutes),
+		},
+		SummonerName: name,
+		SummonerID:   summonerID,
+		AccountID:    accountID,
+		PUUID:        PUUID,
+	}
+}
+
+func testSummoners(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetSummonerByName("nobody"); err == nil {
+		t.Errorf("GetSummonerByName for unknown Summoner returned no error")
+	}
+	if _, err := backend.GetSummonerBySummonerID("nobody"); err == nil {
+		t.Errorf("GetSummonerBySummonerID for unknown Summoner returned no error")
+	}
+	if _, err := backend.GetSummonerByAccountID("nobody"); err == nil {
+		t.Errorf("GetSummonerByAccountID for unknown Summoner returned no error")
+	}
+	if _, err := backend.GetSummonerByPUUID("nobody"); err == nil {
+		t.Errorf("GetSummonerByPUUID for unknown Summoner returned no error")
+	}
+	checkTimeStamp(t, "GetSummonerByNameTimeStamp for unknown Summoner", backend.GetSummonerByNameTimeStamp("nobody"), time.Time{})
+	checkTimeStamp(t, "GetSummonerBySummonerIDTimeStamp for unknown Summoner", backend.GetSummonerBySummonerIDTimeStamp("nobody"), time.Time{})
+	checkTimeStamp(t, "GetSummonerByAccountIDTimeStamp for unknown Summoner", backend.GetSummonerByAccountIDTimeStamp("nobody"), time.Time{})
+	checkTimeStamp(t, "GetSummonerByPUUIDTimeStamp for unknown Summoner", backend.GetSummonerByPUUIDTimeStamp("nobody"), time.Time{})
+
+	for _, s := range []*storage.Summoner{
+		summoner("oldname", "sid1", "aid1", "puuid1", 1),
+		summoner("other", "sid2", "aid2", "puuid2", 2),
+	} {
+		if err := backend.StoreSummoner(s); err != nil {
+			t.Fatalf("StoreSummoner returned error: %s", err)
+		}
+	}
+
+	stored, err := backend.GetSummonerByName("OldName")
+	if err != nil {
+		t.Fatalf("GetSummonerByName is not case-insensitive: %s", err)
+	}
+	if stored.SummonerID != "sid1" || stored.SummonerDTO.AccountID != "aid1" {
+		t.Errorf("GetSummonerByName returned wrong Summoner: %v", stored)
+	}
+	checkTimeStamp(t, "GetSummonerByNameTimeStamp", backend.GetSummonerByNameTimeStamp("oldname"), timestamp(1))
+
+	// Name change: same ids, new name replaces the old entry
+	if err := backend.StoreSummoner(summoner("newname", "sid1", "aid1", "puuid1", 3)); err != nil {
+		t.Fatalf("StoreSummoner returned error: %s", err)
+	}
+	if _, err := backend.GetSummonerByName("oldname"); err == nil {
+		t.Errorf("GetSummonerByName still finds the Summoner under its old name")
+	}
+
+	lookups := []struct {
+		what      string
+		get       func(string) (*storage.Summoner, error)
+		timeStamp func(string) time.Time
+		id        string
+	}{
+		{"GetSummonerByName", backend.GetSummonerByName, backend.GetSummonerByNameTimeStamp, "newname"},
+		{"GetSummonerBySummonerID", backend.GetSummonerBySummonerID, backend.GetSummonerBySummonerIDTimeStamp, "sid1"},
+		{"GetSummonerByAccountID", backend.GetSummonerByAccountID, backend.GetSummonerByAccountIDTimeStamp, "aid1"},
+		{"GetSummonerByPUUID", backend.GetSummonerByPUUID, backend.GetSummonerByPUUIDTimeStamp, "puuid1"},
+	}
+	for _, lookup := range lookups {
+		stored, err := lookup.get(lookup.id)
+		if err != nil {
+			t.Errorf("%s returned error: %s", lookup.what, err)
+			continue
+		}
+		if stored.SummonerName != "newname" || stored.SummonerID != "sid1" || stored.AccountID != "aid1" || stored.PUUID != "puuid1" {
+			t.Errorf("%s returned wrong Summoner: %v", lookup.what, stored)
+		}
+		checkTimeStamp(t, lookup.what+"TimeStamp", lookup.timeStamp(lookup.id), timestamp(3))
+	}
+
+	summary, err := backend.GetStorageSummary()
+	if err != nil {
+		t.Fatalf("GetStorageSummary returned error: %s", err)
+	}
+	if summary.NumberOfSummoners != 2 {
+		t.Errorf("GetStorageSummary returned %d Summoners, want 2", summary.NumberOfSummoners)
+	}
+}
+
+func summonerLeagues(name, summonerID string, minutes ...int) *storage.SummonerLeagues {
+	leagues := storage.SummonerLeagues{
+		SummonerName: name,
+		SummonerID:   summonerID,
+	}
+	for _, m := range minutes {
+		leagues.LeaguePositionDTOList.LeaguePosition = append(leagues.LeaguePositionDTOList.LeaguePosition, riotclient.LeaguePositionDTO{
+			QueueType:    "RANKED_SOLO_5x5",
+			SummonerName: name,
+			SummonerID:   summonerID,
+			Tier:         "GOLD",
+			Timestamp:    timestamp(m),
+		})
+	}
+	return &leagues
+}
+
+func testSummonerLeagues(t *testing.T, backend storage.Backend) {
+	if _, err := backend.GetLeaguesForSummoner("nobody"); err == nil {
+		t.Errorf("GetLeaguesForSummoner for unknown Summoner returned no error")
+	}
+	if _, err := backend.GetLeaguesForSummonerBySummonerID("nobody"); err == nil {
+		t.Errorf("GetLeaguesForSummonerBySummonerID for unknown Summoner returned no error")
+	}
+	if _, err := backend.GetLeaguesForSummonerTimeStamp("nobody"); err == nil {
+		t.Errorf("GetLeaguesForSummonerTimeStamp for unknown Summoner returned no error")
+	}
+
+	if err := backend.StoreLeaguesForSummoner(summonerLeagues("oldname", "sid1", 5, 2)); err != nil {
+		t.Fatalf("StoreLeaguesForSummoner returned error: %s", err)
+	}
+
+	leagues, err := backend.GetLeaguesForSummoner("OldName")
+	if err != nil {
+		t.Fatalf("GetLeaguesForSummoner is not case-insensitive: %s", err)
+	}
+	if len(leagues.LeaguePositionDTOList.LeaguePosition) != 2 || leagues.SummonerID != "sid1" {
+		t.Errorf("GetLeaguesForSummoner returned wrong leagues: %v", leagues)
+	}
+	timeStamp, err := backend.GetLeaguesForSummonerTimeStamp("oldname")
+	if err != nil {
+		t.Fatalf("GetLeaguesForSummonerTimeStamp returned error: %s", err)
+	}
+	checkTimeStamp(t, "GetLeaguesForSummonerTimeStamp", timeStamp, timestamp(2))
+
+	// Name change: same Summoner ID, new name replaces the old entry
+	if err := backend.StoreLeaguesForSummoner(summonerLeagues("newname", "sid1", 7)); err != nil {
+		t.Fatalf("StoreLeaguesForSummoner returned error: %s", err)
+	}
+	if _, err := backend.GetLeaguesForSummoner("oldname"); err == nil {
+		t.Errorf("GetLeaguesForSummoner still finds the leagues under the old Summoner name")
+	}
+
+	leagues, err = backend.GetLeaguesForSummonerBySummonerID("sid1")
+	if err != nil {
+		t.Fatalf("GetLeaguesForSummonerBySummonerID returned error: %s", err)
+	}
+	if leagues.SummonerName != "newname" || len(leagues.LeaguePositionDTOList.LeaguePosition) != 1 {
+		t.Errorf("GetLeaguesForSummonerBySummonerID returned wrong leagues: %v", leagues)
+	}
+	timeStamp, err = backend.GetLeaguesForSummonerBySummonerIDTimeStamp("sid1")
+	if err != nil {
+		t.Fatalf("GetLeaguesForSummonerBySummonerIDTimeStamp returned error: %s", err)
+	}
+	checkTimeStamp(t, "GetLeaguesForSummonerBySummonerIDTimeStamp", timeStamp, timestamp(7))
+}
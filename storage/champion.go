@@ -11,7 +11,8 @@ import (
 // forceUpdate will try to update the champion, if it is false the config settings will be considered if update is required
 func (s *Storage) GetChampions(forceUpdate bool) riotclient.ChampionsList {
 	duration := time.Since(s.backend.GetChampionsTimeStamp())
-	if (duration.Minutes() > float64(s.config.MaxAgeChampion)) || forceUpdate {
+	expired := duration.Minutes() > float64(s.config.MaxAgeChampion) && !s.config.OfflineStaticData
+	if expired || forceUpdate {
 		champions, err := s.riotClient.Champions()
 		if err != nil {
 			s.log.Warnln(err)
@@ -111,3 +112,26 @@ func (s *Storage) GetChampionByKey(key string, forceUpdate bool) (riotclient.Cha
 
 	return champion, fmt.Errorf("Champion with Key %s not found", key)
 }
+
+// GetChampionDetails returns the full data of a champion, including spells, passive, skins and tips
+// championID is the ID of the champion, e.g., Annie
+// gameVersion is the Data Dragon game version we want to have, e.g., 9.10.1
+// language is the langauge that we want to have
+func (s *Storage) GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	championDetails, err := s.backend.GetChampionDetails(championID, gameVersion, language)
+	if err != nil {
+		championDetails, errClient := s.riotClient.ChampionDetailsSpecificVersionLanguage(championID, gameVersion, language)
+		if errClient != nil {
+			s.log.Warnln(errClient)
+			return nil, riotclient.WrapError(errClient, "Could not get Champion Details from Backend or Client: %s", errClient)
+		}
+		s.log.Debugf("Could not get Champion Details from storage backend, returning from Client instead: %s", err)
+		err = s.backend.StoreChampionDetails(gameVersion, language, championDetails)
+		if err != nil {
+			s.log.Warnln("Could not store Champion Details in storage backend:", err)
+		}
+		return championDetails, nil
+	}
+
+	return championDetails, nil
+}
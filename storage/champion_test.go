@@ -273,3 +273,49 @@ func TestGettingChampionList(t *testing.T) {
 		t.Error("Should have returned nil")
 	}
 }
+
+func TestGettingChampionListOffline(t *testing.T) {
+	config := config.LoLStorage{MaxAgeChampion: 120, OfflineStaticData: true, DefaultRiotClient: "euw1"}
+	riotClient := &mockClient{}
+	backend := &mockBackend{}
+
+	storage, err := NewStorage(config, map[string]riotclient.Client{"euw1": riotClient}, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	championsListBackend := riotclient.ChampionsList{"3": riotclient.Champion{Name: "BACKEND CHAMP", ID: "3"}}
+	championsListClient := riotclient.ChampionsList{"43222": riotclient.Champion{Name: "CLIENT CHAMP", ID: "43222"}}
+
+	// Stored list with expired TimeStamp in backend should still get it from backend
+	riotClient.setChampions(championsListClient)
+	backend.setChampions(championsListBackend)
+	backend.setChampionsTimeStamp(time.Now().Add(-time.Minute * time.Duration(config.MaxAgeChampion+1)))
+
+	actualChampions := storage.GetChampions(false)
+
+	if backend.getChampionsRetrieved() != true {
+		t.Errorf("Storage did not get Champions from backend even though it should have")
+	}
+	if riotClient.getChampionsRetrieved() != false {
+		t.Errorf("Storage did get the Champions from client")
+	}
+	if actualChampions["3"].Name != "BACKEND CHAMP" {
+		t.Errorf("Got Champions %v, want the ones of the backend", actualChampions)
+	}
+
+	// A forced update should still get it from client
+	riotClient.reset()
+	riotClient.setChampions(championsListClient)
+	backend.reset()
+	backend.setChampions(championsListBackend)
+
+	actualChampions = storage.GetChampions(true)
+
+	if riotClient.getChampionsRetrieved() != true {
+		t.Errorf("Storage did not get the Champions from client")
+	}
+	if actualChampions["43222"].Name != "CLIENT CHAMP" {
+		t.Errorf("Got Champions %v, want the ones of the client", actualChampions)
+	}
+}
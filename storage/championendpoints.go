@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync/atomic"
 
 	"git.abyle.org/hps/alolstats/utils"
@@ -137,6 +139,18 @@ func (s *Storage) championByIDEndpoint(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	details := false
+	if _, ok := r.URL.Query()["details"]; ok {
+		detailsParameter, err := extractURLStringParameter(r.URL.Query(), "details")
+		if err == nil {
+			details, err = strconv.ParseBool(detailsParameter)
+		}
+		if err != nil {
+			http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, fmt.Sprintf("details parameter must be true or false")), http.StatusBadRequest)
+			return
+		}
+	}
+
 	champion, err := s.GetChampionByID(id, false)
 	if err != nil {
 		s.log.Warnf("Could not get Champion with ID %s", id)
@@ -149,7 +163,29 @@ func (s *Storage) championByIDEndpoint(w http.ResponseWriter, r *http.Request) {
 		champion.Roles = stats.Roles
 	}
 
-	out, err := json.Marshal(champion)
+	var response interface{} = champion
+	if details {
+		language := "en_US"
+		if _, ok := r.URL.Query()["language"]; ok {
+			language, err = extractURLStringParameter(r.URL.Query(), "language")
+			if err != nil {
+				http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, err.Error()), http.StatusBadRequest)
+				return
+			}
+		}
+
+		championDetails, err := s.GetChampionDetails(champion.ID, dataDragonVersion(gameVersion), language)
+		if err != nil {
+			s.log.Warnf("Could not get Champion Details for Champion ID %s, gameversion %s, language %s: %s", champion.ID, gameVersion, language, err)
+			status := statusCodeForError(err, http.StatusNotFound)
+			http.Error(w, utils.GenerateStatusResponse(uint16(status), fmt.Sprintf("Could not get Champion Details for Champion ID %s, gameversion %s, language %s", champion.ID, gameVersion, language)), status)
+			return
+		}
+		championDetails.Roles = champion.Roles
+		response = championDetails
+	}
+
+	out, err := json.Marshal(response)
 	if err != nil {
 		s.log.Errorln(err)
 		http.Error(w, utils.GenerateStatusResponse(http.StatusInternalServerError, fmt.Sprintf("Problem converting Champion to JSON")), http.StatusInternalServerError)
@@ -159,3 +195,12 @@ func (s *Storage) championByIDEndpoint(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Cache-Control", s.getHTTPGetResponseHeader("Cache-Control"))
 	io.WriteString(w, string(out))
 }
+
+// dataDragonVersion returns the Data Dragon version for a game version, e.g., 9.10.1 for 9.10.
+// Game versions with three or more parts are returned unchanged.
+func dataDragonVersion(gameVersion string) string {
+	if strings.Count(gameVersion, ".") == 1 {
+		return gameVersion + ".1"
+	}
+	return gameVersion
+}
@@ -0,0 +1,102 @@
+package storage
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func TestChampionByIDEndpointDetails(t *testing.T) {
+	config := config.LoLStorage{}
+	riotClient := &mockClient{}
+	backend := &mockBackend{}
+	backend.reset()
+
+	config.MaxAgeChampion = 120
+	config.DefaultRiotClient = "euw1"
+
+	storage, err := NewStorage(config, map[string]riotclient.Client{"euw1": riotClient}, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	annie := riotclient.Champion{ID: "Annie", Key: "1", Name: "Annie", Timestamp: time.Now()}
+	backend.setChampions(riotclient.ChampionsList{"Annie": annie})
+	backend.setChampionsTimeStamp(time.Now())
+	riotClient.championDetails = &riotclient.ChampionDetails{
+		Champion: annie,
+		Spells: []riotclient.ChampionSpell{
+			{ID: "Disintegrate", Name: "Disintegrate", Maxrank: 5, Cooldown: []float64{4, 4, 4, 4, 4}},
+			{ID: "Incinerate", Name: "Incinerate", Maxrank: 5},
+		},
+		Passive: riotclient.ChampionPassive{Name: "Pyromania"},
+	}
+
+	get := func(url string) (int, []byte) {
+		req := httptest.NewRequest("GET", url, nil)
+		w := httptest.NewRecorder()
+		storage.championByIDEndpoint(w, req)
+		resp := w.Result()
+		body, _ := ioutil.ReadAll(resp.Body)
+		return resp.StatusCode, body
+	}
+
+	// Without details only the summary is returned
+	status, body := get("http://example.com/endpoint?id=Annie&gameversion=9.10&tier=ALL&queue=RANKED_SOLO")
+	if status != 200 {
+		t.Fatalf("Did not get correct status, status received was: %d", status)
+	}
+	if riotClient.championDetailsRetrieved != 0 {
+		t.Errorf("Champion Details were retrieved without details=true")
+	}
+
+	// With details the spells and the passive are included, fetched from the client once and then served from the backend
+	for i := 0; i < 2; i++ {
+		status, body = get("http://example.com/endpoint?id=Annie&gameversion=9.10&tier=ALL&queue=RANKED_SOLO&details=true&language=de_DE")
+		if status != 200 {
+			t.Fatalf("Did not get correct status, status received was: %d", status)
+		}
+
+		received := riotclient.ChampionDetails{}
+		err = json.Unmarshal(body, &received)
+		if err != nil {
+			t.Fatalf("Decoding the json into the correct data struct was not possible: %s", err)
+		}
+		if received.Key != "1" || len(received.Spells) != 2 || received.Spells[0].Cooldown[4] != 4 || received.Passive.Name != "Pyromania" {
+			t.Errorf("Received wrong Champion Details: %+v", received)
+		}
+	}
+	if riotClient.championDetailsRetrieved != 1 {
+		t.Errorf("Champion Details were retrieved %d times from client, want 1", riotClient.championDetailsRetrieved)
+	}
+	if _, err := backend.GetChampionDetails("Annie", "9.10.1", "de_DE"); err != nil {
+		t.Errorf("Champion Details were not stored for Data Dragon version 9.10.1 and de_DE: %s", err)
+	}
+
+	if status, _ = get("http://example.com/endpoint?id=Annie&gameversion=9.10&tier=ALL&queue=RANKED_SOLO&details=maybe"); status != 400 {
+		t.Errorf("Got status %d for invalid details parameter, want 400", status)
+	}
+
+	// Champion Details which are neither stored nor provided by the client are not found, errors of the Riot API are passed on
+	riotClient.championDetails = nil
+	if status, _ = get("http://example.com/endpoint?id=Annie&gameversion=9.9&tier=ALL&queue=RANKED_SOLO&details=true"); status != 404 {
+		t.Errorf("Got status %d for unknown Champion Details, want 404", status)
+	}
+	riotClient.championDetailsErr = riotclient.NewError(riotclient.ErrorServerError, "Service unavailable")
+	if status, _ = get("http://example.com/endpoint?id=Annie&gameversion=9.9&tier=ALL&queue=RANKED_SOLO&details=true"); status != 502 {
+		t.Errorf("Got status %d for a Riot API error, want 502", status)
+	}
+}
+
+func TestDataDragonVersion(t *testing.T) {
+	for gameVersion, want := range map[string]string{"9.10": "9.10.1", "9.10.1": "9.10.1", "10.1.2": "10.1.2"} {
+		if got := dataDragonVersion(gameVersion); got != want {
+			t.Errorf("dataDragonVersion(%s) = %s, want %s", gameVersion, got, want)
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package storage
+
+import (
+	"fmt"
+	"time"
+
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+// ChampionMasteries is the storage type used for the Champion Masteries of a Summoner
+type ChampionMasteries struct {
+	ChampionMasteryDTOList riotclient.ChampionMasteryDTOList
+	SummonerID             string
+}
+
+// ChampionMasteryScore returns the total Champion Mastery score, i.e., the sum of all Champion Mastery levels
+func ChampionMasteryScore(masteries *riotclient.ChampionMasteryDTOList) int {
+	score := 0
+	for _, mastery := range masteries.ChampionMasteries {
+		score += mastery.ChampionLevel
+	}
+	return score
+}
+
+func (s *Storage) storeChampionMasteries(summonerID string, masteries *riotclient.ChampionMasteryDTOList) error {
+	return s.backend.StoreChampionMasteries(&ChampionMasteries{
+		ChampionMasteryDTOList: *masteries,
+		SummonerID:             summonerID,
+	})
+}
+
+// getChampionMasteriesBySummonerIDFromClient returns the Champion Masteries of a Summoner identified by Summoner ID
+// forceUpdate will try to update the Champion Masteries, if it is false the config settings will be considered if update is required
+func (s *Storage) getChampionMasteriesBySummonerIDFromClient(client riotclient.Client, summonerID string, forceUpdate bool) (riotclient.ChampionMasteryDTOList, error) {
+	if len(summonerID) == 0 {
+		return riotclient.ChampionMasteryDTOList{}, fmt.Errorf("Summoner ID cannot be empty")
+	}
+	duration := time.Since(s.backend.GetChampionMasteriesTimeStamp(summonerID))
+	if (duration.Minutes() > float64(s.config.MaxAgeChampionMastery)) || forceUpdate {
+		masteries, err := client.ChampionMasteriesBySummonerID(summonerID)
+		if err != nil {
+			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
+			masteries, errBackend := s.backend.GetChampionMasteries(summonerID)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
+				return riotclient.ChampionMasteryDTOList{}, err
+			}
+			s.log.Debugf("Returned Champion Masteries for Summoner with SummonerID %s from Storage", summonerID)
+			return masteries.ChampionMasteryDTOList, nil
+		}
+		err = s.storeChampionMasteries(summonerID, masteries)
+		if err != nil {
+			s.log.Warnln("Could not store Champion Masteries in storage backend:", err)
+		}
+		s.log.Debugf("Returned Champion Masteries for Summoner with SummonerID %s from Riot API", summonerID)
+		return *masteries, nil
+	}
+	masteries, err := s.backend.GetChampionMasteries(summonerID)
+	if err != nil {
+		masteries, errClient := client.ChampionMasteriesBySummonerID(summonerID)
+		if errClient != nil {
+			s.log.Warnln("Could not get data from either Storage nor Client:", errClient)
+			return riotclient.ChampionMasteryDTOList{}, errClient
+		}
+		s.log.Warnln("Could not get Champion Masteries from storage backend, returning from Client instead:", err)
+		err = s.storeChampionMasteries(summonerID, masteries)
+		if err != nil {
+			s.log.Warnln("Could not store Champion Masteries in storage backend:", err)
+		}
+		s.log.Debugf("Returned Champion Masteries for Summoner with SummonerID %s from Riot API", summonerID)
+		return *masteries, nil
+	}
+	s.log.Debugf("Returned Champion Masteries for Summoner with SummonerID %s from Storage", summonerID)
+	return masteries.ChampionMasteryDTOList, nil
+}
+
+// GetChampionMasteriesBySummonerID returns the Champion Masteries of a Summoner identified by Summoner ID
+// forceUpdate will try to update the Champion Masteries, if it is false the config settings will be considered if update is required
+func (s *Storage) GetChampionMasteriesBySummonerID(summonerID string, forceUpdate bool) (riotclient.ChampionMasteryDTOList, error) {
+	return s.getChampionMasteriesBySummonerIDFromClient(s.riotClient, summonerID, forceUpdate)
+}
+
+// GetRegionalChampionMasteriesBySummonerID returns the Champion Masteries of a Summoner identified by Summoner ID for a specific region
+// forceUpdate will try to update the Champion Masteries, if it is false the config settings will be considered if update is required
+func (s *Storage) GetRegionalChampionMasteriesBySummonerID(region string, summonerID string, forceUpdate bool) (riotclient.ChampionMasteryDTOList, error) {
+	if client, ok := s.riotClients[region]; ok {
+		return s.getChampionMasteriesBySummonerIDFromClient(client, summonerID, forceUpdate)
+	}
+	return riotclient.ChampionMasteryDTOList{}, fmt.Errorf("Invalid region specified: %s", region)
+}
@@ -0,0 +1,78 @@
+package storage
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CrawlEntry is an account in the crawl frontier of a region, i.e., an account whose matches are fetched by the FetchRunner
+type CrawlEntry struct {
+	Region    string
+	AccountID string
+	// Entries with a higher priority are crawled first
+	Priority int
+	// Tier of the account, e.g., GOLD, empty if not known
+	Tier string
+	// When the matches of the account were fetched the last time, zero if never
+	LastCrawled time.Time
+	// Creation time of the newest match seen for the account in ms since epoch, 0 if none was seen yet
+	LastMatchTimestamp int64
+	// When the account was added to the crawl frontier
+	Added time.Time
+}
+
+// AddCrawlAccount adds an account to the crawl frontier of a region. If the account is already known,
+// its crawl progress is kept, its priority is raised if the given one is higher and its tier is updated if one is given.
+func (s *Storage) AddCrawlAccount(region string, accountID string, priority int, tier string) error {
+	if len(accountID) == 0 {
+		return fmt.Errorf("Account ID cannot be empty")
+	}
+	region = strings.ToLower(region)
+
+	entry, err := s.backend.GetCrawlEntry(region, accountID)
+	if err != nil {
+		return s.backend.StoreCrawlEntry(&CrawlEntry{
+			Region:    region,
+			AccountID: accountID,
+			Priority:  priority,
+			Tier:      tier,
+			Added:     time.Now(),
+		})
+	}
+
+	if entry.Priority >= priority && (len(tier) == 0 || entry.Tier == tier) {
+		return nil
+	}
+	if priority > entry.Priority {
+		entry.Priority = priority
+	}
+	if len(tier) > 0 {
+		entry.Tier = tier
+	}
+	return s.backend.StoreCrawlEntry(entry)
+}
+
+// GetCrawlBatch returns up to n accounts of the crawl frontier of a region with at least the given priority which were not
+// crawled since crawledBefore. Accounts with a higher priority come first, then the ones which were not crawled for the longest time.
+func (s *Storage) GetCrawlBatch(region string, minPriority int, crawledBefore time.Time, n int) ([]CrawlEntry, error) {
+	return s.backend.GetCrawlEntries(strings.ToLower(region), minPriority, crawledBefore, n)
+}
+
+// StoreCrawlEntry stores the crawl progress of an account in the crawl frontier
+func (s *Storage) StoreCrawlEntry(entry *CrawlEntry) error {
+	entry.Region = strings.ToLower(entry.Region)
+	return s.backend.StoreCrawlEntry(entry)
+}
+
+// CrawlEntryLess returns true if entry a has to be crawled before entry b. It defines the order of the crawl
+// entries returned by Backends: higher priority first, then the ones crawled the longest time ago, then by account id.
+func CrawlEntryLess(a, b CrawlEntry) bool {
+	if a.Priority != b.Priority {
+		return a.Priority > b.Priority
+	}
+	if !a.LastCrawled.Equal(b.LastCrawled) {
+		return a.LastCrawled.Before(b.LastCrawled)
+	}
+	return a.AccountID < b.AccountID
+}
@@ -0,0 +1,55 @@
+package storage
+
+import (
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func TestAddCrawlAccount(t *testing.T) {
+	backend := &mockBackend{}
+	backend.reset()
+
+	storage, err := NewStorage(config.LoLStorage{DefaultRiotClient: "euw1"}, map[string]riotclient.Client{"euw1": &mockClient{}}, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	if err := storage.AddCrawlAccount("EUW1", "a1", 1, ""); err != nil {
+		t.Fatalf("AddCrawlAccount returned error: %s", err)
+	}
+	crawled := time.Now().Add(-time.Hour)
+	if err := storage.StoreCrawlEntry(&CrawlEntry{Region: "euw1", AccountID: "a1", Priority: 1, LastCrawled: crawled, LastMatchTimestamp: 42}); err != nil {
+		t.Fatalf("StoreCrawlEntry returned error: %s", err)
+	}
+
+	// Adding a known account again keeps its progress, only raises its priority and keeps a known tier
+	for _, tier := range []string{"GOLD", ""} {
+		if err := storage.AddCrawlAccount("euw1", "a1", 2, tier); err != nil {
+			t.Fatalf("AddCrawlAccount returned error: %s", err)
+		}
+	}
+	for _, priority := range []int{2, 1} {
+		if err := storage.AddCrawlAccount("euw1", "a1", priority, ""); err != nil {
+			t.Fatalf("AddCrawlAccount returned error: %s", err)
+		}
+	}
+	entry, err := backend.GetCrawlEntry("euw1", "a1")
+	if err != nil {
+		t.Fatalf("Crawl entry was not stored: %s", err)
+	}
+	if entry.Priority != 2 || entry.Tier != "GOLD" || !entry.LastCrawled.Equal(crawled) || entry.LastMatchTimestamp != 42 {
+		t.Errorf("AddCrawlAccount did not keep the crawl progress, raise the priority or set the tier: %+v", entry)
+	}
+
+	if err := storage.AddCrawlAccount("euw1", "", 1, ""); err == nil {
+		t.Errorf("AddCrawlAccount with empty Account ID returned no error")
+	}
+
+	batch, err := storage.GetCrawlBatch("EUW1", 0, time.Now(), 10)
+	if err != nil || len(batch) != 1 || batch[0].AccountID != "a1" {
+		t.Errorf("GetCrawlBatch = %v, %v, want entry a1", batch, err)
+	}
+}
@@ -2,35 +2,113 @@ package storage
 
 import (
 	"fmt"
+	"strings"
 	"time"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 	"git.abyle.org/hps/alolstats/utils"
 )
 
+// League is the storage type used for League List snapshots
+type League struct {
+	LeagueListDTO riotclient.LeagueListDTO
+	Region        string
+	Tier          string
+	Queue         string
+	Timestamp     time.Time
+}
+
+// leagueTier returns the tier for a league endpoint name, e.g., MASTER for masterleagues
+func leagueTier(league string) string {
+	return strings.ToUpper(strings.TrimSuffix(strings.ToLower(league), "leagues"))
+}
+
+func (s *Storage) storeLeague(region string, league string, leagueData *riotclient.LeagueListDTO) error {
+	timestamp := leagueData.Timestamp
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	return s.backend.StoreLeague(&League{
+		LeagueListDTO: *leagueData,
+		Region:        region,
+		Tier:          leagueTier(league),
+		Queue:         leagueData.Queue,
+		Timestamp:     timestamp,
+	})
+}
+
+// getLeagueByQueueFromClient returns a league identified by its name for a specific queue name
+// The config settings will be considered if an update is required
+func (s *Storage) getLeagueByQueueFromClient(client riotclient.Client, region string, league string, queue string) (*riotclient.LeagueListDTO, error) {
+	timeStamp, _ := s.backend.GetLeagueByQueueTimeStamp(region, leagueTier(league), queue)
+	duration := time.Since(timeStamp)
+	if duration.Minutes() > float64(s.config.MaxAgeLeague) {
+		leagueData, err := client.LeagueByQueue(league, queue)
+		if err != nil {
+			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
+			stored, errBackend := s.backend.GetLeagueByQueue(region, leagueTier(league), queue)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
+				return nil, err
+			}
+			s.log.Debugf("Returned %s for queue %s in region %s from Storage", league, queue, region)
+			return &stored.LeagueListDTO, nil
+		}
+		err = s.storeLeague(region, league, leagueData)
+		if err != nil {
+			s.log.Warnln("Could not store League in storage backend:", err)
+		}
+		s.log.Debugf("Returned %s for queue %s in region %s from Riot API", league, queue, region)
+		return leagueData, nil
+	}
+	stored, err := s.backend.GetLeagueByQueue(region, leagueTier(league), queue)
+	if err != nil {
+		leagueData, errClient := client.LeagueByQueue(league, queue)
+		if errClient != nil {
+			s.log.Warnln("Could not get data from either Storage nor Client:", errClient)
+			return nil, errClient
+		}
+		s.log.Warnln("Could not get League from storage backend, returning from Client instead:", err)
+		err = s.storeLeague(region, league, leagueData)
+		if err != nil {
+			s.log.Warnln("Could not store League in storage backend:", err)
+		}
+		s.log.Debugf("Returned %s for queue %s in region %s from Riot API", league, queue, region)
+		return leagueData, nil
+	}
+	s.log.Debugf("Returned %s for queue %s in region %s from Storage", league, queue, region)
+	return &stored.LeagueListDTO, nil
+}
+
 // GetLeagueByQueue returns a league identified by its name for a specific queue name
 func (s *Storage) GetLeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error) {
 	s.log.Debugf("GetLeagueByQueue(%s, %s)", league, queue)
-	leagueData, errClient := s.riotClient.LeagueByQueue(league, queue)
-	if errClient != nil {
-		s.log.Warnln("Could not get data from Riot API:", errClient)
-		return nil, errClient
-	}
-	s.log.Debugf("Returned %s for queue %s from Riot API", league, queue)
-	return leagueData, nil
+	return s.getLeagueByQueueFromClient(s.riotClient, s.config.DefaultRiotClient, league, queue)
 }
 
 // GetRegionalLeagueByQueue returns a league identified by its name for a specific queue name in a specific region
 func (s *Storage) GetRegionalLeagueByQueue(region string, league string, queue string) (*riotclient.LeagueListDTO, error) {
 	s.log.Debugf("GetRegionalLeagueByQueue(%s, %s, %s)", region, league, queue)
 	if client, ok := s.riotClients[region]; ok {
-		leagueData, errClient := client.LeagueByQueue(league, queue)
-		if errClient != nil {
-			s.log.Warnln("Could not get data from Riot API:", errClient)
-			return nil, errClient
-		}
-		s.log.Debugf("Returned %s for queue %s from Riot API", league, queue)
-		return leagueData, nil
+		return s.getLeagueByQueueFromClient(client, region, league, queue)
+	}
+	return nil, fmt.Errorf("Invalid region specified: %s", region)
+}
+
+// GetRegionalLeagueHistoryByQueue returns all stored snapshots of a league identified by its name for a specific queue name
+// in a specific region which were taken between from and to, sorted from oldest to newest
+func (s *Storage) GetRegionalLeagueHistoryByQueue(region string, league string, queue string, from time.Time, to time.Time) ([]League, error) {
+	if _, ok := s.riotClients[region]; !ok {
+		return nil, fmt.Errorf("Invalid region specified: %s", region)
+	}
+	return s.backend.GetLeagueHistoryByQueue(region, leagueTier(league), queue, from, to)
+}
+
+// GetRegionalLeagueEntries returns one page of the entries of a tier and division in a queue for a specific region.
+// League entries change constantly and are therefore not stored, they are always fetched from the Riot API.
+func (s *Storage) GetRegionalLeagueEntries(region string, queue string, tier string, division string, page int) ([]riotclient.LeagueEntryDTO, error) {
+	if client, ok := s.riotClients[region]; ok {
+		return client.LeagueEntries(queue, tier, division, page)
 	}
 	return nil, fmt.Errorf("Invalid region specified: %s", region)
 }
@@ -65,9 +143,9 @@ func (s *Storage) GetLeaguesForSummonerBySummonerID(summonerID string, forceUpda
 		leagues, err := s.riotClient.LeaguesForSummoner(summonerID)
 		if err != nil {
 			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
-			leagues, err := s.backend.GetLeaguesForSummonerBySummonerID(summonerID)
-			if err != nil {
-				s.log.Warnln("Could not get data from either Storage nor Client:", err)
+			leagues, errBackend := s.backend.GetLeaguesForSummonerBySummonerID(summonerID)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
 				return riotclient.LeaguePositionDTOList{}, err
 			}
 			s.log.Debugf("Returned Leagues for Summoner with SummonerID %s from Storage", summonerID)
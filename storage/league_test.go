@@ -0,0 +1,102 @@
+package storage_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/memorybackend"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// The league tests are an external test package, as the memory backend imports storage
+
+// leagueClient is a Riot client which only answers LeagueByQueue
+type leagueClient struct {
+	riotclient.Client
+
+	league *riotclient.LeagueListDTO
+	err    error
+	calls  int
+}
+
+func (c *leagueClient) LeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error) {
+	c.calls++
+	if c.err != nil {
+		return nil, c.err
+	}
+	return c.league, nil
+}
+
+func TestGetRegionalLeagueByQueue(t *testing.T) {
+	tests := []struct {
+		name          string
+		storedAge     time.Duration
+		clientErr     error
+		wantName      string
+		wantCalls     int
+		wantSnapshots int
+	}{
+		{"Fresh snapshot is returned from storage", 10 * time.Minute, nil, "stored", 0, 1},
+		{"Stale snapshot is fetched again", 2 * time.Hour, nil, "fetched", 1, 2},
+		{"Stale snapshot is returned on client error", 2 * time.Hour, fmt.Errorf("Riot API unavailable"), "stored", 1, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend, err := memorybackend.NewBackend()
+			if err != nil {
+				t.Fatalf("Could not create memory backend: %s", err)
+			}
+			client := &leagueClient{
+				league: &riotclient.LeagueListDTO{Name: "fetched", Tier: "MASTER", Queue: "RANKED_SOLO_5x5"},
+				err:    tt.clientErr,
+			}
+			s, err := storage.NewStorage(config.LoLStorage{DefaultRiotClient: "euw1", MaxAgeLeague: 60},
+				map[string]riotclient.Client{"euw1": client}, backend)
+			if err != nil {
+				t.Fatalf("Could not get a new Storage: %s", err)
+			}
+
+			storedAt := time.Now().Add(-tt.storedAge)
+			if err := backend.StoreLeague(&storage.League{
+				LeagueListDTO: riotclient.LeagueListDTO{Name: "stored", Tier: "MASTER", Queue: "RANKED_SOLO_5x5"},
+				Region:        "euw1",
+				Tier:          "MASTER",
+				Queue:         "RANKED_SOLO_5x5",
+				Timestamp:     storedAt,
+			}); err != nil {
+				t.Fatalf("Could not store League: %s", err)
+			}
+
+			league, err := s.GetRegionalLeagueByQueue("euw1", "masterleagues", "RANKED_SOLO_5x5")
+			if err != nil {
+				t.Fatalf("GetRegionalLeagueByQueue returned error: %s", err)
+			}
+			if league.Name != tt.wantName {
+				t.Errorf("Got League %s, want %s", league.Name, tt.wantName)
+			}
+			if client.calls != tt.wantCalls {
+				t.Errorf("Riot client was called %d times, want %d", client.calls, tt.wantCalls)
+			}
+
+			history, err := s.GetRegionalLeagueHistoryByQueue("euw1", "masterleagues", "RANKED_SOLO_5x5", storedAt, time.Now())
+			if err != nil {
+				t.Fatalf("GetRegionalLeagueHistoryByQueue returned error: %s", err)
+			}
+			if len(history) != tt.wantSnapshots {
+				t.Errorf("Got %d stored snapshots, want %d", len(history), tt.wantSnapshots)
+			}
+		})
+	}
+
+	// Without a stored snapshot the client error is returned
+	backend, _ := memorybackend.NewBackend()
+	client := &leagueClient{err: fmt.Errorf("Riot API unavailable")}
+	s, _ := storage.NewStorage(config.LoLStorage{DefaultRiotClient: "euw1", MaxAgeLeague: 60},
+		map[string]riotclient.Client{"euw1": client}, backend)
+	if _, err := s.GetRegionalLeagueByQueue("euw1", "masterleagues", "RANKED_SOLO_5x5"); err == nil {
+		t.Errorf("GetRegionalLeagueByQueue without stored snapshot and client returned no error")
+	}
+}
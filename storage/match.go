@@ -3,13 +3,14 @@ package storage
 import (
 	"fmt"
 	"strconv"
+	"strings"
 
 	"git.abyle.org/hps/alolstats/riotclient"
 )
 
-// getMatch gets a match from storage or riot client based on GameID
-func (s *Storage) getMatchFromClient(client riotclient.Client, id uint64) (riotclient.MatchDTO, error) {
-	match, err := s.backend.GetMatch(id)
+// getMatchFromClient gets a match from storage or riot client based on platform and GameID
+func (s *Storage) getMatchFromClient(client riotclient.Client, platformID string, id uint64) (riotclient.MatchDTO, error) {
+	match, err := s.backend.GetMatch(platformID, id)
 	if err != nil {
 		s.log.Warnln(err)
 		match, err := client.MatchByID(id)
@@ -17,52 +18,92 @@ func (s *Storage) getMatchFromClient(client riotclient.Client, id uint64) (riotc
 			s.log.Warnln(err)
 			return riotclient.MatchDTO{}, err
 		}
-		s.log.Debugf("Returned Match %d from Riot API", id)
+		s.log.Debugf("Returned Match %d for platform %s from Riot API", id, platformID)
 		s.backend.StoreMatch(match)
 		return *match, nil
 	}
-	s.log.Debugf("Returned Match %d from Storage", id)
+	s.log.Debugf("Returned Match %d for platform %s from Storage", id, platformID)
 	return *match, nil
 }
 
-// GetMatch gets a match from storage or riot client based on GameID
+// GetMatch gets a match from storage or riot client based on GameID for the default region
 func (s *Storage) GetMatch(id uint64) (riotclient.MatchDTO, error) {
-	return s.getMatchFromClient(s.riotClient, id)
+	return s.getMatchFromClient(s.riotClient, s.platformID(s.config.DefaultRiotClient), id)
 }
 
 // GetRegionalMatch gets a match from storage or riot client based on GameID for a specific region
 func (s *Storage) GetRegionalMatch(region string, id uint64) (riotclient.MatchDTO, error) {
 	if client, ok := s.riotClients[region]; ok {
-		return s.getMatchFromClient(client, id)
+		return s.getMatchFromClient(client, s.platformID(region), id)
 	}
 	return riotclient.MatchDTO{}, fmt.Errorf("Invalid region specified: %s", region)
 }
 
-// fetchAndStoreMatchFromClient gets a match from Riot Client and stores it in storage backend if it doesn't exist, yet
-func (s *Storage) fetchAndStoreMatchFromClient(client riotclient.Client, id uint64) (*riotclient.MatchDTO, error) {
-	_, err := s.backend.GetMatch(id)
+// MatchFilter decides which matches are stored. Empty lists and zero values accept all matches.
+type MatchFilter struct {
+	QueueIDs  []int
+	MapIDs    []int
+	SeasonIDs []int
+	// Creation time of the oldest match to accept in ms since epoch
+	MinGameCreation int64
+}
+
+func containsID(ids []int, id int) bool {
+	if len(ids) == 0 {
+		return true
+	}
+	for _, i := range ids {
+		if i == id {
+			return true
+		}
+	}
+	return false
+}
+
+// Accepts returns true if the match passes the filter
+func (f MatchFilter) Accepts(match *riotclient.MatchDTO) bool {
+	return containsID(f.QueueIDs, match.QueueID) &&
+		containsID(f.MapIDs, match.MapID) &&
+		containsID(f.SeasonIDs, match.SeasonID) &&
+		match.GameCreation >= f.MinGameCreation
+}
+
+// fetchAndStoreMatchFromClient gets a match from Riot Client and stores it in storage backend if it doesn't exist, yet.
+// Matches which do not pass the filter are returned, but not stored.
+func (s *Storage) fetchAndStoreMatchFromClient(client riotclient.Client, platformID string, id uint64, filter MatchFilter) (*riotclient.MatchDTO, error) {
+	_, err := s.backend.GetMatch(platformID, id)
 	if err != nil {
 		match, err := client.MatchByID(id)
 		if err != nil {
 			s.log.Warnln(err)
 			return nil, err
 		}
-		s.log.Debugf("Storing Match %d from Riot API in Backend", id)
+		if !filter.Accepts(match) {
+			s.log.Debugf("Not storing Match %d for platform %s, it does not pass the filter", id, platformID)
+			return match, nil
+		}
+		s.log.Debugf("Storing Match %d for platform %s from Riot API in Backend", id, platformID)
 		s.backend.StoreMatch(match)
 		return match, nil
 	}
 	return nil, nil
 }
 
-// FetchAndStoreMatch gets a match from Riot Client and stores it in storage backend if it doesn't exist, yet
+// FetchAndStoreMatch gets a match from Riot Client for the default region and stores it in storage backend if it doesn't exist, yet
 func (s *Storage) FetchAndStoreMatch(id uint64) (*riotclient.MatchDTO, error) {
-	return s.fetchAndStoreMatchFromClient(s.riotClient, id)
+	return s.fetchAndStoreMatchFromClient(s.riotClient, s.platformID(s.config.DefaultRiotClient), id, MatchFilter{})
 }
 
 // RegionalFetchAndStoreMatch gets a match from Riot Client for a specific region and stores it in storage backend if it doesn't exist, yet
 func (s *Storage) RegionalFetchAndStoreMatch(region string, id uint64) (*riotclient.MatchDTO, error) {
+	return s.RegionalFetchAndStoreFilteredMatch(region, id, MatchFilter{})
+}
+
+// RegionalFetchAndStoreFilteredMatch gets a match from Riot Client for a specific region and stores it in storage backend if it doesn't exist, yet,
+// and passes the filter. Matches which do not pass the filter are returned, but not stored.
+func (s *Storage) RegionalFetchAndStoreFilteredMatch(region string, id uint64, filter MatchFilter) (*riotclient.MatchDTO, error) {
 	if client, ok := s.riotClients[region]; ok {
-		return s.fetchAndStoreMatchFromClient(client, id)
+		return s.fetchAndStoreMatchFromClient(client, s.platformID(region), id, filter)
 	}
 	return nil, fmt.Errorf("Invalid region specified: %s", region)
 }
@@ -79,42 +120,99 @@ func (s *Storage) GetStoredMatchesByGameVersionChampionIDMapBetweenQueueIDs(game
 	return *matches, err
 }
 
-// GetStoredMatchesCursorByGameVersion returns cursor to matches specific to a certain game version
-func (s *Storage) GetStoredMatchesCursorByGameVersion(gameVersion string) (QueryCursor, error) {
-	return s.backend.GetMatchesCursorByGameVersion(gameVersion)
+// GetStoredMatchesCursorByGameVersion returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (s *Storage) GetStoredMatchesCursorByGameVersion(platformID string, gameVersion string) (QueryCursor, error) {
+	return s.backend.GetMatchesCursorByGameVersion(platformID, gameVersion)
+}
+
+// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (s *Storage) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
+	return s.backend.GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID, gameVersion, championID, mapID, ltequeue, gtequeue)
+}
+
+// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (s *Storage) GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
+	return s.backend.GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID, gameVersion, mapID, ltequeue, gtequeue)
 }
 
-// GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs returns cursor to matches specific to a certain game version
-func (s *Storage) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
-	return s.backend.GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(gameVersion, championID, mapID, ltequeue, gtequeue)
+// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain platform (all platforms if empty) and game version
+func (s *Storage) GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error) {
+	return s.backend.GetMatchesCursorByGameVersionMapQueueID(platformID, gameVersion, mapID, queueid)
 }
 
-// GetMatchesCursorByGameVersionMapBetweenQueueIDs returns cursor to matches specific to a certain game version
-func (s *Storage) GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
-	return s.backend.GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion, mapID, ltequeue, gtequeue)
+// MatchlistFilter restricts the match references returned for an Account ID. Zero values do not restrict anything.
+// Match lists cannot be filtered by map.
+type MatchlistFilter struct {
+	// Creation time of the oldest match to return in ms since epoch
+	BeginTime int64
+	// Queue IDs of the matches to return. API v5 supports only one queue per request, see RegionalMultipleMatchlistQueues
+	Queues []int
+	// Season IDs of the matches to return. API v5 does not support it and returns matches of all seasons
+	Season []int
 }
 
-// GetMatchesCursorByGameVersionMapQueueID returns cursor to matches specific to a certain game version
-func (s *Storage) GetMatchesCursorByGameVersionMapQueueID(gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error) {
-	return s.backend.GetMatchesCursorByGameVersionMapQueueID(gameVersion, mapID, queueid)
+// args returns the match list arguments for the filter. Several values of an argument are separated by commas.
+func (f MatchlistFilter) args() map[string]string {
+	args := make(map[string]string)
+	if f.BeginTime > 0 {
+		args["beginTime"] = strconv.FormatInt(f.BeginTime, 10)
+	}
+	if len(f.Queues) > 0 {
+		args["queue"] = joinIDs(f.Queues)
+	}
+	if len(f.Season) > 0 {
+		args["season"] = joinIDs(f.Season)
+	}
+	return args
+}
+
+func joinIDs(ids []int) string {
+	s := make([]string, 0, len(ids))
+	for _, id := range ids {
+		s = append(s, strconv.Itoa(id))
+	}
+	return strings.Join(s, ",")
+}
+
+// RegionalMultipleMatchlistQueues returns true if the match lists of a specific region can be filtered by several queues
+// in one request. Otherwise one match list per queue has to be fetched.
+func (s *Storage) RegionalMultipleMatchlistQueues(region string) bool {
+	if client, ok := s.riotClients[region].(riotclient.ClientMatchlistQueues); ok {
+		return client.MultipleMatchlistQueues()
+	}
+	return false
 }
 
-// getMatchesByAccountIDFromClient gets all match references for a specified Account ID and startIndex, endIndex
-func (s *Storage) getMatchesByAccountIDFromClient(client riotclient.Client, accountID string, beginIndex uint32, endIndex uint32) (*riotclient.MatchlistDTO, error) {
-	beginIndexStr := strconv.FormatInt(int64(beginIndex), 10)
-	endIndexStr := strconv.FormatInt(int64(endIndex), 10)
-	return client.MatchesByAccountID(accountID, map[string]string{"beginIndex": beginIndexStr, "endIndex": endIndexStr})
+// getMatchesByAccountIDFromClient gets all match references for a specified Account ID and startIndex, endIndex which pass the filter.
+// For Clients requesting match lists by PUUID, the PUUID is taken from the stored Summoner, so that it is not requested for every page.
+func (s *Storage) getMatchesByAccountIDFromClient(client riotclient.Client, accountID string, beginIndex uint32, endIndex uint32, filter MatchlistFilter) (*riotclient.MatchlistDTO, error) {
+	args := filter.args()
+	args["beginIndex"] = strconv.FormatInt(int64(beginIndex), 10)
+	args["endIndex"] = strconv.FormatInt(int64(endIndex), 10)
+
+	if puuidClient, ok := client.(riotclient.ClientMatchlistPUUID); ok {
+		summoner, err := s.getSummonerByAccountIDFromClient(client, accountID, false)
+		if err != nil {
+			return nil, err
+		}
+		if len(summoner.PuuID) > 0 {
+			return puuidClient.MatchesByPUUID(summoner.PuuID, args)
+		}
+	}
+
+	return client.MatchesByAccountID(accountID, args)
 }
 
 // GetMatchesByAccountID gets all match references for a specified Account ID and startIndex, endIndex
 func (s *Storage) GetMatchesByAccountID(accountID string, beginIndex uint32, endIndex uint32) (*riotclient.MatchlistDTO, error) {
-	return s.getMatchesByAccountIDFromClient(s.riotClient, accountID, beginIndex, endIndex)
+	return s.getMatchesByAccountIDFromClient(s.riotClient, accountID, beginIndex, endIndex, MatchlistFilter{})
 }
 
 // GetRegionalMatchesByAccountID gets all match references for a specified Account ID and startIndex, endIndex for a specific region
-func (s *Storage) GetRegionalMatchesByAccountID(region string, accountID string, beginIndex uint32, endIndex uint32) (*riotclient.MatchlistDTO, error) {
+// which pass the filter
+func (s *Storage) GetRegionalMatchesByAccountID(region string, accountID string, beginIndex uint32, endIndex uint32, filter MatchlistFilter) (*riotclient.MatchlistDTO, error) {
 	if client, ok := s.riotClients[region]; ok {
-		return s.getMatchesByAccountIDFromClient(client, accountID, beginIndex, endIndex)
+		return s.getMatchesByAccountIDFromClient(client, accountID, beginIndex, endIndex, filter)
 	}
 	return nil, fmt.Errorf("Invalid region specified: %s", region)
 }
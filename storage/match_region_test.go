@@ -0,0 +1,68 @@
+package storage_test
+
+import (
+	"testing"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/memorybackend"
+	"git.abyle.org/hps/alolstats/riotclient"
+	"git.abyle.org/hps/alolstats/storage"
+)
+
+// regionClient is a Riot client configured for a region, which only answers MatchByID and MatchTimeLineByID
+type regionClient struct {
+	riotclient.Client
+
+	region        string
+	matchCalls    int
+	timeLineCalls int
+}
+
+func (c *regionClient) Region() string {
+	return c.region
+}
+
+func (c *regionClient) MatchByID(id uint64) (*riotclient.MatchDTO, error) {
+	c.matchCalls++
+	return &riotclient.MatchDTO{GameID: int64(id), PlatformID: "EUW1", QueueID: 420, MapID: 11, GameVersion: "9.10.1.1"}, nil
+}
+
+func (c *regionClient) MatchTimeLineByID(id uint64) (*riotclient.MatchTimelineDTO, error) {
+	c.timeLineCalls++
+	return &riotclient.MatchTimelineDTO{FrameInterval: 60000}, nil
+}
+
+// The name of a Riot client is not the platform id of its matches, the configured region is
+func TestRegionalMatch_ClientRegion(t *testing.T) {
+	backend, err := memorybackend.NewBackend()
+	if err != nil {
+		t.Fatalf("Could not create memory backend: %s", err)
+	}
+	client := &regionClient{region: "euw1"}
+	s, err := storage.NewStorage(config.LoLStorage{DefaultRiotClient: "main"}, map[string]riotclient.Client{"main": client}, backend)
+	if err != nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	match, err := s.RegionalFetchAndStoreFilteredMatch("main", 1, storage.MatchFilter{})
+	if err != nil || match == nil {
+		t.Fatalf("RegionalFetchAndStoreFilteredMatch returned %v, %v", match, err)
+	}
+	if _, err := s.RegionalFetchAndStoreMatchTimeLine(match); err != nil || client.timeLineCalls != 1 {
+		t.Errorf("RegionalFetchAndStoreMatchTimeLine returned %v and called the client %d times, want 1", err, client.timeLineCalls)
+	}
+
+	// The stored match is found for the region of the client and not fetched again
+	if match, err := s.RegionalFetchAndStoreFilteredMatch("main", 1, storage.MatchFilter{}); err != nil || match != nil {
+		t.Errorf("RegionalFetchAndStoreFilteredMatch for a stored match returned %v, %v", match, err)
+	}
+	if _, err := s.GetRegionalMatch("main", 1); err != nil {
+		t.Errorf("GetRegionalMatch returned error: %s", err)
+	}
+	if _, err := s.GetMatch(1); err != nil {
+		t.Errorf("GetMatch returned error: %s", err)
+	}
+	if client.matchCalls != 1 {
+		t.Errorf("Match was fetched %d times from the client, want 1", client.matchCalls)
+	}
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"git.abyle.org/hps/alolstats/config"
+	"git.abyle.org/hps/alolstats/riotclient"
+)
+
+func TestGetRegionalMatchesByAccountID(t *testing.T) {
+	riotClient := &mockClient{}
+	backend := &mockBackend{}
+	backend.reset()
+
+	storage, err := NewStorage(config.LoLStorage{DefaultRiotClient: "euw1"}, map[string]riotclient.Client{"euw1": riotClient}, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	tests := []struct {
+		filter MatchlistFilter
+		want   map[string]string
+	}{
+		{MatchlistFilter{}, map[string]string{"beginIndex": "0", "endIndex": "100"}},
+		{MatchlistFilter{BeginTime: 1552564800001, Queues: []int{420}}, map[string]string{"beginIndex": "0", "endIndex": "100", "beginTime": "1552564800001", "queue": "420"}},
+		{MatchlistFilter{Queues: []int{400, 420}}, map[string]string{"beginIndex": "0", "endIndex": "100", "queue": "400,420"}},
+		{MatchlistFilter{Season: []int{12, 13}}, map[string]string{"beginIndex": "0", "endIndex": "100", "season": "12,13"}},
+	}
+	for _, tt := range tests {
+		if _, err := storage.GetRegionalMatchesByAccountID("euw1", "a1", 0, 100, tt.filter); err != nil {
+			t.Fatalf("GetRegionalMatchesByAccountID returned error: %s", err)
+		}
+		if !reflect.DeepEqual(riotClient.matchlistArgs, tt.want) {
+			t.Errorf("GetRegionalMatchesByAccountID with filter %+v called client with %v, want %v", tt.filter, riotClient.matchlistArgs, tt.want)
+		}
+	}
+
+	if _, err := storage.GetRegionalMatchesByAccountID("na1", "a1", 0, 100, MatchlistFilter{}); err == nil {
+		t.Errorf("GetRegionalMatchesByAccountID with invalid region returned no error")
+	}
+}
+
+// puuidClient is a Riot client which requests match lists by PUUID
+type puuidClient struct {
+	mockClient
+	matchlistPUUID string
+}
+
+func (c *puuidClient) MatchesByPUUID(PUUID string, args map[string]string) (s *riotclient.MatchlistDTO, err error) {
+	c.matchlistPUUID = PUUID
+	c.matchlistArgs = args
+	return &riotclient.MatchlistDTO{}, nil
+}
+
+func TestGetRegionalMatchesByAccountID_PUUID(t *testing.T) {
+	riotClient := &puuidClient{}
+	backend := &mockBackend{}
+	backend.reset()
+	backend.setSummoner(Summoner{SummonerDTO: riotclient.SummonerDTO{AccountID: "a1", PuuID: "puuid1", Timestamp: time.Now()}})
+
+	storage, err := NewStorage(config.LoLStorage{DefaultRiotClient: "euw1", MaxAgeSummoner: 120}, map[string]riotclient.Client{"euw1": riotClient}, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	// The PUUID of the stored Summoner is used for every page
+	for _, beginIndex := range []uint32{0, 100} {
+		if _, err := storage.GetRegionalMatchesByAccountID("euw1", "a1", beginIndex, beginIndex+100, MatchlistFilter{}); err != nil {
+			t.Fatalf("GetRegionalMatchesByAccountID returned error: %s", err)
+		}
+	}
+	if riotClient.matchlistPUUID != "puuid1" {
+		t.Errorf("Match list was requested for PUUID %s, want puuid1", riotClient.matchlistPUUID)
+	}
+	if riotClient.getWasSummonerRetrieved() {
+		t.Errorf("Summoner was requested from client even though it is stored")
+	}
+	want := map[string]string{"beginIndex": "100", "endIndex": "200"}
+	if !reflect.DeepEqual(riotClient.matchlistArgs, want) {
+		t.Errorf("Client was called with %v, want %v", riotClient.matchlistArgs, want)
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync/atomic"
 
 	"git.abyle.org/hps/alolstats/utils"
@@ -19,17 +20,24 @@ func (s *Storage) getMatchEndpoint(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, err.Error()), http.StatusBadRequest)
 		return
 	}
-	idNum, err := strconv.ParseUint(id, 10, 32)
+	idNum, err := strconv.ParseUint(id, 10, 64)
 	if err != nil {
 		s.log.Warnf("Could not convert value %s to GameID", id)
 		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, fmt.Sprintf("Provided game ID %s not a positive number", id)), http.StatusBadRequest)
 		return
 	}
 
-	match, err := s.GetMatch(idNum)
+	// The platform is optional, without it the match is looked up in the default region
+	platform := strings.ToLower(r.URL.Query().Get("platform"))
+	if len(platform) == 0 {
+		platform = s.config.DefaultRiotClient
+	}
+
+	match, err := s.GetRegionalMatch(platform, idNum)
 	if err != nil {
-		s.log.Warnf("Could not get match for id %d", idNum)
-		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, fmt.Sprintf("Could not get match for id %d", idNum)), http.StatusBadRequest)
+		s.log.Warnf("Could not get match for id %d on platform %s: %s", idNum, platform, err)
+		status := statusCodeForError(err, http.StatusBadRequest)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), fmt.Sprintf("Could not get match for id %d on platform %s", idNum, platform)), status)
 		return
 	}
 
@@ -32,6 +32,21 @@ func (s *Storage) storageSummaryEndpoint(w http.ResponseWriter, r *http.Request)
 	atomic.AddUint64(&s.stats.handledRequests, 1)
 }
 
+func (s *Storage) riotClientStatsEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.log.Debugln("Received Rest API RiotClientStats request from", r.RemoteAddr)
+
+	out, err := json.Marshal(s.GetRiotClientRequestStats())
+	if err != nil {
+		s.log.Errorf("Could not marshal Riot Client Stats to JSON: %s", err)
+		http.Error(w, utils.GenerateStatusResponse(http.StatusInternalServerError, fmt.Sprintf("Server error, try again later")), http.StatusInternalServerError)
+		return
+	}
+
+	io.WriteString(w, string(out))
+
+	atomic.AddUint64(&s.stats.handledRequests, 1)
+}
+
 func (s *Storage) getKnownVersionsEndpoint(w http.ResponseWriter, r *http.Request) {
 	s.log.Debugln("Received Rest API Known Versions request from", r.RemoteAddr)
 
@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"sort"
 	"time"
 
 	"git.abyle.org/hps/alolstats/riotclient"
@@ -15,6 +16,10 @@ type mockBackend struct {
 	championsStored      riotclient.ChampionsList
 	championsWhereStored bool
 
+	championDetails map[string]riotclient.ChampionDetails
+
+	crawlEntries map[string]CrawlEntry
+
 	failFreeRotation        bool
 	freeRotation            riotclient.FreeRotation
 	freeRotationRetrieved   bool
@@ -36,6 +41,10 @@ func (b *mockBackend) reset() {
 	b.championsStored = make(riotclient.ChampionsList)
 	b.championsWhereStored = false
 
+	b.championDetails = make(map[string]riotclient.ChampionDetails)
+
+	b.crawlEntries = make(map[string]CrawlEntry)
+
 	b.failFreeRotation = false
 	b.freeRotation = riotclient.FreeRotation{}
 	b.freeRotationRetrieved = false
@@ -53,6 +62,10 @@ func (b *mockBackend) Connect() error {
 	return nil
 }
 
+func (b *mockBackend) Close() error {
+	return nil
+}
+
 //
 // Champions
 //
@@ -102,6 +115,22 @@ func (b *mockBackend) StoreChampions(championList riotclient.ChampionsList) erro
 	return nil
 }
 
+func (b *mockBackend) GetChampionDetails(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	championDetails, ok := b.championDetails[championID+"/"+gameVersion+"/"+language]
+	if !ok {
+		return nil, fmt.Errorf("Champion Details not found")
+	}
+	return &championDetails, nil
+}
+
+func (b *mockBackend) StoreChampionDetails(gameVersion, language string, championDetails *riotclient.ChampionDetails) error {
+	if b.championDetails == nil {
+		b.championDetails = make(map[string]riotclient.ChampionDetails)
+	}
+	b.championDetails[championDetails.ID+"/"+gameVersion+"/"+language] = *championDetails
+	return nil
+}
+
 //
 // Free Rotation
 //
@@ -232,7 +261,7 @@ func (b *mockBackend) StoreSummoner(data *Summoner) error {
 // Match
 //
 
-func (b *mockBackend) GetMatch(id uint64) (*riotclient.MatchDTO, error) {
+func (b *mockBackend) GetMatch(platformID string, id uint64) (*riotclient.MatchDTO, error) {
 	return &riotclient.MatchDTO{}, nil
 }
 
@@ -256,15 +285,19 @@ func (b *mockBackend) GetStorageSummary() (Summary, error) {
 	return Summary{}, nil
 }
 
-func (b *mockBackend) GetLeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error) {
-	return &riotclient.LeagueListDTO{}, nil
+func (b *mockBackend) GetLeagueByQueue(region string, tier string, queue string) (*League, error) {
+	return &League{}, nil
 }
 
-func (b *mockBackend) GetLeagueByQueueTimeStamp(league string, queue string) (time.Time, error) {
+func (b *mockBackend) GetLeagueByQueueTimeStamp(region string, tier string, queue string) (time.Time, error) {
 	return time.Time{}, nil
 }
 
-func (b *mockBackend) StoreLeague(*riotclient.LeagueListDTO) error {
+func (b *mockBackend) GetLeagueHistoryByQueue(region string, tier string, queue string, from time.Time, to time.Time) ([]League, error) {
+	return []League{}, nil
+}
+
+func (b *mockBackend) StoreLeague(*League) error {
 	return nil
 }
 
@@ -288,7 +321,19 @@ func (b *mockBackend) GetLeaguesForSummonerBySummonerIDTimeStamp(summonerID stri
 	return time.Time{}, nil
 }
 
-func (b *mockBackend) GetMatchTimeLine(id uint64) (*riotclient.MatchTimelineDTO, error) {
+func (b *mockBackend) GetChampionMasteries(summonerID string) (*ChampionMasteries, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (b *mockBackend) GetChampionMasteriesTimeStamp(summonerID string) time.Time {
+	return time.Time{}
+}
+
+func (b *mockBackend) StoreChampionMasteries(masteries *ChampionMasteries) error {
+	return fmt.Errorf("Not implemented")
+}
+
+func (b *mockBackend) GetMatchTimeLine(platformID string, id uint64) (*riotclient.MatchTimelineDTO, error) {
 	return &riotclient.MatchTimelineDTO{}, nil
 }
 
@@ -320,23 +365,23 @@ func (b *mockBackend) StoreKnownGameVersions(gameVersions *GameVersions) error {
 	return fmt.Errorf("Not implemented")
 }
 
-func (b *mockBackend) GetMatchesCursorByGameVersion(gameVersion string) (QueryCursor, error) {
+func (b *mockBackend) GetMatchesCursorByGameVersion(platformID string, gameVersion string) (QueryCursor, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
 
-func (b *mockBackend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
+func (b *mockBackend) GetMatchesCursorByGameVersionChampionIDMapBetweenQueueIDs(platformID string, gameVersion string, championID uint64, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
 
-func (b *mockBackend) GetMatchesCursorByGameVersionChampionIDMapQueueID(gameVersion string, championID uint64, mapID uint64, queueid uint64) (QueryCursor, error) {
+func (b *mockBackend) GetMatchesCursorByGameVersionChampionIDMapQueueID(platformID string, gameVersion string, championID uint64, mapID uint64, queueid uint64) (QueryCursor, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
 
-func (b *mockBackend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
+func (b *mockBackend) GetMatchesCursorByGameVersionMapBetweenQueueIDs(platformID string, gameVersion string, mapID uint64, ltequeue uint64, gtequeue uint64) (QueryCursor, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
 
-func (b *mockBackend) GetMatchesCursorByGameVersionMapQueueID(gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error) {
+func (b *mockBackend) GetMatchesCursorByGameVersionMapQueueID(platformID string, gameVersion string, mapID uint64, queueid uint64) (QueryCursor, error) {
 	return nil, fmt.Errorf("Not implemented")
 }
 
@@ -395,3 +440,34 @@ func (b *mockBackend) GetRunesReforged(gameVersion, language string) (riotclient
 func (b *mockBackend) StoreRunesReforged(gameVersion, language string, runesReforgedList riotclient.RunesReforgedList) error {
 	return fmt.Errorf("Not implemented")
 }
+
+//
+// Crawl Frontier
+//
+
+func (b *mockBackend) GetCrawlEntry(region string, accountID string) (*CrawlEntry, error) {
+	entry, ok := b.crawlEntries[region+"/"+accountID]
+	if !ok {
+		return nil, fmt.Errorf("Crawl entry not found")
+	}
+	return &entry, nil
+}
+
+func (b *mockBackend) GetCrawlEntries(region string, minPriority int, crawledBefore time.Time, limit int) ([]CrawlEntry, error) {
+	entries := []CrawlEntry{}
+	for _, entry := range b.crawlEntries {
+		if entry.Region == region && entry.Priority >= minPriority && entry.LastCrawled.Before(crawledBefore) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return CrawlEntryLess(entries[i], entries[j]) })
+	if limit > 0 && len(entries) > limit {
+		entries = entries[:limit]
+	}
+	return entries, nil
+}
+
+func (b *mockBackend) StoreCrawlEntry(entry *CrawlEntry) error {
+	b.crawlEntries[entry.Region+"/"+entry.AccountID] = *entry
+	return nil
+}
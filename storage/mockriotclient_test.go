@@ -11,6 +11,10 @@ type mockClient struct {
 	champions          riotclient.ChampionsList
 	championsRetrieved bool
 
+	championDetails          *riotclient.ChampionDetails
+	championDetailsRetrieved int
+	championDetailsErr       error
+
 	failFreeRotation      bool
 	freeRotation          riotclient.FreeRotation
 	freeRotationRetrieved bool
@@ -18,6 +22,8 @@ type mockClient struct {
 	failSummoner         bool
 	summoner             riotclient.SummonerDTO
 	wasSummonerRetrieved bool
+
+	matchlistArgs map[string]string
 }
 
 func (c *mockClient) Start() {
@@ -75,7 +81,8 @@ func (c *mockClient) MatchByID(id uint64) (s *riotclient.MatchDTO, err error) {
 }
 
 func (c *mockClient) MatchesByAccountID(acountID string, args map[string]string) (s *riotclient.MatchlistDTO, err error) {
-	return nil, nil
+	c.matchlistArgs = args
+	return &riotclient.MatchlistDTO{}, nil
 }
 
 func (c *mockClient) LeagueByQueue(league string, queue string) (*riotclient.LeagueListDTO, error) {
@@ -118,6 +125,21 @@ func (c *mockClient) Champions() (s riotclient.ChampionsList, err error) {
 	return c.champions, nil
 }
 
+func (c *mockClient) ChampionDetailsSpecificVersionLanguage(championID, gameVersion, language string) (*riotclient.ChampionDetails, error) {
+	c.championDetailsRetrieved++
+
+	if c.championDetailsErr != nil {
+		return nil, c.championDetailsErr
+	}
+	if c.championDetails == nil || c.championDetails.ID != championID {
+		return nil, fmt.Errorf("Error retreiving champion details")
+	}
+
+	championDetails := *c.championDetails
+	championDetails.Version = gameVersion
+	return &championDetails, nil
+}
+
 func (c *mockClient) setFreeRotation(freeRotation riotclient.FreeRotation) {
 	c.freeRotation = freeRotation
 }
@@ -151,6 +173,22 @@ func (c *mockClient) LeaguesForSummoner(encSummonerID string) (*riotclient.Leagu
 	return nil, fmt.Errorf("Not implemented")
 }
 
+func (c *mockClient) LeagueEntries(queue string, tier string, division string, page int) ([]riotclient.LeagueEntryDTO, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *mockClient) ChampionMasteriesBySummonerID(encSummonerID string) (*riotclient.ChampionMasteryDTOList, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *mockClient) ChampionMasteryBySummonerIDChampionID(encSummonerID string, championID string) (*riotclient.ChampionMasteryDTO, error) {
+	return nil, fmt.Errorf("Not implemented")
+}
+
+func (c *mockClient) ChampionMasteryScoreBySummonerID(encSummonerID string) (int, error) {
+	return 0, fmt.Errorf("Not implemented")
+}
+
 func (c *mockClient) MatchTimeLineByID(matchID uint64) (t *riotclient.MatchTimelineDTO, err error) {
 	return nil, fmt.Errorf("Not implemented")
 }
@@ -14,7 +14,7 @@ func TestStorage_GetActiveGameBySummonerID(t *testing.T) {
 		config     config.LoLStorage
 		riotClient riotclient.Client
 		log        *logrus.Entry
-		stats      stats
+		stats      *stats
 		backend    Backend
 	}
 	type args struct {
@@ -55,7 +55,7 @@ func TestStorage_GetFeaturedGames(t *testing.T) {
 		config     config.LoLStorage
 		riotClient riotclient.Client
 		log        *logrus.Entry
-		stats      stats
+		stats      *stats
 		backend    Backend
 	}
 	tests := []struct {
@@ -25,15 +25,17 @@ func (s *Storage) getActiveGameBySummonerNameEndpoint(w http.ResponseWriter, r *
 
 	summoner, err := s.GetSummonerByName(summonerName, false)
 	if err != nil {
-		s.log.Warnf("Error getting SummonerByName data")
-		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, "Summoner "+summonerName+" not found"), http.StatusBadRequest)
+		s.log.Warnf("Error getting SummonerByName data: %s", err)
+		status := statusCodeForError(err, http.StatusBadRequest)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), "Summoner "+summonerName+" not found"), status)
 		return
 	}
 
 	activeGame, err := s.GetActiveGameBySummonerID(summoner.ID)
 	if err != nil {
 		s.log.Errorf("getActiveGameBySummonerNameEndpoint error %s", err)
-		http.Error(w, utils.GenerateStatusResponse(http.StatusNotFound, "Active Game for Summoner "+summonerName+" not found"), http.StatusNotFound)
+		status := statusCodeForError(err, http.StatusNotFound)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), "Active Game for Summoner "+summonerName+" not found"), status)
 		return
 	}
 
@@ -55,7 +57,8 @@ func (s *Storage) getFeaturedGamesEndpoint(w http.ResponseWriter, r *http.Reques
 	featuredGames, err := s.GetFeaturedGames()
 	if err != nil {
 		s.log.Errorf("Could not get featured games: %s", err)
-		http.Error(w, utils.GenerateStatusResponse(http.StatusInternalServerError, fmt.Sprintf("Server error, try again later")), http.StatusInternalServerError)
+		status := statusCodeForError(err, http.StatusInternalServerError)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), fmt.Sprintf("Server error, try again later")), status)
 		return
 	}
 
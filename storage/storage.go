@@ -3,8 +3,10 @@
 package storage
 
 import (
+	"context"
 	"fmt"
 	"path/filepath"
+	"strings"
 	"sync/atomic"
 
 	"git.abyle.org/hps/alolstats/config"
@@ -23,8 +25,9 @@ type Storage struct {
 	config      config.LoLStorage
 	riotClients map[string]riotclient.Client
 	riotClient  riotclient.Client // Default riotclient, should be removed at some point
+	platformIDs map[string]string // Platform ids of the regions of the riotclients, key = riotclient name
 	log         *logrus.Entry
-	stats       stats
+	stats       *stats
 	backend     Backend
 }
 
@@ -38,11 +41,22 @@ type Summary struct {
 // NewStorage creates a new Riot LoL API client
 func NewStorage(cfg config.LoLStorage, riotClients map[string]riotclient.Client, backend Backend) (*Storage, error) {
 	if client, ok := riotClients[cfg.DefaultRiotClient]; ok {
+		// Without a configured region the name of the riotclient is used as platform id
+		platformIDs := make(map[string]string)
+		for name, client := range riotClients {
+			platformIDs[name] = name
+			if c, ok := client.(riotclient.ClientRegion); ok && len(c.Region()) > 0 {
+				platformIDs[name] = c.Region()
+			}
+		}
+
 		s := &Storage{
 			config:      cfg,
 			riotClients: riotClients,
 			riotClient:  client,
+			platformIDs: platformIDs,
 			log:         logging.Get("Storage"),
+			stats:       &stats{},
 			backend:     backend,
 		}
 
@@ -51,6 +65,71 @@ func NewStorage(cfg config.LoLStorage, riotClients map[string]riotclient.Client,
 	return nil, fmt.Errorf("Error creating Storage. Requested default region RiotAPI does not exist: %s", cfg.DefaultRiotClient)
 }
 
+// WithPriority returns a Storage which does all its Riot API calls with the given priority, if the Riot clients support it.
+// It shares config, stats and backend with s.
+func (s *Storage) WithPriority(priority riotclient.Priority) *Storage {
+	return s.withClients(func(client riotclient.Client) riotclient.Client {
+		if c, ok := client.(riotclient.ClientPriority); ok {
+			return c.WithPriority(priority)
+		}
+		return client
+	})
+}
+
+// WithContext returns a Storage which does all its Riot API calls with the given context, if the Riot clients support it.
+// It shares config, stats and backend with s.
+func (s *Storage) WithContext(ctx context.Context) *Storage {
+	return s.withClients(func(client riotclient.Client) riotclient.Client {
+		if c, ok := client.(riotclient.ClientContext); ok {
+			return c.WithContext(ctx)
+		}
+		return client
+	})
+}
+
+// withClients returns a copy of s with all Riot clients replaced by the result of f
+func (s *Storage) withClients(f func(riotclient.Client) riotclient.Client) *Storage {
+	riotClients := make(map[string]riotclient.Client)
+	for region, client := range s.riotClients {
+		riotClients[region] = f(client)
+	}
+
+	return &Storage{
+		config:      s.config,
+		riotClients: riotClients,
+		riotClient:  f(s.riotClient),
+		platformIDs: s.platformIDs,
+		log:         s.log,
+		stats:       s.stats,
+		backend:     s.backend,
+	}
+}
+
+// platformID returns the platform id of the matches fetched by the riotclient with the given name
+func (s *Storage) platformID(region string) string {
+	if platformID, ok := s.platformIDs[region]; ok {
+		return platformID
+	}
+	return region
+}
+
+// clientForPlatform returns the riotclient fetching the matches of the given platform id. If several riotclients are configured
+// for its region, the one named like the platform id is preferred.
+func (s *Storage) clientForPlatform(platformID string) (riotclient.Client, bool) {
+	name := strings.ToLower(platformID)
+	if strings.EqualFold(s.platformID(name), platformID) {
+		if client, ok := s.riotClients[name]; ok {
+			return client, true
+		}
+	}
+	for region, clientPlatformID := range s.platformIDs {
+		if strings.EqualFold(clientPlatformID, platformID) {
+			return s.riotClients[region], true
+		}
+	}
+	return nil, false
+}
+
 // Start starts the storage runners
 func (s *Storage) Start() {
 	s.log.Info("Starting Storage")
@@ -86,6 +165,17 @@ func (s *Storage) GetHandeledRequests() uint64 {
 	return atomic.LoadUint64(&s.stats.handledRequests)
 }
 
+// GetRiotClientRequestStats gets the counters of the requests sent to the Riot API per region, for all Riot clients which count them
+func (s *Storage) GetRiotClientRequestStats() map[string]riotclient.RequestStats {
+	requestStats := make(map[string]riotclient.RequestStats)
+	for region, client := range s.riotClients {
+		if c, ok := client.(riotclient.ClientRequestStats); ok {
+			requestStats[region] = c.RequestStats()
+		}
+	}
+	return requestStats
+}
+
 // GameVersions struct is a list of game versions in the format major.minor, e.g., 8.24 or 9.1.
 type GameVersions struct {
 	Versions []string `json:"versions"`
@@ -1,6 +1,8 @@
 package storage
 
 import (
+	"context"
+	"reflect"
 	"testing"
 
 	"git.abyle.org/hps/alolstats/config"
@@ -22,3 +24,101 @@ func TestCreatingNewStorage(t *testing.T) {
 		t.Fatalf("Could not get a new Storage: %s", err)
 	}
 }
+
+type mockPriorityClient struct {
+	*mockClient
+	priority riotclient.Priority
+	ctx      context.Context
+}
+
+func (c *mockPriorityClient) WithPriority(priority riotclient.Priority) riotclient.Client {
+	return &mockPriorityClient{mockClient: c.mockClient, priority: priority, ctx: c.ctx}
+}
+
+func (c *mockPriorityClient) WithContext(ctx context.Context) riotclient.Client {
+	return &mockPriorityClient{mockClient: c.mockClient, priority: c.priority, ctx: ctx}
+}
+
+func TestStorageWithPriority(t *testing.T) {
+	config := config.LoLStorage{}
+	config.DefaultRiotClient = "euw1"
+	backend := &mockBackend{}
+
+	riotClients := map[string]riotclient.Client{
+		"euw1": &mockPriorityClient{mockClient: &mockClient{}},
+		"na1":  &mockClient{},
+	}
+	storage, err := NewStorage(config, riotClients, backend)
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	background := storage.WithPriority(riotclient.PriorityBackground)
+	if background.backend != storage.backend {
+		t.Errorf("Expected the same backend")
+	}
+	if client, ok := background.riotClients["euw1"].(*mockPriorityClient); !ok || client.priority != riotclient.PriorityBackground {
+		t.Errorf("Expected euw1 client with background priority, got %v", background.riotClients["euw1"])
+	}
+	if client, ok := background.riotClient.(*mockPriorityClient); !ok || client.priority != riotclient.PriorityBackground {
+		t.Errorf("Expected default client with background priority, got %v", background.riotClient)
+	}
+	if background.riotClients["na1"] != riotClients["na1"] {
+		t.Errorf("Expected na1 client without priority support to be used as is")
+	}
+	if client := storage.riotClients["euw1"].(*mockPriorityClient); client.priority != riotclient.PriorityInteractive {
+		t.Errorf("Expected original Storage to keep interactive priority")
+	}
+}
+
+func TestStorageWithContext(t *testing.T) {
+	config := config.LoLStorage{}
+	config.DefaultRiotClient = "euw1"
+
+	storage, err := NewStorage(config, map[string]riotclient.Client{"euw1": &mockPriorityClient{mockClient: &mockClient{}}}, &mockBackend{})
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	withContext := storage.WithPriority(riotclient.PriorityBulk).WithContext(ctx)
+	client, ok := withContext.riotClients["euw1"].(*mockPriorityClient)
+	if !ok || client.ctx != ctx || client.priority != riotclient.PriorityBulk {
+		t.Errorf("Expected euw1 client with context and bulk priority, got %v", withContext.riotClients["euw1"])
+	}
+
+	withContext.stats.handledRequests++
+	if storage.GetHandeledRequests() != 1 {
+		t.Errorf("Expected Storages with context to share the stats")
+	}
+}
+
+type mockRequestStatsClient struct {
+	*mockClient
+	requestStats riotclient.RequestStats
+}
+
+func (c *mockRequestStatsClient) RequestStats() riotclient.RequestStats {
+	return c.requestStats
+}
+
+func TestStorageGetRiotClientRequestStats(t *testing.T) {
+	config := config.LoLStorage{}
+	config.DefaultRiotClient = "euw1"
+
+	euw1 := riotclient.RequestStats{Requests: 10, Retries: map[string]uint64{"Server Error": 2}}
+	riotClients := map[string]riotclient.Client{
+		"euw1": &mockRequestStatsClient{mockClient: &mockClient{}, requestStats: euw1},
+		"na1":  &mockClient{},
+	}
+	storage, err := NewStorage(config, riotClients, &mockBackend{})
+	if err != nil || storage == nil {
+		t.Fatalf("Could not get a new Storage: %s", err)
+	}
+
+	want := map[string]riotclient.RequestStats{"euw1": euw1}
+	if got := storage.GetRiotClientRequestStats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetRiotClientRequestStats() = %v, want %v", got, want)
+	}
+}
@@ -37,9 +37,9 @@ func (s *Storage) getSummonerByNameFromClient(client riotclient.Client, name str
 		summoner, err := s.riotClient.SummonerByName(name)
 		if err != nil {
 			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
-			summoner, err := s.backend.GetSummonerByName(name)
-			if err != nil {
-				s.log.Warnln("Could not get data from either Storage nor Client:", err)
+			summoner, errBackend := s.backend.GetSummonerByName(name)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
 				return riotclient.SummonerDTO{}, err
 			}
 			s.log.Debugf("Returned Summoner %s from Storage", name)
@@ -97,9 +97,9 @@ func (s *Storage) getSummonerBySummonerIDFromClient(client riotclient.Client, su
 		summoner, err := client.SummonerBySummonerID(summonerID)
 		if err != nil {
 			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
-			summoner, err := s.backend.GetSummonerBySummonerID(summonerID)
-			if err != nil {
-				s.log.Warnln("Could not get data from either Storage nor Client:", err)
+			summoner, errBackend := s.backend.GetSummonerBySummonerID(summonerID)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
 				return riotclient.SummonerDTO{}, err
 			}
 			s.log.Debugf("Returned Summoner with SummonerID %s from Storage", summonerID)
@@ -146,19 +146,20 @@ func (s *Storage) GetRegionalSummonerBySummonerID(region string, summonerID stri
 	return riotclient.SummonerDTO{}, fmt.Errorf("Invalid region specified: %s", region)
 }
 
-// GetSummonerByAccountID returns a Summoner identified by Account ID
-func (s *Storage) GetSummonerByAccountID(accountID string, forceUpdate bool) (riotclient.SummonerDTO, error) {
+// getSummonerByAccountIDFromClient returns a Summoner identified by Account ID
+// forceUpdate will try to update the Summoner, if it is false the config settings will be considered if update is required
+func (s *Storage) getSummonerByAccountIDFromClient(client riotclient.Client, accountID string, forceUpdate bool) (riotclient.SummonerDTO, error) {
 	if len(accountID) == 0 {
 		return riotclient.SummonerDTO{}, fmt.Errorf("Account ID cannot be empty")
 	}
 	duration := time.Since(s.backend.GetSummonerByAccountIDTimeStamp(accountID))
 	if (duration.Minutes() > float64(s.config.MaxAgeSummoner)) || forceUpdate {
-		summoner, err := s.riotClient.SummonerByAccountID(accountID)
+		summoner, err := client.SummonerByAccountID(accountID)
 		if err != nil {
 			s.log.Warnln("Could not get new data from Client, trying to get it from Storage instead", err)
-			summoner, err := s.backend.GetSummonerByAccountID(accountID)
-			if err != nil {
-				s.log.Warnln("Could not get data from either Storage nor Client:", err)
+			summoner, errBackend := s.backend.GetSummonerByAccountID(accountID)
+			if errBackend != nil {
+				s.log.Warnln("Could not get data from either Storage nor Client:", errBackend)
 				return riotclient.SummonerDTO{}, err
 			}
 			s.log.Debugf("Returned Summoner with AccountID %s from Storage", accountID)
@@ -173,7 +174,7 @@ func (s *Storage) GetSummonerByAccountID(accountID string, forceUpdate bool) (ri
 	}
 	summoner, err := s.backend.GetSummonerByAccountID(accountID)
 	if err != nil {
-		summoner, errClient := s.riotClient.SummonerByAccountID(accountID)
+		summoner, errClient := client.SummonerByAccountID(accountID)
 		if errClient != nil {
 			s.log.Warnln("Could not get data from either Storage nor Client:", errClient)
 			return riotclient.SummonerDTO{}, errClient
@@ -189,3 +190,17 @@ func (s *Storage) GetSummonerByAccountID(accountID string, forceUpdate bool) (ri
 	s.log.Debugf("Returned Summoner with AccountID %s from Storage", accountID)
 	return summoner.SummonerDTO, nil
 }
+
+// GetSummonerByAccountID returns a Summoner identified by Account ID
+func (s *Storage) GetSummonerByAccountID(accountID string, forceUpdate bool) (riotclient.SummonerDTO, error) {
+	return s.getSummonerByAccountIDFromClient(s.riotClient, accountID, forceUpdate)
+}
+
+// GetRegionalSummonerByAccountID returns a Summoner identified by Account ID for a specific region
+// forceUpdate will try to update the Summoner, if it is false the config settings will be considered if update is required
+func (s *Storage) GetRegionalSummonerByAccountID(region string, accountID string, forceUpdate bool) (riotclient.SummonerDTO, error) {
+	if client, ok := s.riotClients[region]; ok {
+		return s.getSummonerByAccountIDFromClient(client, accountID, forceUpdate)
+	}
+	return riotclient.SummonerDTO{}, fmt.Errorf("Invalid region specified: %s", region)
+}
@@ -25,7 +25,7 @@ type SummonerResponse struct {
 func (s *Storage) prepareSummonerResponse(summonerName string, forceUpdate bool) (*SummonerResponse, error) {
 	summoner, err := s.GetSummonerByName(summonerName, forceUpdate)
 	if err != nil {
-		return nil, fmt.Errorf("Error getting SummonerByName data")
+		return nil, riotclient.WrapError(err, "Error getting SummonerByName data")
 	}
 
 	summonerResponse := SummonerResponse{
@@ -58,7 +58,9 @@ func (s *Storage) summonerByNameEndpoint(w http.ResponseWriter, r *http.Request)
 
 	summonerResponse, err := s.prepareSummonerResponse(summonerName, false)
 	if err != nil {
-		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, err.Error()), http.StatusBadRequest)
+		status := statusCodeForError(err, http.StatusBadRequest)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), err.Error()), status)
+		return
 	}
 
 	out, err := json.Marshal(summonerResponse)
@@ -73,3 +75,59 @@ func (s *Storage) summonerByNameEndpoint(w http.ResponseWriter, r *http.Request)
 
 	atomic.AddUint64(&s.stats.handledRequests, 1)
 }
+
+// SummonerMasteryResponse contains the Champion Masteries of a summoner
+type SummonerMasteryResponse struct {
+	Name       string                          `json:"name"`
+	TotalScore int                             `json:"totalScore"`
+	Masteries  []riotclient.ChampionMasteryDTO `json:"masteries"`
+	Timestamp  time.Time                       `json:"timestamp"`
+}
+
+func (s *Storage) prepareSummonerMasteryResponse(summonerName string, forceUpdate bool) (*SummonerMasteryResponse, error) {
+	summoner, err := s.GetSummonerByName(summonerName, forceUpdate)
+	if err != nil {
+		return nil, riotclient.WrapError(err, "Error getting SummonerByName data")
+	}
+
+	masteries, err := s.GetChampionMasteriesBySummonerID(summoner.ID, forceUpdate)
+	if err != nil {
+		return nil, riotclient.WrapError(err, "Error getting Champion Mastery data")
+	}
+
+	return &SummonerMasteryResponse{
+		Name:       summoner.Name,
+		TotalScore: ChampionMasteryScore(&masteries),
+		Masteries:  masteries.ChampionMasteries,
+		Timestamp:  masteries.Timestamp,
+	}, nil
+}
+
+func (s *Storage) summonerMasteryEndpoint(w http.ResponseWriter, r *http.Request) {
+	s.log.Debugln("Received Rest API SummonerMastery request from", r.RemoteAddr)
+
+	summonerName, err := extractURLStringParameter(r.URL.Query(), "name")
+	if err != nil {
+		http.Error(w, utils.GenerateStatusResponse(http.StatusBadRequest, err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	masteryResponse, err := s.prepareSummonerMasteryResponse(summonerName, false)
+	if err != nil {
+		status := statusCodeForError(err, http.StatusBadRequest)
+		http.Error(w, utils.GenerateStatusResponse(uint16(status), err.Error()), status)
+		return
+	}
+
+	out, err := json.Marshal(masteryResponse)
+	if err != nil {
+		s.log.Errorf("Could not marshal Champion Mastery data to JSON: %s", err)
+		http.Error(w, utils.GenerateStatusResponse(http.StatusInternalServerError, fmt.Sprintf("Server error, try again later")), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Cache-Control", s.getHTTPGetResponseHeader("Cache-Control"))
+	io.WriteString(w, string(out))
+
+	atomic.AddUint64(&s.stats.handledRequests, 1)
+}
@@ -2,7 +2,10 @@ package storage
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
+
+	"git.abyle.org/hps/alolstats/riotclient"
 )
 
 func extractURLStringParameter(parameters url.Values, parameterName string) (string, error) {
@@ -19,3 +22,22 @@ func extractURLStringParameter(parameters url.Values, parameterName string) (str
 	}
 	return parameterValue, nil
 }
+
+// statusCodeForError returns the HTTP status code to respond with when a request failed because of err.
+// Errors which are not caused by the Riot API get the fallback status code.
+func statusCodeForError(err error, fallback int) int {
+	switch riotclient.KindOf(err) {
+	case riotclient.ErrorNotFound:
+		return http.StatusNotFound
+	case riotclient.ErrorBadRequest:
+		return http.StatusBadRequest
+	case riotclient.ErrorForbidden, riotclient.ErrorServerError, riotclient.ErrorTransport:
+		return http.StatusBadGateway
+	case riotclient.ErrorRateLimited, riotclient.ErrorCanceled:
+		return http.StatusServiceUnavailable
+	case riotclient.ErrorTimeout:
+		return http.StatusGatewayTimeout
+	default:
+		return fallback
+	}
+}
@@ -1,8 +1,12 @@
 package storage
 
 import (
+	"fmt"
+	"net/http"
 	"net/url"
 	"testing"
+
+	"git.abyle.org/hps/alolstats/riotclient"
 )
 
 func Test_extractURLStringParameter(t *testing.T) {
@@ -74,3 +78,24 @@ func Test_extractURLStringParameter(t *testing.T) {
 		})
 	}
 }
+
+func Test_statusCodeForError(t *testing.T) {
+	tests := []struct {
+		err  error
+		want int
+	}{
+		{fmt.Errorf("Some backend error"), http.StatusBadRequest},
+		{riotclient.NewStatusCodeError(404), http.StatusNotFound},
+		{riotclient.NewStatusCodeError(400), http.StatusBadRequest},
+		{riotclient.NewStatusCodeError(403), http.StatusBadGateway},
+		{riotclient.NewStatusCodeError(500), http.StatusBadGateway},
+		{riotclient.NewStatusCodeError(429), http.StatusServiceUnavailable},
+		{riotclient.NewError(riotclient.ErrorTimeout, "API call timed out"), http.StatusGatewayTimeout},
+		{riotclient.WrapError(riotclient.NewStatusCodeError(404), "Error getting SummonerByName data"), http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		if got := statusCodeForError(tt.err, http.StatusBadRequest); got != tt.want {
+			t.Errorf("statusCodeForError(%s) = %d, want %d", tt.err, got, tt.want)
+		}
+	}
+}